@@ -0,0 +1,123 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// FFTConfig describes an FFT offloaded to an [Accelerator].
+type FFTConfig struct {
+	// Inverse is set for the inverse transform, including the scaling by 1/n.
+	Inverse bool
+	// DIF is set for the decimation in frequency, which takes its input in
+	// regular order and returns it in bit-reversed order. Otherwise the
+	// decimation is in time, from bit-reversed to regular order.
+	DIF bool
+	// OnCoset is set for the transform on the coset of the domain by the
+	// multiplicative generator of the field.
+	OnCoset bool
+}
+
+// MultiExpConfig describes a multi-exponentiation offloaded to an
+// [Accelerator].
+type MultiExpConfig struct {
+	// Points identifies the points of the proving key the multi-exponentiation
+	// is computed over, for the accelerators holding them in advance. It is one
+	// of the Points* constants, or empty for points that are not identified,
+	// such as the commitment keys.
+	Points string
+}
+
+// Identifiers of the points of a Groth16 proving key, see
+// [MultiExpConfig.Points]. The prover may use only a prefix of the points,
+// when there are fewer scalars than points.
+const (
+	PointsGroth16G1A = "groth16.pk.G1.A"
+	PointsGroth16G1B = "groth16.pk.G1.B"
+	PointsGroth16G1Z = "groth16.pk.G1.Z"
+	PointsGroth16G1K = "groth16.pk.G1.K"
+	PointsGroth16G2B = "groth16.pk.G2.B"
+)
+
+// Accelerator offloads the large multi-exponentiations and FFTs of the
+// provers to an external engine, for instance on a GPU. It is registered with
+// [RegisterAccelerator] and selected with [WithProverAccelerator], or given to
+// a single call with [WithProverAcceleratorEngine].
+//
+// The arguments are the gnark-crypto types of the curve of the proof. The
+// methods return [ErrAcceleratorUnsupported] for the curves or operations the
+// engine doesn't support, the prover then computes them on the CPU. Any other
+// error aborts the proof.
+//
+// ctx is the context of the prover, with its deadline if any (see
+// [WithProverContext] and [WithProverDeadline]). The engines should abandon the
+// operation and return ctx.Err() when it is done.
+type Accelerator interface {
+	// MultiExp sets res to ∑ scalars[i]·points[i]. For a curve C, res is a
+	// *C.G1Jac with points a []C.G1Affine, or a *C.G2Jac with points a
+	// []C.G2Affine, and scalars is a []fr.Element of C. There may be fewer
+	// scalars than points, in which case only the first points are used.
+	MultiExp(ctx context.Context, curve ecc.ID, res, points, scalars any, config MultiExpConfig) error
+
+	// FFT transforms a in place. For a curve C, a is a []fr.Element of C and
+	// domain is a *fft.Domain of C of the size of a.
+	FFT(ctx context.Context, curve ecc.ID, a, domain any, config FFTConfig) error
+}
+
+var (
+	accelerators     = make(map[string]Accelerator)
+	acceleratorsLock sync.RWMutex
+)
+
+// RegisterAccelerator registers the accelerator under the name, replacing the
+// accelerator previously registered under it, if any. A nil accelerator
+// unregisters the name.
+func RegisterAccelerator(name string, a Accelerator) {
+	acceleratorsLock.Lock()
+	defer acceleratorsLock.Unlock()
+	if a == nil {
+		delete(accelerators, name)
+		return
+	}
+	accelerators[name] = a
+}
+
+// GetAccelerator returns the accelerator registered under the name, or nil.
+func GetAccelerator(name string) Accelerator {
+	acceleratorsLock.RLock()
+	defer acceleratorsLock.RUnlock()
+	return accelerators[name]
+}
+
+// WithProverAccelerator requests the prover to offload its large
+// multi-exponentiations and FFTs to the accelerator registered under the name
+// with [RegisterAccelerator].
+func WithProverAccelerator(name string) ProverOption {
+	return func(pc *ProverConfig) error {
+		if GetAccelerator(name) == nil {
+			return fmt.Errorf("no accelerator registered under %q", name)
+		}
+		pc.Accelerator = name
+		pc.AcceleratorEngine = GetAccelerator(name)
+		return nil
+	}
+}
+
+// WithProverAcceleratorEngine requests the prover to offload its large
+// multi-exponentiations and FFTs to the accelerator a. Unlike
+// [WithProverAccelerator], a is not registered and is only used by the call
+// the option is given to.
+func WithProverAcceleratorEngine(a Accelerator) ProverOption {
+	return func(pc *ProverConfig) error {
+		if a == nil {
+			return errors.New("nil accelerator")
+		}
+		pc.Accelerator = fmt.Sprintf("%T", a)
+		pc.AcceleratorEngine = a
+		return nil
+	}
+}
@@ -16,10 +16,52 @@
 package backend
 
 import (
+	"context"
 	"crypto/sha256"
+	"errors"
+	"fmt"
 	"hash"
+	"io"
+	"time"
 
-	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/internal/errs"
+)
+
+var (
+	// ErrUnsatisfiedConstraint is returned (wrapped) by the provers when the
+	// witness does not satisfy the constraints of the circuit.
+	ErrUnsatisfiedConstraint = errs.ErrUnsatisfiedConstraint
+
+	// ErrInvalidWitnessSize is returned (wrapped) by the provers and the
+	// verifiers when the number of values in the witness doesn't match the
+	// circuit.
+	ErrInvalidWitnessSize = errs.ErrInvalidWitnessSize
+
+	// ErrCurveMismatch is returned (wrapped) when the constraint system, the
+	// keys, the proof and the witness are not all defined over the same curve.
+	ErrCurveMismatch = errs.ErrCurveMismatch
+
+	// ErrDomainTooSmall is returned (wrapped) when the evaluation domain of the
+	// circuit is too small for the proof system.
+	ErrDomainTooSmall = errors.New("domain is too small")
+
+	// ErrSRSTooSmall is returned (wrapped) when the structured reference string
+	// is too small for the circuit.
+	ErrSRSTooSmall = errors.New("srs is too small")
+
+	// ErrInsufficientMemoryBudget is returned (wrapped) by the provers when the
+	// memory they need exceeds the budget set with [WithProverMemoryBudget].
+	ErrInsufficientMemoryBudget = errors.New("insufficient memory budget")
+
+	// ErrAcceleratorUnsupported is returned by an [Accelerator] for the
+	// operations it doesn't support. The prover then computes them on the CPU.
+	ErrAcceleratorUnsupported = errors.New("operation not supported by the accelerator")
+
+	// ErrRerandomizeCommitments is returned when re-randomizing a Groth16 proof
+	// of a circuit committing to witness variables. The commitments can't be
+	// re-randomized as they determine the public inputs derived from them, so
+	// they would link the proof to the original one.
+	ErrRerandomizeCommitments = errors.New("can't re-randomize a proof with commitments")
 )
 
 // ID represent a unique ID for a proving scheme
@@ -54,12 +96,28 @@ func (id ID) String() string {
 type ProverOption func(*ProverConfig) error
 
 // ProverConfig is the configuration for the prover with the options applied.
+//
+// The solver options (SolverOpts) are not part of the configuration in
+// verifier_only builds, which don't link the constraint system solver.
 type ProverConfig struct {
-	SolverOpts     []solver.Option
-	HashToFieldFn  hash.Hash
-	ChallengeHash  hash.Hash
-	KZGFoldingHash hash.Hash
-	Accelerator    string
+	solverConfig
+	HashToFieldFn     hash.Hash
+	ChallengeHash     hash.Hash
+	KZGFoldingHash    hash.Hash
+	Accelerator       string
+	AcceleratorEngine Accelerator
+	RandomSource      io.Reader
+	NoBlinding        bool
+	SelfCheck         bool
+	MemoryBudget      uint64
+	SecurityLevel     int
+	Context           context.Context
+	Progress          func(stage string, progress float64)
+	StageTimings      func(stage string, elapsed time.Duration)
+	Deadline          time.Time
+	NbTasks           int
+
+	IgnoreUnsatisfiedConstraints bool
 }
 
 // NewProverConfig returns a default ProverConfig with given prover options opts
@@ -70,19 +128,27 @@ func NewProverConfig(opts ...ProverOption) (ProverConfig, error) {
 		// separation tags for PLONK and Groth16
 		ChallengeHash:  sha256.New(),
 		KZGFoldingHash: sha256.New(),
+		Context:        context.Background(),
 	}
 	for _, option := range opts {
 		if err := option(&opt); err != nil {
 			return ProverConfig{}, err
 		}
 	}
+	opt.applySolverOptions()
 	return opt, nil
 }
 
-// WithSolverOptions specifies the constraint system solver options.
-func WithSolverOptions(solverOpts ...solver.Option) ProverOption {
+// WithNbTasks sets the number of parallel workers of the solver (see
+// [solver.WithNbTasks]) and of the multi-exponentiations and FFTs of the
+// prover. By default the number of CPUs is used. The number of tasks of the
+// verifiers is set with [WithVerifierNbTasks].
+func WithNbTasks(nbTasks int) ProverOption {
 	return func(opt *ProverConfig) error {
-		opt.SolverOpts = solverOpts
+		if nbTasks <= 0 {
+			return fmt.Errorf("invalid number of tasks: %d", nbTasks)
+		}
+		opt.NbTasks = nbTasks
 		return nil
 	}
 }
@@ -120,6 +186,150 @@ func WithProverKZGFoldingHashFunction(hFunc hash.Hash) ProverOption {
 	}
 }
 
+// WithProverRandomSource sets the source from which the prover samples its
+// randomness (the zero-knowledge blinding factors). If not set then
+// crypto/rand is used.
+//
+// The prover samples the random values sequentially from the source before
+// starting any parallel computation, so that with a fixed source the generated
+// proof is bit-identical regardless of the number of threads. This is meant
+// for reproducible builds, audits and tests: the source must be
+// cryptographically secure and never reused for proofs which must be
+// zero-knowledge.
+func WithProverRandomSource(r io.Reader) ProverOption {
+	return func(pc *ProverConfig) error {
+		pc.RandomSource = r
+		return nil
+	}
+}
+
+// WithProverNoBlinding sets all the blinding factors of the PLONK prover to
+// zero. The resulting proofs are valid but NOT zero-knowledge: they leak
+// information about the witness. This is meant for benchmarking and debugging
+// only. The option is ignored by the Groth16 prover.
+func WithProverNoBlinding() ProverOption {
+	return func(pc *ProverConfig) error {
+		pc.NoBlinding = true
+		return nil
+	}
+}
+
+// WithProverSelfCheck makes the prover check its computations before returning
+// the proof. This detects a proof corrupted by a faulty computation (e.g.
+// silent memory corruption in a multi-scalar multiplication).
+//
+// The PLONK prover verifies the proof against the verifying key embedded in
+// the proving key, at the cost of a verification. The Groth16 proving key
+// doesn't embed the verifying key, so the Groth16 prover instead recomputes
+// its multi-exponentiations on the CPU, which doubles their cost, and checks
+// the quotient against the solution at a random point.
+func WithProverSelfCheck() ProverOption {
+	return func(pc *ProverConfig) error {
+		pc.SelfCheck = true
+		return nil
+	}
+}
+
+// WithProverMemoryBudget sets the maximal number of bytes the prover may
+// allocate. Before solving the constraint system, the prover estimates the
+// memory it needs, excluding the constraint system, the witness and the
+// proving key. If the estimate exceeds the budget, the Groth16 prover first
+// falls back to computing its multi-exponentiations one at a time. If the
+// budget is still exceeded, the provers return an error wrapping
+// [ErrInsufficientMemoryBudget] instead of running out of memory mid-proof. A
+// zero budget means no limit.
+func WithProverMemoryBudget(nbBytes uint64) ProverOption {
+	return func(pc *ProverConfig) error {
+		pc.MemoryBudget = nbBytes
+		return nil
+	}
+}
+
+// WithProverSecurityLevel makes the prover check the curve, the hash functions
+// and the SRS against the security level in bits (e.g. 100 or 128) before
+// proving, and fail if any of them is below it. See the security package for
+// the checks. A zero level disables the checks.
+func WithProverSecurityLevel(nbBits int) ProverOption {
+	return func(pc *ProverConfig) error {
+		if nbBits < 0 {
+			return fmt.Errorf("invalid security level %d", nbBits)
+		}
+		pc.SecurityLevel = nbBits
+		return nil
+	}
+}
+
+// WithProverContext sets the context of the prover. When the context is
+// cancelled or its deadline exceeded, the prover stops at the end of the
+// current stage and returns the error of the context.
+func WithProverContext(ctx context.Context) ProverOption {
+	return func(pc *ProverConfig) error {
+		if ctx == nil {
+			return errors.New("nil context")
+		}
+		pc.Context = ctx
+		return nil
+	}
+}
+
+// WithProverProgress sets a callback called by the prover each time it
+// completes a stage, with the name of the stage and the fraction of the stages
+// completed, in (0, 1]. The callback is called from the goroutines of the
+// prover, one call at a time, and must return quickly.
+func WithProverProgress(progress func(stage string, progress float64)) ProverOption {
+	return func(pc *ProverConfig) error {
+		pc.Progress = progress
+		return nil
+	}
+}
+
+// WithProverStageTimings sets a callback called by the prover each time it
+// completes a stage, with the name of the stage and the time elapsed since the
+// prover started. As for [WithProverProgress], the callback is called one call
+// at a time and must return quickly.
+func WithProverStageTimings(timings func(stage string, elapsed time.Duration)) ProverOption {
+	return func(pc *ProverConfig) error {
+		pc.StageTimings = timings
+		return nil
+	}
+}
+
+// WithProverDeadline sets a deadline to the prover. The prover checks it
+// between its stages, and returns a [*DeadlineExceededError] holding the
+// timings of the completed stages when it is exceeded. The deadline applies in
+// addition to the context set with [WithProverContext].
+func WithProverDeadline(deadline time.Time) ProverOption {
+	return func(pc *ProverConfig) error {
+		pc.Deadline = deadline
+		return nil
+	}
+}
+
+// StageTiming is the completion time of a stage of the prover.
+type StageTiming struct {
+	Stage string
+	// Elapsed is the time elapsed since the prover started.
+	Elapsed time.Duration
+}
+
+// DeadlineExceededError is returned by the provers when the deadline set with
+// [WithProverDeadline] is exceeded. It matches [context.DeadlineExceeded] with
+// [errors.Is].
+type DeadlineExceededError struct {
+	Deadline time.Time
+	// Completed holds the stages completed before the prover aborted, in
+	// order of completion.
+	Completed []StageTiming
+}
+
+func (e *DeadlineExceededError) Error() string {
+	return fmt.Sprintf("prover deadline %s exceeded after %d completed stages", e.Deadline.Format(time.RFC3339Nano), len(e.Completed))
+}
+
+func (e *DeadlineExceededError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
 // WithIcicleAcceleration requests to use [ICICLE] GPU proving backend for the
 // prover. This option requires that the program is compiled with `icicle` build
 // tag and the ICICLE dependencies are properly installed. See [ICICLE] for
@@ -133,9 +343,49 @@ func WithIcicleAcceleration() ProverOption {
 	}
 }
 
+// SetupOption defines option for altering the behavior of the setup. See the
+// descriptions of functions returning instances of this type for implemented
+// options.
+type SetupOption func(*SetupConfig) error
+
+// SetupConfig is the configuration for the setup with the options applied.
+type SetupConfig struct {
+	SecurityLevel int
+}
+
+// NewSetupConfig returns a default [SetupConfig] with given setup options
+// applied.
+func NewSetupConfig(opts ...SetupOption) (SetupConfig, error) {
+	var opt SetupConfig
+	for _, option := range opts {
+		if err := option(&opt); err != nil {
+			return SetupConfig{}, err
+		}
+	}
+	return opt, nil
+}
+
+// WithSetupSecurityLevel makes the setup check the curve and the SRS against
+// the security level in bits (e.g. 100 or 128), and fail if any of them is
+// below it. See the security package for the checks. A zero level disables the
+// checks.
+func WithSetupSecurityLevel(nbBits int) SetupOption {
+	return func(sc *SetupConfig) error {
+		if nbBits < 0 {
+			return fmt.Errorf("invalid security level %d", nbBits)
+		}
+		sc.SecurityLevel = nbBits
+		return nil
+	}
+}
+
 // VerifierOption defines option for altering the behavior of the verifier. See
 // the descriptions of functions returning instances of this type for
 // implemented options.
+//
+// The verifiers don't solve the constraint system, so that the solver options
+// of the provers ([WithHints], [WithSolverLogger] and
+// [WithProverIgnoreUnsatisfiedConstraints]) have no verifier counterpart.
 type VerifierOption func(*VerifierConfig) error
 
 // VerifierConfig is the configuration for the verifier with the options applied.
@@ -143,6 +393,7 @@ type VerifierConfig struct {
 	HashToFieldFn  hash.Hash
 	ChallengeHash  hash.Hash
 	KZGFoldingHash hash.Hash
+	NbTasks        int
 }
 
 // NewVerifierConfig returns a default [VerifierConfig] with given verifier
@@ -194,3 +445,16 @@ func WithVerifierKZGFoldingHashFunction(hFunc hash.Hash) VerifierOption {
 		return nil
 	}
 }
+
+// WithVerifierNbTasks sets the number of parallel tasks of the
+// multi-exponentiations of the verifier. By default it is set by gnark-crypto
+// from the number of CPUs.
+func WithVerifierNbTasks(nbTasks int) VerifierOption {
+	return func(pc *VerifierConfig) error {
+		if nbTasks <= 0 {
+			return fmt.Errorf("invalid number of tasks: %d", nbTasks)
+		}
+		pc.NbTasks = nbTasks
+		return nil
+	}
+}
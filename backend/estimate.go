@@ -0,0 +1,90 @@
+package backend
+
+import (
+	"math/bits"
+	"time"
+)
+
+// ResourceEstimate is an estimate of the resources needed to generate a proof,
+// as returned by the EstimateResources method of the proving keys. It allows to
+// size machines before proving.
+type ResourceEstimate struct {
+	// Memory is the peak number of bytes allocated by the prover, excluding the
+	// proving key, the constraint system and the witness.
+	Memory uint64
+	// MultiExps are the multi-exponentiations computed by the prover.
+	MultiExps []MultiExpEstimate
+	// FFTs are the FFTs computed by the prover, grouped by size.
+	FFTs []FFTEstimate
+	// Time is a rough estimate of the time of the multi-exponentiations and of
+	// the FFTs, which dominate the proving time. It is extrapolated from the
+	// time of small operations measured on this machine, and ignores the time
+	// of the solver.
+	Time time.Duration
+}
+
+// MultiExpEstimate is a multi-exponentiation computed by the prover.
+type MultiExpEstimate struct {
+	// G2 is set if the points are in G2, otherwise they are in G1.
+	G2 bool
+	// Size is the number of points.
+	Size int
+}
+
+// FFTEstimate is a number of FFTs of the same size computed by the prover.
+type FFTEstimate struct {
+	// Size is the size of the domain.
+	Size uint64
+	// Count is the number of FFTs.
+	Count int
+}
+
+// Calibration is the time of small operations measured on a machine, from
+// which the time of larger ones is extrapolated.
+type Calibration struct {
+	// G1MultiExp and G2MultiExp are the times of multi-exponentiations of
+	// CalibrationMultiExpSize points.
+	G1MultiExp, G2MultiExp time.Duration
+	// FFT is the time of an FFT of size CalibrationFFTSize.
+	FFT time.Duration
+}
+
+const (
+	// CalibrationMultiExpSize is the size of the calibration multi-exponentiations.
+	CalibrationMultiExpSize = 1 << 10
+	// CalibrationFFTSize is the size of the calibration FFT.
+	CalibrationFFTSize = 1 << 12
+)
+
+// EstimateTime sets e.Time from the multi-exponentiations and the FFTs of the
+// estimate, extrapolated from the calibration. The time of a
+// multi-exponentiation of n points grows as n/log₂(n), and the time of an FFT
+// of size n as n·log₂(n).
+func (e *ResourceEstimate) EstimateTime(c Calibration) {
+	var t float64
+	msm := func(n int) float64 {
+		if n <= 1 {
+			return 0
+		}
+		return float64(n) / float64(bits.Len(uint(n))-1)
+	}
+	refMSM := msm(CalibrationMultiExpSize)
+	for _, m := range e.MultiExps {
+		ref := c.G1MultiExp
+		if m.G2 {
+			ref = c.G2MultiExp
+		}
+		t += float64(ref) * msm(m.Size) / refMSM
+	}
+	fft := func(n uint64) float64 {
+		if n <= 1 {
+			return 0
+		}
+		return float64(n) * float64(bits.Len64(n)-1)
+	}
+	refFFT := fft(CalibrationFFTSize)
+	for _, f := range e.FFTs {
+		t += float64(c.FFT) * float64(f.Count) * fft(f.Size) / refFFT
+	}
+	e.Time = time.Duration(t)
+}
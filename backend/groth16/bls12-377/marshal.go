@@ -18,8 +18,6 @@ package groth16
 
 import (
 	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
-
-	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/pedersen"
 	"github.com/consensys/gnark/internal/utils"
 	"io"
 )
@@ -228,147 +226,3 @@ func (vk *VerifyingKey) readFrom(r io.Reader, decOptions ...func(*curve.Decoder)
 
 	return dec.BytesRead(), nil
 }
-
-// WriteTo writes binary encoding of the key elements to writer
-// points are compressed
-// use WriteRawTo(...) to encode the key without point compression
-func (pk *ProvingKey) WriteTo(w io.Writer) (n int64, err error) {
-	return pk.writeTo(w, false)
-}
-
-// WriteRawTo writes binary encoding of the key elements to writer
-// points are not compressed
-// use WriteTo(...) to encode the key with point compression
-func (pk *ProvingKey) WriteRawTo(w io.Writer) (n int64, err error) {
-	return pk.writeTo(w, true)
-}
-
-func (pk *ProvingKey) writeTo(w io.Writer, raw bool) (int64, error) {
-	n, err := pk.Domain.WriteTo(w)
-	if err != nil {
-		return n, err
-	}
-
-	var enc *curve.Encoder
-	if raw {
-		enc = curve.NewEncoder(w, curve.RawEncoding())
-	} else {
-		enc = curve.NewEncoder(w)
-	}
-	nbWires := uint64(len(pk.InfinityA))
-
-	toEncode := []interface{}{
-		&pk.G1.Alpha,
-		&pk.G1.Beta,
-		&pk.G1.Delta,
-		pk.G1.A,
-		pk.G1.B,
-		pk.G1.Z,
-		pk.G1.K,
-		&pk.G2.Beta,
-		&pk.G2.Delta,
-		pk.G2.B,
-		nbWires,
-		pk.NbInfinityA,
-		pk.NbInfinityB,
-		pk.InfinityA,
-		pk.InfinityB,
-		uint32(len(pk.CommitmentKeys)),
-	}
-
-	for _, v := range toEncode {
-		if err := enc.Encode(v); err != nil {
-			return n + enc.BytesWritten(), err
-		}
-	}
-
-	for i := range pk.CommitmentKeys {
-		var (
-			n2  int64
-			err error
-		)
-		if raw {
-			n2, err = pk.CommitmentKeys[i].WriteRawTo(w)
-		} else {
-			n2, err = pk.CommitmentKeys[i].WriteTo(w)
-		}
-
-		n += n2
-		if err != nil {
-			return n, err
-		}
-	}
-
-	return n + enc.BytesWritten(), nil
-
-}
-
-// ReadFrom attempts to decode a ProvingKey from reader
-// ProvingKey must be encoded through WriteTo (compressed) or WriteRawTo (uncompressed)
-// note that we don't check that the points are on the curve or in the correct subgroup at this point
-func (pk *ProvingKey) ReadFrom(r io.Reader) (int64, error) {
-	return pk.readFrom(r)
-}
-
-// UnsafeReadFrom behaves like ReadFrom excepts it doesn't check if the decoded points are on the curve
-// or in the correct subgroup
-func (pk *ProvingKey) UnsafeReadFrom(r io.Reader) (int64, error) {
-	return pk.readFrom(r, curve.NoSubgroupChecks())
-}
-
-func (pk *ProvingKey) readFrom(r io.Reader, decOptions ...func(*curve.Decoder)) (int64, error) {
-	n, err := pk.Domain.ReadFrom(r)
-	if err != nil {
-		return n, err
-	}
-
-	dec := curve.NewDecoder(r, decOptions...)
-
-	var nbWires uint64
-	var nbCommitments uint32
-
-	toDecode := []interface{}{
-		&pk.G1.Alpha,
-		&pk.G1.Beta,
-		&pk.G1.Delta,
-		&pk.G1.A,
-		&pk.G1.B,
-		&pk.G1.Z,
-		&pk.G1.K,
-		&pk.G2.Beta,
-		&pk.G2.Delta,
-		&pk.G2.B,
-		&nbWires,
-		&pk.NbInfinityA,
-		&pk.NbInfinityB,
-	}
-
-	for _, v := range toDecode {
-		if err := dec.Decode(v); err != nil {
-			return n + dec.BytesRead(), err
-		}
-	}
-	pk.InfinityA = make([]bool, nbWires)
-	pk.InfinityB = make([]bool, nbWires)
-
-	if err := dec.Decode(&pk.InfinityA); err != nil {
-		return n + dec.BytesRead(), err
-	}
-	if err := dec.Decode(&pk.InfinityB); err != nil {
-		return n + dec.BytesRead(), err
-	}
-	if err := dec.Decode(&nbCommitments); err != nil {
-		return n + dec.BytesRead(), err
-	}
-
-	pk.CommitmentKeys = make([]pedersen.ProvingKey, nbCommitments)
-	for i := range pk.CommitmentKeys {
-		n2, err := pk.CommitmentKeys[i].ReadFrom(r)
-		n += n2
-		if err != nil {
-			return n, err
-		}
-	}
-
-	return n + dec.BytesRead(), nil
-}
@@ -1,3 +1,6 @@
+//go:build !verifier_only
+// +build !verifier_only
+
 // Copyright 2020 ConsenSys Software Inc.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -18,7 +21,6 @@ package groth16
 
 import (
 	"errors"
-	"github.com/consensys/gnark-crypto/ecc"
 	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
@@ -30,57 +32,6 @@ import (
 	"math/bits"
 )
 
-// ProvingKey is used by a Groth16 prover to encode a proof of a statement
-// Notation follows Figure 4. in DIZK paper https://eprint.iacr.org/2018/691.pdf
-type ProvingKey struct {
-	// domain
-	Domain fft.Domain
-
-	// [α]₁, [β]₁, [δ]₁
-	// [A(t)]₁, [B(t)]₁, [Kpk(t)]₁, [Z(t)]₁
-	G1 struct {
-		Alpha, Beta, Delta curve.G1Affine
-		A, B, Z            []curve.G1Affine
-		K                  []curve.G1Affine // the indexes correspond to the private wires
-	}
-
-	// [β]₂, [δ]₂, [B(t)]₂
-	G2 struct {
-		Beta, Delta curve.G2Affine
-		B           []curve.G2Affine
-	}
-
-	// if InfinityA[i] == true, the point G1.A[i] == infinity
-	InfinityA, InfinityB     []bool
-	NbInfinityA, NbInfinityB uint64
-
-	CommitmentKeys []pedersen.ProvingKey
-}
-
-// VerifyingKey is used by a Groth16 verifier to verify the validity of a proof and a statement
-// Notation follows Figure 4. in DIZK paper https://eprint.iacr.org/2018/691.pdf
-type VerifyingKey struct {
-	// [α]₁, [Kvk]₁
-	G1 struct {
-		Alpha       curve.G1Affine
-		Beta, Delta curve.G1Affine   // unused, here for compatibility purposes
-		K           []curve.G1Affine // The indexes correspond to the public wires
-	}
-
-	// [β]₂, [δ]₂, [γ]₂,
-	// -[δ]₂, -[γ]₂: see proof.Verify() for more details
-	G2 struct {
-		Beta, Delta, Gamma curve.G2Affine
-		deltaNeg, gammaNeg curve.G2Affine // not serialized
-	}
-
-	// e(α, β)
-	e curve.GT // not serialized
-
-	CommitmentKey                pedersen.VerifyingKey
-	PublicAndCommitmentCommitted [][]int // indexes of public/commitment committed variables
-}
-
 // Setup constructs the SRS
 func Setup(r1cs *cs.R1CS, pk *ProvingKey, vk *VerifyingKey) error {
 	/*
@@ -336,19 +287,6 @@ func Setup(r1cs *cs.R1CS, pk *ProvingKey, vk *VerifyingKey) error {
 	return nil
 }
 
-// Precompute sets e, -[δ]₂, -[γ]₂
-// This is meant to be called internally during setup or deserialization.
-func (vk *VerifyingKey) Precompute() error {
-	var err error
-	vk.e, err = curve.Pair([]curve.G1Affine{vk.G1.Alpha}, []curve.G2Affine{vk.G2.Beta})
-	if err != nil {
-		return err
-	}
-	vk.G2.deltaNeg.Neg(&vk.G2.Delta)
-	vk.G2.gammaNeg.Neg(&vk.G2.Gamma)
-	return nil
-}
-
 func setupABC(r1cs *cs.R1CS, domain *fft.Domain, toxicWaste toxicWaste) (A []fr.Element, B []fr.Element, C []fr.Element) {
 
 	nbWires := r1cs.NbInternalVariables + r1cs.GetNbPublicVariables() + r1cs.GetNbSecretVariables()
@@ -602,78 +540,6 @@ func dummyInfinityCount(r1cs *cs.R1CS) (nbZeroesA, nbZeroesB int) {
 
 }
 
-// IsDifferent returns true if provided vk is different than self
-// this is used by groth16.Assert to ensure random sampling
-func (vk *VerifyingKey) IsDifferent(_other interface{}) bool {
-	vk2 := _other.(*VerifyingKey)
-	for i := 0; i < len(vk.G1.K); i++ {
-		if !vk.G1.K[i].IsInfinity() {
-			if vk.G1.K[i].Equal(&vk2.G1.K[i]) {
-				return false
-			}
-		}
-	}
-
-	return true
-}
-
-// IsDifferent returns true if provided pk is different than self
-// this is used by groth16.Assert to ensure random sampling
-func (pk *ProvingKey) IsDifferent(_other interface{}) bool {
-	pk2 := _other.(*ProvingKey)
-
-	if pk.G1.Alpha.Equal(&pk2.G1.Alpha) ||
-		pk.G1.Beta.Equal(&pk2.G1.Beta) ||
-		pk.G1.Delta.Equal(&pk2.G1.Delta) {
-		return false
-	}
-
-	for i := 0; i < len(pk.G1.K); i++ {
-		if !pk.G1.K[i].IsInfinity() {
-			if pk.G1.K[i].Equal(&pk2.G1.K[i]) {
-				return false
-			}
-		}
-	}
-
-	return true
-}
-
-// CurveID returns the curveID
-func (pk *ProvingKey) CurveID() ecc.ID {
-	return curve.ID
-}
-
-// CurveID returns the curveID
-func (vk *VerifyingKey) CurveID() ecc.ID {
-	return curve.ID
-}
-
-// NbPublicWitness returns the number of elements in the expected public witness
-func (vk *VerifyingKey) NbPublicWitness() int {
-	return (len(vk.G1.K) - 1)
-}
-
-// NbG1 returns the number of G1 elements in the VerifyingKey
-func (vk *VerifyingKey) NbG1() int {
-	return 3 + len(vk.G1.K)
-}
-
-// NbG2 returns the number of G2 elements in the VerifyingKey
-func (vk *VerifyingKey) NbG2() int {
-	return 3
-}
-
-// NbG1 returns the number of G1 elements in the ProvingKey
-func (pk *ProvingKey) NbG1() int {
-	return 3 + len(pk.G1.A) + len(pk.G1.B) + len(pk.G1.Z) + len(pk.G1.K)
-}
-
-// NbG2 returns the number of G2 elements in the ProvingKey
-func (pk *ProvingKey) NbG2() int {
-	return 2 + len(pk.G2.B)
-}
-
 // bitReverse permutation as in fft.BitReverse , but with []curve.G1Affine
 func bitReverse(a []curve.G1Affine) {
 	n := uint(len(a))
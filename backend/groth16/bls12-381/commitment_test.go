@@ -1,3 +1,6 @@
+//go:build !verifier_only
+// +build !verifier_only
+
 // Copyright 2020 ConsenSys Software Inc.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
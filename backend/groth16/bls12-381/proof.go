@@ -0,0 +1,88 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package groth16
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/backend"
+	"math/big"
+)
+
+// Proof represents a Groth16 proof that was encoded with a ProvingKey and can be verified
+// with a valid statement and a VerifyingKey
+// Notation follows Figure 4. in DIZK paper https://eprint.iacr.org/2018/691.pdf
+type Proof struct {
+	Ar, Krs       curve.G1Affine
+	Bs            curve.G2Affine
+	Commitments   []curve.G1Affine // Pedersen commitments a la https://eprint.iacr.org/2022/1072
+	CommitmentPok curve.G1Affine   // Batched proof of knowledge of the above commitments
+}
+
+// isValid ensures proof elements are in the correct subgroup
+func (proof *Proof) isValid() bool {
+	return proof.Ar.IsInSubGroup() && proof.Krs.IsInSubGroup() && proof.Bs.IsInSubGroup()
+}
+
+// CurveID returns the curveID
+func (proof *Proof) CurveID() ecc.ID {
+	return curve.ID
+}
+
+// Rerandomize re-randomizes the proof in place. The resulting proof is valid
+// for the same statement and VerifyingKey, but is unlinkable to the original
+// one. Neither the witness nor the ProvingKey are needed.
+//
+// For random r₁ ≠ 0 and r₂, (Ar, Bs, Krs) is replaced by
+// (r₁⁻¹·Ar, r₁·Bs + r₁r₂·[δ]₂, Krs + r₂·Ar).
+//
+// The commitments to witness variables can't be re-randomized, as they
+// determine the public inputs derived from them, and would link the proofs.
+// Rerandomize returns [backend.ErrRerandomizeCommitments] if the proof has
+// any.
+func (proof *Proof) Rerandomize(vk *VerifyingKey) error {
+	if len(proof.Commitments) > 0 {
+		return backend.ErrRerandomizeCommitments
+	}
+	var r1, r2 fr.Element
+	for r1.IsZero() {
+		if _, err := r1.SetRandom(); err != nil {
+			return err
+		}
+	}
+	if _, err := r2.SetRandom(); err != nil {
+		return err
+	}
+
+	var r1Inv, r1r2 fr.Element
+	r1Inv.Inverse(&r1)
+	r1r2.Mul(&r1, &r2)
+
+	var bi big.Int
+	var ar curve.G1Affine
+	ar.ScalarMultiplication(&proof.Ar, r2.BigInt(&bi))
+	proof.Krs.Add(&proof.Krs, &ar)
+	proof.Ar.ScalarMultiplication(&proof.Ar, r1Inv.BigInt(&bi))
+
+	var delta curve.G2Affine
+	delta.ScalarMultiplication(&vk.G2.Delta, r1r2.BigInt(&bi))
+	proof.Bs.ScalarMultiplication(&proof.Bs, r1.BigInt(&bi))
+	proof.Bs.Add(&proof.Bs, &delta)
+
+	return nil
+}
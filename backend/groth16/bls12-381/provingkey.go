@@ -0,0 +1,236 @@
+//go:build !verifier_only
+// +build !verifier_only
+
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package groth16
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/pedersen"
+	"io"
+)
+
+// ProvingKey is used by a Groth16 prover to encode a proof of a statement
+// Notation follows Figure 4. in DIZK paper https://eprint.iacr.org/2018/691.pdf
+type ProvingKey struct {
+	// domain
+	Domain fft.Domain
+
+	// [α]₁, [β]₁, [δ]₁
+	// [A(t)]₁, [B(t)]₁, [Kpk(t)]₁, [Z(t)]₁
+	G1 struct {
+		Alpha, Beta, Delta curve.G1Affine
+		A, B, Z            []curve.G1Affine
+		K                  []curve.G1Affine // the indexes correspond to the private wires
+	}
+
+	// [β]₂, [δ]₂, [B(t)]₂
+	G2 struct {
+		Beta, Delta curve.G2Affine
+		B           []curve.G2Affine
+	}
+
+	// if InfinityA[i] == true, the point G1.A[i] == infinity
+	InfinityA, InfinityB     []bool
+	NbInfinityA, NbInfinityB uint64
+
+	CommitmentKeys []pedersen.ProvingKey
+}
+
+// IsDifferent returns true if provided pk is different than self
+// this is used by groth16.Assert to ensure random sampling
+func (pk *ProvingKey) IsDifferent(_other interface{}) bool {
+	pk2 := _other.(*ProvingKey)
+
+	if pk.G1.Alpha.Equal(&pk2.G1.Alpha) ||
+		pk.G1.Beta.Equal(&pk2.G1.Beta) ||
+		pk.G1.Delta.Equal(&pk2.G1.Delta) {
+		return false
+	}
+
+	for i := 0; i < len(pk.G1.K); i++ {
+		if !pk.G1.K[i].IsInfinity() {
+			if pk.G1.K[i].Equal(&pk2.G1.K[i]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// CurveID returns the curveID
+func (pk *ProvingKey) CurveID() ecc.ID {
+	return curve.ID
+}
+
+// NbG1 returns the number of G1 elements in the ProvingKey
+func (pk *ProvingKey) NbG1() int {
+	return 3 + len(pk.G1.A) + len(pk.G1.B) + len(pk.G1.Z) + len(pk.G1.K)
+}
+
+// NbG2 returns the number of G2 elements in the ProvingKey
+func (pk *ProvingKey) NbG2() int {
+	return 2 + len(pk.G2.B)
+}
+
+// WriteTo writes binary encoding of the key elements to writer
+// points are compressed
+// use WriteRawTo(...) to encode the key without point compression
+func (pk *ProvingKey) WriteTo(w io.Writer) (n int64, err error) {
+	return pk.writeTo(w, false)
+}
+
+// WriteRawTo writes binary encoding of the key elements to writer
+// points are not compressed
+// use WriteTo(...) to encode the key with point compression
+func (pk *ProvingKey) WriteRawTo(w io.Writer) (n int64, err error) {
+	return pk.writeTo(w, true)
+}
+
+func (pk *ProvingKey) writeTo(w io.Writer, raw bool) (int64, error) {
+	n, err := pk.Domain.WriteTo(w)
+	if err != nil {
+		return n, err
+	}
+
+	var enc *curve.Encoder
+	if raw {
+		enc = curve.NewEncoder(w, curve.RawEncoding())
+	} else {
+		enc = curve.NewEncoder(w)
+	}
+	nbWires := uint64(len(pk.InfinityA))
+
+	toEncode := []interface{}{
+		&pk.G1.Alpha,
+		&pk.G1.Beta,
+		&pk.G1.Delta,
+		pk.G1.A,
+		pk.G1.B,
+		pk.G1.Z,
+		pk.G1.K,
+		&pk.G2.Beta,
+		&pk.G2.Delta,
+		pk.G2.B,
+		nbWires,
+		pk.NbInfinityA,
+		pk.NbInfinityB,
+		pk.InfinityA,
+		pk.InfinityB,
+		uint32(len(pk.CommitmentKeys)),
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return n + enc.BytesWritten(), err
+		}
+	}
+
+	for i := range pk.CommitmentKeys {
+		var (
+			n2  int64
+			err error
+		)
+		if raw {
+			n2, err = pk.CommitmentKeys[i].WriteRawTo(w)
+		} else {
+			n2, err = pk.CommitmentKeys[i].WriteTo(w)
+		}
+
+		n += n2
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return n + enc.BytesWritten(), nil
+
+}
+
+// ReadFrom attempts to decode a ProvingKey from reader
+// ProvingKey must be encoded through WriteTo (compressed) or WriteRawTo (uncompressed)
+// note that we don't check that the points are on the curve or in the correct subgroup at this point
+func (pk *ProvingKey) ReadFrom(r io.Reader) (int64, error) {
+	return pk.readFrom(r)
+}
+
+// UnsafeReadFrom behaves like ReadFrom excepts it doesn't check if the decoded points are on the curve
+// or in the correct subgroup
+func (pk *ProvingKey) UnsafeReadFrom(r io.Reader) (int64, error) {
+	return pk.readFrom(r, curve.NoSubgroupChecks())
+}
+
+func (pk *ProvingKey) readFrom(r io.Reader, decOptions ...func(*curve.Decoder)) (int64, error) {
+	n, err := pk.Domain.ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+
+	dec := curve.NewDecoder(r, decOptions...)
+
+	var nbWires uint64
+	var nbCommitments uint32
+
+	toDecode := []interface{}{
+		&pk.G1.Alpha,
+		&pk.G1.Beta,
+		&pk.G1.Delta,
+		&pk.G1.A,
+		&pk.G1.B,
+		&pk.G1.Z,
+		&pk.G1.K,
+		&pk.G2.Beta,
+		&pk.G2.Delta,
+		&pk.G2.B,
+		&nbWires,
+		&pk.NbInfinityA,
+		&pk.NbInfinityB,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return n + dec.BytesRead(), err
+		}
+	}
+	pk.InfinityA = make([]bool, nbWires)
+	pk.InfinityB = make([]bool, nbWires)
+
+	if err := dec.Decode(&pk.InfinityA); err != nil {
+		return n + dec.BytesRead(), err
+	}
+	if err := dec.Decode(&pk.InfinityB); err != nil {
+		return n + dec.BytesRead(), err
+	}
+	if err := dec.Decode(&nbCommitments); err != nil {
+		return n + dec.BytesRead(), err
+	}
+
+	pk.CommitmentKeys = make([]pedersen.ProvingKey, nbCommitments)
+	for i := range pk.CommitmentKeys {
+		n2, err := pk.CommitmentKeys[i].ReadFrom(r)
+		n += n2
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return n + dec.BytesRead(), nil
+}
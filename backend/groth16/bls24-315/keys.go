@@ -0,0 +1,95 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package groth16
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/pedersen"
+)
+
+// VerifyingKey is used by a Groth16 verifier to verify the validity of a proof and a statement
+// Notation follows Figure 4. in DIZK paper https://eprint.iacr.org/2018/691.pdf
+type VerifyingKey struct {
+	// [α]₁, [Kvk]₁
+	G1 struct {
+		Alpha       curve.G1Affine
+		Beta, Delta curve.G1Affine   // unused, here for compatibility purposes
+		K           []curve.G1Affine // The indexes correspond to the public wires
+	}
+
+	// [β]₂, [δ]₂, [γ]₂,
+	// -[δ]₂, -[γ]₂: see proof.Verify() for more details
+	G2 struct {
+		Beta, Delta, Gamma curve.G2Affine
+		deltaNeg, gammaNeg curve.G2Affine // not serialized
+	}
+
+	// e(α, β)
+	e curve.GT // not serialized
+
+	CommitmentKey                pedersen.VerifyingKey
+	PublicAndCommitmentCommitted [][]int // indexes of public/commitment committed variables
+}
+
+// Precompute sets e, -[δ]₂, -[γ]₂
+// This is meant to be called internally during setup or deserialization.
+func (vk *VerifyingKey) Precompute() error {
+	var err error
+	vk.e, err = curve.Pair([]curve.G1Affine{vk.G1.Alpha}, []curve.G2Affine{vk.G2.Beta})
+	if err != nil {
+		return err
+	}
+	vk.G2.deltaNeg.Neg(&vk.G2.Delta)
+	vk.G2.gammaNeg.Neg(&vk.G2.Gamma)
+	return nil
+}
+
+// IsDifferent returns true if provided vk is different than self
+// this is used by groth16.Assert to ensure random sampling
+func (vk *VerifyingKey) IsDifferent(_other interface{}) bool {
+	vk2 := _other.(*VerifyingKey)
+	for i := 0; i < len(vk.G1.K); i++ {
+		if !vk.G1.K[i].IsInfinity() {
+			if vk.G1.K[i].Equal(&vk2.G1.K[i]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// CurveID returns the curveID
+func (vk *VerifyingKey) CurveID() ecc.ID {
+	return curve.ID
+}
+
+// NbPublicWitness returns the number of elements in the expected public witness
+func (vk *VerifyingKey) NbPublicWitness() int {
+	return (len(vk.G1.K) - 1)
+}
+
+// NbG1 returns the number of G1 elements in the VerifyingKey
+func (vk *VerifyingKey) NbG1() int {
+	return 3 + len(vk.G1.K)
+}
+
+// NbG2 returns the number of G2 elements in the VerifyingKey
+func (vk *VerifyingKey) NbG2() int {
+	return 3
+}
@@ -0,0 +1,301 @@
+//go:build !verifier_only
+
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/witness"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+)
+
+// Keys under which Coordinator.LoadProvingKey stores the proving key points on
+// the workers. They are the identifiers of the points given by the prover to
+// the accelerator, see [backend.MultiExpConfig].
+const (
+	KeyG1A = backend.PointsGroth16G1A
+	KeyG1B = backend.PointsGroth16G1B
+	KeyG1Z = backend.PointsGroth16G1Z
+	KeyG1K = backend.PointsGroth16G1K
+	KeyG2B = backend.PointsGroth16G2B
+)
+
+// loadChunkSize is the maximal number of points sent to a worker in a message.
+const loadChunkSize = 1 << 20
+
+// DefaultCallTimeout is the default bound on the duration of a call to a
+// worker, see WithCallTimeout.
+const DefaultCallTimeout = 10 * time.Minute
+
+// Coordinator shards multi-exponentiations and FFTs across a set of workers.
+// It implements [backend.Accelerator], see [Coordinator.Prove].
+type Coordinator struct {
+	conns       []grpc.ClientConnInterface
+	callTimeout time.Duration
+
+	lock   sync.RWMutex
+	shards map[string][]int // key -> shard boundaries (len(conns)+1 offsets)
+}
+
+// CoordinatorOption configures a Coordinator.
+type CoordinatorOption func(*Coordinator)
+
+// WithCallTimeout bounds the duration of each call to a worker, so that a hung
+// worker fails the operation instead of blocking it. A message of a load
+// counts as a call. A timeout of 0 disables the bound, the calls then only
+// end with their context. The default is DefaultCallTimeout.
+func WithCallTimeout(timeout time.Duration) CoordinatorOption {
+	return func(c *Coordinator) {
+		c.callTimeout = timeout
+	}
+}
+
+// NewCoordinator returns a coordinator dispatching the work to the workers
+// behind the gRPC connections, typically obtained with grpc.Dial.
+func NewCoordinator(conns []grpc.ClientConnInterface, opts ...CoordinatorOption) (*Coordinator, error) {
+	if len(conns) == 0 {
+		return nil, errors.New("at least one worker is required")
+	}
+	c := &Coordinator{
+		conns:       conns,
+		callTimeout: DefaultCallTimeout,
+		shards:      make(map[string][]int),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// LoadProvingKey loads the points of the proving key involved in the prover
+// multi-exponentiations on the workers, under the keys KeyG1A, KeyG1B, KeyG1Z,
+// KeyG1K and KeyG2B.
+func (c *Coordinator) LoadProvingKey(ctx context.Context, pk *groth16_bn254.ProvingKey) error {
+	for _, l := range []struct {
+		key    string
+		points []curve.G1Affine
+	}{
+		{KeyG1A, pk.G1.A},
+		{KeyG1B, pk.G1.B},
+		{KeyG1Z, pk.G1.Z},
+		{KeyG1K, pk.G1.K},
+	} {
+		if err := c.LoadG1(ctx, l.key, l.points); err != nil {
+			return fmt.Errorf("load %s: %w", l.key, err)
+		}
+	}
+	if err := c.LoadG2(ctx, KeyG2B, pk.G2.B); err != nil {
+		return fmt.Errorf("load %s: %w", KeyG2B, err)
+	}
+	return nil
+}
+
+// LoadG1 splits the points in contiguous shards and sends one to each worker.
+func (c *Coordinator) LoadG1(ctx context.Context, key string, points []curve.G1Affine) error {
+	return c.load(ctx, key, "LoadG1", len(points), func(start, end int) ([]byte, error) {
+		var buf bytes.Buffer
+		err := curve.NewEncoder(&buf, curve.RawEncoding()).Encode(points[start:end])
+		return buf.Bytes(), err
+	})
+}
+
+// LoadG2 splits the points in contiguous shards and sends one to each worker.
+func (c *Coordinator) LoadG2(ctx context.Context, key string, points []curve.G2Affine) error {
+	return c.load(ctx, key, "LoadG2", len(points), func(start, end int) ([]byte, error) {
+		var buf bytes.Buffer
+		err := curve.NewEncoder(&buf, curve.RawEncoding()).Encode(points[start:end])
+		return buf.Bytes(), err
+	})
+}
+
+// load sends the shards of the n points to the workers with method, in
+// messages of at most loadChunkSize points encoded by encode.
+func (c *Coordinator) load(ctx context.Context, key, method string, n int, encode func(start, end int) ([]byte, error)) error {
+	bounds := c.bounds(n)
+	err := c.each(ctx, func(ctx context.Context, i int, conn grpc.ClientConnInterface) error {
+		var reply LoadReply
+		for start, first := bounds[i], true; first || start < bounds[i+1]; first = false {
+			end := min(start+loadChunkSize, bounds[i+1])
+			data, err := encode(start, end)
+			if err != nil {
+				return err
+			}
+			if err := c.call(ctx, conn, method, &LoadArgs{Key: key, Append: !first, Points: data}, &reply); err != nil {
+				return err
+			}
+			start = end
+		}
+		if reply.NbPoints != bounds[i+1]-bounds[i] {
+			return fmt.Errorf("worker loaded %d points, want %d", reply.NbPoints, bounds[i+1]-bounds[i])
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	c.shards[key] = bounds
+	c.lock.Unlock()
+	return nil
+}
+
+// MultiExpG1 computes ∑ scalars[i]⋅points[i] where points were loaded under
+// key with LoadG1. The scalars may be fewer than the points, in which case only
+// the first len(scalars) points are used.
+func (c *Coordinator) MultiExpG1(ctx context.Context, key string, scalars []fr.Element) (curve.G1Jac, error) {
+	var res curve.G1Jac
+	partials := make([]curve.G1Affine, len(c.conns))
+	err := c.multiExp(ctx, key, "MultiExpG1", scalars, func(i int, b []byte) error {
+		_, err := partials[i].SetBytes(b)
+		return err
+	})
+	if err != nil {
+		return res, err
+	}
+	for i := range partials {
+		res.AddMixed(&partials[i])
+	}
+	return res, nil
+}
+
+// MultiExpG2 computes ∑ scalars[i]⋅points[i] where points were loaded under
+// key with LoadG2. The scalars may be fewer than the points, in which case only
+// the first len(scalars) points are used.
+func (c *Coordinator) MultiExpG2(ctx context.Context, key string, scalars []fr.Element) (curve.G2Jac, error) {
+	var res curve.G2Jac
+	partials := make([]curve.G2Affine, len(c.conns))
+	err := c.multiExp(ctx, key, "MultiExpG2", scalars, func(i int, b []byte) error {
+		_, err := partials[i].SetBytes(b)
+		return err
+	})
+	if err != nil {
+		return res, err
+	}
+	for i := range partials {
+		res.AddMixed(&partials[i])
+	}
+	return res, nil
+}
+
+// MultiExp implements [backend.Accelerator]. It shards the
+// multi-exponentiations over the points identified by config.Points, loaded
+// with LoadG1 or LoadG2 under that key. It returns
+// [backend.ErrAcceleratorUnsupported] for the other points, which the prover
+// then handles locally.
+func (c *Coordinator) MultiExp(ctx context.Context, curveID ecc.ID, res, points, scalars any, config backend.MultiExpConfig) error {
+	s, ok := scalars.([]fr.Element)
+	if curveID != ecc.BN254 || !ok {
+		return backend.ErrAcceleratorUnsupported
+	}
+	switch p := points.(type) {
+	case []curve.G1Affine:
+		r, ok := res.(*curve.G1Jac)
+		if !ok || !c.loaded(config.Points, len(p)) {
+			return backend.ErrAcceleratorUnsupported
+		}
+		v, err := c.MultiExpG1(ctx, config.Points, s)
+		if err != nil {
+			return err
+		}
+		*r = v
+		return nil
+	case []curve.G2Affine:
+		r, ok := res.(*curve.G2Jac)
+		if !ok || !c.loaded(config.Points, len(p)) {
+			return backend.ErrAcceleratorUnsupported
+		}
+		v, err := c.MultiExpG2(ctx, config.Points, s)
+		if err != nil {
+			return err
+		}
+		*r = v
+		return nil
+	default:
+		return backend.ErrAcceleratorUnsupported
+	}
+}
+
+// loaded returns true if n points were loaded under the key.
+func (c *Coordinator) loaded(key string, n int) bool {
+	if key == "" {
+		return false
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	bounds, ok := c.shards[key]
+	return ok && bounds[len(bounds)-1] == n
+}
+
+// Prove computes a Groth16 proof with the BN254 prover, offloading its
+// multi-exponentiations and FFTs to the workers, which learn the witness (see
+// the package documentation). The proving key must have been loaded with
+// LoadProvingKey, the multi-exponentiations over other points (for instance
+// the commitment keys) are computed locally.
+func (c *Coordinator) Prove(r1cs *cs.R1CS, pk *groth16_bn254.ProvingKey, fullWitness witness.Witness, opts ...backend.ProverOption) (*groth16_bn254.Proof, error) {
+	opts = append(opts[:len(opts):len(opts)], backend.WithProverAcceleratorEngine(c))
+	return groth16_bn254.Prove(r1cs, pk, fullWitness, opts...)
+}
+
+func (c *Coordinator) multiExp(ctx context.Context, key, method string, scalars []fr.Element, setPartial func(int, []byte) error) error {
+	c.lock.RLock()
+	bounds, ok := c.shards[key]
+	c.lock.RUnlock()
+	if !ok {
+		return fmt.Errorf("points %q not loaded", key)
+	}
+	if len(scalars) > bounds[len(bounds)-1] {
+		return fmt.Errorf("%d scalars for %d points", len(scalars), bounds[len(bounds)-1])
+	}
+	return c.each(ctx, func(ctx context.Context, i int, conn grpc.ClientConnInterface) error {
+		start, end := min(bounds[i], len(scalars)), min(bounds[i+1], len(scalars))
+		shard := fr.Vector(scalars[start:end])
+		data, err := shard.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		var reply MultiExpReply
+		if err := c.call(ctx, conn, method, &MultiExpArgs{Key: key, Scalars: data}, &reply); err != nil {
+			return err
+		}
+		return setPartial(i, reply.Result)
+	})
+}
+
+// bounds returns the offsets splitting n elements in len(c.conns) contiguous
+// shards of (almost) equal size.
+func (c *Coordinator) bounds(n int) []int {
+	bounds := make([]int, len(c.conns)+1)
+	for i := range bounds {
+		bounds[i] = i * n / len(c.conns)
+	}
+	return bounds
+}
+
+// each calls f concurrently for every worker and returns the first error. The
+// context given to f is cancelled as soon as one of the calls fails, so that
+// the calls to the other workers are abandoned.
+func (c *Coordinator) each(ctx context.Context, f func(ctx context.Context, i int, conn grpc.ClientConnInterface) error) error {
+	g, ctx := errgroup.WithContext(ctx)
+	for i := range c.conns {
+		i := i
+		g.Go(func() error {
+			if err := f(ctx, i, c.conns[i]); err != nil {
+				return fmt.Errorf("worker %d: %w", i, err)
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
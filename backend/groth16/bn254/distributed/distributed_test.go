@@ -0,0 +1,268 @@
+//go:build !verifier_only
+
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	"github.com/consensys/gnark/backend"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// newTestCoordinator starts nbWorkers local workers and returns a coordinator
+// dispatching to them.
+func newTestCoordinator(t *testing.T, nbWorkers int) *Coordinator {
+	assert := require.New(t)
+	conns := make([]grpc.ClientConnInterface, nbWorkers)
+	for i := range conns {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(err)
+		t.Cleanup(func() { l.Close() })
+		go NewWorker(1).Serve(l)
+		conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.NoError(err)
+		t.Cleanup(func() { conn.Close() })
+		conns[i] = conn
+	}
+	c, err := NewCoordinator(conns)
+	assert.NoError(err)
+	return c
+}
+
+func TestMultiExp(t *testing.T) {
+	assert := require.New(t)
+	c := newTestCoordinator(t, 3)
+	var err error
+
+	const n = 100
+	_, _, _, g2 := curve.Generators()
+	scalars := make([]fr.Element, n)
+	g1Points := make([]curve.G1Affine, n)
+	g2Points := make([]curve.G2Affine, n)
+	for i := range scalars {
+		scalars[i].SetRandom()
+		var s fr.Element
+		s.SetRandom()
+		g1Points[i].ScalarMultiplicationBase(s.BigInt(new(big.Int)))
+		g2Points[i].ScalarMultiplication(&g2, s.BigInt(new(big.Int)))
+	}
+
+	assert.NoError(c.LoadG1(context.Background(), KeyG1A, g1Points))
+	assert.NoError(c.LoadG2(context.Background(), KeyG2B, g2Points))
+
+	for _, nbScalars := range []int{n, n - 10, 1} {
+		var expectedG1 curve.G1Jac
+		_, err = expectedG1.MultiExp(g1Points[:nbScalars], scalars[:nbScalars], ecc.MultiExpConfig{})
+		assert.NoError(err)
+		resG1, err := c.MultiExpG1(context.Background(), KeyG1A, scalars[:nbScalars])
+		assert.NoError(err)
+		assert.True(resG1.Equal(&expectedG1), "G1 mismatch with %d scalars", nbScalars)
+
+		var expectedG2 curve.G2Jac
+		_, err = expectedG2.MultiExp(g2Points[:nbScalars], scalars[:nbScalars], ecc.MultiExpConfig{})
+		assert.NoError(err)
+		resG2, err := c.MultiExpG2(context.Background(), KeyG2B, scalars[:nbScalars])
+		assert.NoError(err)
+		assert.True(resG2.Equal(&expectedG2), "G2 mismatch with %d scalars", nbScalars)
+	}
+
+	_, err = c.MultiExpG1(context.Background(), KeyG1K, scalars)
+	assert.Error(err, "multi-exponentiation over points not loaded should fail")
+	_, err = c.MultiExpG1(context.Background(), KeyG1A, append(scalars, fr.One()))
+	assert.Error(err, "more scalars than points should fail")
+}
+
+func TestAcceleratorMultiExp(t *testing.T) {
+	assert := require.New(t)
+	c := newTestCoordinator(t, 2)
+
+	const n = 50
+	scalars := make([]fr.Element, n)
+	points := make([]curve.G1Affine, n)
+	for i := range scalars {
+		scalars[i].SetRandom()
+		var s fr.Element
+		s.SetRandom()
+		points[i].ScalarMultiplicationBase(s.BigInt(new(big.Int)))
+	}
+	assert.NoError(c.LoadG1(context.Background(), KeyG1Z, points))
+
+	var expected curve.G1Jac
+	_, err := expected.MultiExp(points, scalars, ecc.MultiExpConfig{})
+	assert.NoError(err)
+
+	// the points are identified by their key, not by their address
+	copied := append([]curve.G1Affine{}, points...)
+	var res curve.G1Jac
+	assert.NoError(c.MultiExp(context.Background(), ecc.BN254, &res, copied, scalars, backend.MultiExpConfig{Points: KeyG1Z}))
+	assert.True(res.Equal(&expected))
+
+	err = c.MultiExp(context.Background(), ecc.BN254, &res, copied, scalars, backend.MultiExpConfig{})
+	assert.ErrorIs(err, backend.ErrAcceleratorUnsupported, "points without key")
+	err = c.MultiExp(context.Background(), ecc.BN254, &res, copied, scalars, backend.MultiExpConfig{Points: KeyG1K})
+	assert.ErrorIs(err, backend.ErrAcceleratorUnsupported, "points not loaded")
+	err = c.MultiExp(context.Background(), ecc.BN254, &res, copied[:n-1], scalars, backend.MultiExpConfig{Points: KeyG1Z})
+	assert.ErrorIs(err, backend.ErrAcceleratorUnsupported, "points of another size")
+}
+
+func TestFFT(t *testing.T) {
+	assert := require.New(t)
+	c := newTestCoordinator(t, 3)
+
+	for _, n := range []uint64{1 << 4, 1 << 7} {
+		domain := fft.NewDomain(n)
+		input := make([]fr.Element, n)
+		for i := range input {
+			input[i].SetRandom()
+		}
+		for _, config := range []backend.FFTConfig{
+			{},
+			{DIF: true},
+			{Inverse: true},
+			{Inverse: true, DIF: true},
+			{OnCoset: true},
+			{OnCoset: true, DIF: true},
+			{Inverse: true, OnCoset: true},
+			{Inverse: true, DIF: true, OnCoset: true},
+		} {
+			expected := append([]fr.Element{}, input...)
+			decimation := fft.DIT
+			if config.DIF {
+				decimation = fft.DIF
+			}
+			var opts []fft.Option
+			if config.OnCoset {
+				opts = append(opts, fft.OnCoset())
+			}
+			if config.Inverse {
+				domain.FFTInverse(expected, decimation, opts...)
+			} else {
+				domain.FFT(expected, decimation, opts...)
+			}
+
+			res := append([]fr.Element{}, input...)
+			assert.NoError(c.FFT(context.Background(), ecc.BN254, res, domain, config))
+			assert.Equal(expected, res, "size %d, config %+v", n, config)
+		}
+	}
+}
+
+// stubConn is a worker connection failing its calls with err, or blocking them
+// until their context is done if err is nil, as a hung worker.
+type stubConn struct {
+	err error
+}
+
+func (s stubConn) Invoke(ctx context.Context, _ string, _, _ any, _ ...grpc.CallOption) error {
+	if s.err != nil {
+		return s.err
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (stubConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, errors.New("streams not supported")
+}
+
+func TestCallTimeout(t *testing.T) {
+	assert := require.New(t)
+	c, err := NewCoordinator([]grpc.ClientConnInterface{stubConn{}}, WithCallTimeout(10*time.Millisecond))
+	assert.NoError(err)
+	err = c.LoadG1(context.Background(), KeyG1A, make([]curve.G1Affine, 1))
+	assert.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestCancellation(t *testing.T) {
+	assert := require.New(t)
+	errWorker := errors.New("worker failure")
+	c, err := NewCoordinator([]grpc.ClientConnInterface{stubConn{err: errWorker}, stubConn{}}, WithCallTimeout(0))
+	assert.NoError(err)
+
+	// the failure of a worker cancels the call to the hung one
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err = c.LoadG1(ctx, KeyG1A, make([]curve.G1Affine, 2))
+	assert.ErrorIs(err, errWorker)
+	assert.NoError(ctx.Err(), "the call to the hung worker wasn't cancelled")
+
+	// the cancellation of the prover context cancels the calls
+	c, err = NewCoordinator([]grpc.ClientConnInterface{stubConn{}}, WithCallTimeout(0))
+	assert.NoError(err)
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	const n = 1 << 4
+	err = c.FFT(ctx, ecc.BN254, make([]fr.Element, n), fft.NewDomain(n), backend.FFTConfig{})
+	assert.ErrorIs(err, context.Canceled)
+}
+
+func TestWorkerCancellation(t *testing.T) {
+	assert := require.New(t)
+	w := NewWorker(1)
+	var buf bytes.Buffer
+	assert.NoError(curve.NewEncoder(&buf, curve.RawEncoding()).Encode(make([]curve.G1Affine, 4)))
+	_, err := w.loadG1(context.Background(), &LoadArgs{Key: KeyG1A, Points: buf.Bytes()})
+	assert.NoError(err)
+	values := make(fr.Vector, 4)
+	scalars, err := values.MarshalBinary()
+	assert.NoError(err)
+
+	// the worker stops its computations when the call is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = w.multiExpG1(ctx, &MultiExpArgs{Key: KeyG1A, Scalars: scalars})
+	assert.ErrorIs(err, context.Canceled)
+	_, err = w.fft(ctx, &FFTArgs{Size: 4, Values: scalars})
+	assert.ErrorIs(err, context.Canceled)
+}
+
+type cubicCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *cubicCircuit) Define(api frontend.API) error {
+	x3 := api.Mul(c.X, c.X, c.X)
+	api.AssertIsEqual(c.Y, api.Add(x3, c.X, 5))
+	for i := 0; i < 100; i++ {
+		x3 = api.Mul(x3, c.X)
+	}
+	api.AssertIsDifferent(x3, 0)
+	return nil
+}
+
+func TestProve(t *testing.T) {
+	assert := require.New(t)
+	c := newTestCoordinator(t, 3)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &cubicCircuit{})
+	assert.NoError(err)
+	var pk groth16_bn254.ProvingKey
+	var vk groth16_bn254.VerifyingKey
+	assert.NoError(groth16_bn254.Setup(ccs.(*cs.R1CS), &pk, &vk))
+	assert.NoError(c.LoadProvingKey(context.Background(), &pk))
+
+	w, err := frontend.NewWitness(&cubicCircuit{X: 3, Y: 35}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	proof, err := c.Prove(ccs.(*cs.R1CS), &pk, w)
+	assert.NoError(err)
+	pw, err := w.Public()
+	assert.NoError(err)
+	assert.NoError(groth16_bn254.Verify(proof, &vk, pw.Vector().(fr.Vector)))
+}
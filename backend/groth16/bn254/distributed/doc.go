@@ -0,0 +1,53 @@
+// Package distributed implements a coordinator/worker protocol sharding the
+// multi-scalar multiplications and the FFTs of the BN254 Groth16 prover across
+// several machines.
+//
+// Each worker holds a contiguous shard of the proving key points, loaded once
+// by the coordinator (see [Coordinator.LoadProvingKey]). At proving time the
+// coordinator only sends the matching slices of scalars, the workers compute
+// their partial multi-exponentiations in parallel and the coordinator sums the
+// partial results. The FFTs of the quotient H are split with the four-step
+// algorithm in smaller transforms computed by the workers.
+//
+// [Coordinator.Prove] runs the regular prover with the coordinator as
+// accelerator, so the proofs are the same as the ones of the local prover. It
+// passes the full proving key to groth16_bn254.Prove, so the coordinator still
+// holds all the proving key points in memory: sharding the key reduces the
+// computation of the coordinator, not its memory.
+//
+// The accelerator is scoped to the call: it is passed to the prover through
+// [backend.WithProverAcceleratorEngine] and isn't registered globally. The
+// proving key points are identified on the workers by the keys KeyG1A, ...,
+// KeyG2B, which the prover gives to the accelerator with each
+// multi-exponentiation (see [backend.MultiExpConfig]).
+//
+// # Privacy
+//
+// The scalars of the multi-exponentiations are the wire values of the
+// solution and the FFT inputs are derived from them. They are sent to the
+// workers in the clear, so the workers learn the full witness, including its
+// secret part. The workers must be trusted like the coordinator itself.
+//
+// # Transport
+//
+// Workers are gRPC services, with messages encoded with encoding/gob under the
+// content subtype "gnark-gob", so no generated protobuf code is needed.
+// [Worker.Serve] serves a worker without transport security; use
+// [Worker.Register] to serve it on a custom grpc.Server (for instance with TLS
+// credentials). The coordinator takes the client connections, for instance
+// from grpc.Dial.
+//
+// The calls to the workers made by the prover carry its context, so that they
+// are abandoned when the proof is cancelled or its deadline (see
+// [backend.WithProverContext] and [backend.WithProverDeadline]) is exceeded.
+// Each call is also bounded by a timeout (see [WithCallTimeout]), and the
+// failure of a worker cancels the calls to the other ones. The workers stop
+// computing when a call is cancelled: they check its context between chunks of
+// 2¹⁸ points of the multi-exponentiations and between the transforms of a
+// batch of FFTs.
+//
+// The messages are limited to 2 GiB, which bounds the size of the shards: with
+// w workers, circuits up to about w·2²⁶ constraints are supported.
+//
+// The package is a module of its own, so that only its users depend on gRPC.
+package distributed
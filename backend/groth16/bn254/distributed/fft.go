@@ -0,0 +1,171 @@
+//go:build !verifier_only
+
+package distributed
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/internal/utils"
+	"google.golang.org/grpc"
+)
+
+// minFFTSize is the size below which the FFTs are left to the prover.
+const minFFTSize = 1 << 4
+
+// FFTArgs are the arguments for computing a batch of FFTs on a worker.
+type FFTArgs struct {
+	// Size is the size of each transform.
+	Size int
+	// Inverse is set for the inverse transforms, including the scaling by
+	// 1/Size.
+	Inverse bool
+	// Values is the binary encoding of the fr.Vector of the concatenated
+	// inputs, in natural order.
+	Values []byte
+}
+
+// FFTReply is the result of a batch of FFTs, the binary encoding of the
+// fr.Vector of the concatenated outputs, in natural order.
+type FFTReply struct {
+	Values []byte
+}
+
+// FFT implements [backend.Accelerator]. It transforms a in place as
+// domain.FFT or domain.FFTInverse would with the given configuration.
+//
+// The transform of size n = n₁n₂ is computed with the four-step algorithm: n₂
+// transforms of size n₁, a multiplication by twiddle factors and n₁ transforms
+// of size n₂. The small transforms are shared among the workers, the
+// coordinator only permutes and scales the values.
+func (c *Coordinator) FFT(ctx context.Context, curveID ecc.ID, a, domain any, config backend.FFTConfig) error {
+	v, ok := a.([]fr.Element)
+	if !ok || curveID != ecc.BN254 {
+		return backend.ErrAcceleratorUnsupported
+	}
+	d, ok := domain.(*fft.Domain)
+	if !ok || len(v) < minFFTSize {
+		return backend.ErrAcceleratorUnsupported
+	}
+	if uint64(len(v)) != d.Cardinality {
+		return fmt.Errorf("fft of %d elements on a domain of cardinality %d", len(v), d.Cardinality)
+	}
+
+	// decimation in time takes its input in bit reversed order, decimation in
+	// frequency returns its output in bit reversed order.
+	if !config.DIF {
+		fft.BitReverse(v)
+	}
+	if config.OnCoset && !config.Inverse {
+		scaleByPowers(v, d.FrMultiplicativeGen)
+	}
+	w := d.Generator
+	if config.Inverse {
+		w = d.GeneratorInv
+	}
+	if err := c.fourStep(ctx, v, w, config.Inverse); err != nil {
+		return err
+	}
+	if config.OnCoset && config.Inverse {
+		scaleByPowers(v, d.FrMultiplicativeGenInv)
+	}
+	if config.DIF {
+		fft.BitReverse(v)
+	}
+	return nil
+}
+
+// fourStep sets v to its transform in natural order, w being the generator of
+// the domain (or its inverse for the inverse transform).
+func (c *Coordinator) fourStep(ctx context.Context, v []fr.Element, w fr.Element, inverse bool) error {
+	n := len(v)
+	logN := bits.TrailingZeros(uint(n))
+	n1 := 1 << (logN / 2)
+	n2 := n / n1
+
+	// with j = n₂j₁ + j₂ and k = k₁ + n₁k₂, ωʲᵏ = ω₁^(j₁k₁)⋅ω^(j₂k₁)⋅ω₂^(j₂k₂)
+	// where ω₁ = ωⁿ² and ω₂ = ωⁿ¹ generate the domains of size n₁ and n₂.
+	tmp := make([]fr.Element, n)
+	transpose(tmp, v, n1, n2)
+	if err := c.batchFFT(ctx, tmp, n1, inverse); err != nil {
+		return err
+	}
+	utils.Parallelize(n2, func(start, end int) {
+		var wj2, t fr.Element
+		for j2 := start; j2 < end; j2++ {
+			wj2.Exp(w, big.NewInt(int64(j2)))
+			t.SetOne()
+			row := tmp[j2*n1 : (j2+1)*n1]
+			for k1 := range row {
+				row[k1].Mul(&row[k1], &t)
+				t.Mul(&t, &wj2)
+			}
+		}
+	})
+	transpose(v, tmp, n2, n1)
+	if err := c.batchFFT(ctx, v, n2, inverse); err != nil {
+		return err
+	}
+	transpose(tmp, v, n1, n2)
+	copy(v, tmp)
+	return nil
+}
+
+// batchFFT transforms in place the consecutive chunks of size elements of v,
+// the chunks being shared among the workers.
+func (c *Coordinator) batchFFT(ctx context.Context, v []fr.Element, size int, inverse bool) error {
+	bounds := c.bounds(len(v) / size)
+	return c.each(ctx, func(ctx context.Context, i int, conn grpc.ClientConnInterface) error {
+		if bounds[i] == bounds[i+1] {
+			return nil
+		}
+		chunk := fr.Vector(v[bounds[i]*size : bounds[i+1]*size])
+		data, err := chunk.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		var reply FFTReply
+		if err := c.call(ctx, conn, "FFT", &FFTArgs{Size: size, Inverse: inverse, Values: data}, &reply); err != nil {
+			return err
+		}
+		var res fr.Vector
+		if err := res.UnmarshalBinary(reply.Values); err != nil {
+			return fmt.Errorf("decode values: %w", err)
+		}
+		if len(res) != len(chunk) {
+			return fmt.Errorf("got %d values, want %d", len(res), len(chunk))
+		}
+		copy(chunk, res)
+		return nil
+	})
+}
+
+// transpose sets dst to the transpose of the rows×cols matrix src, stored
+// row by row.
+func transpose(dst, src []fr.Element, rows, cols int) {
+	utils.Parallelize(rows, func(start, end int) {
+		for r := start; r < end; r++ {
+			for c := 0; c < cols; c++ {
+				dst[c*rows+r] = src[r*cols+c]
+			}
+		}
+	})
+}
+
+// scaleByPowers sets v[i] to v[i]⋅gⁱ.
+func scaleByPowers(v []fr.Element, g fr.Element) {
+	utils.Parallelize(len(v), func(start, end int) {
+		var t fr.Element
+		t.Exp(g, big.NewInt(int64(start)))
+		for i := start; i < end; i++ {
+			v[i].Mul(&v[i], &t)
+			t.Mul(&t, &g)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+//go:build !verifier_only
+
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"math"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// serviceName is the name of the gRPC service of the workers.
+const serviceName = "gnark.distributed.Worker"
+
+// codecName is the content subtype of the gRPC messages, which are the Go
+// types of the package encoded with encoding/gob.
+const codecName = "gnark-gob"
+
+// maxMessageSize is the size limit of the gRPC messages, which carry whole
+// shards of points, scalars and FFT values.
+const maxMessageSize = math.MaxInt32
+
+func init() {
+	encoding.RegisterCodec(gobCodec{})
+}
+
+// gobCodec encodes the gRPC messages with encoding/gob, so that the service
+// doesn't need generated protobuf code.
+type gobCodec struct{}
+
+func (gobCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+func (gobCodec) Name() string {
+	return codecName
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		method("LoadG1", (*Worker).loadG1),
+		method("LoadG2", (*Worker).loadG2),
+		method("MultiExpG1", (*Worker).multiExpG1),
+		method("MultiExpG2", (*Worker).multiExpG2),
+		method("FFT", (*Worker).fft),
+	},
+	Metadata: "gnark/backend/groth16/bn254/distributed",
+}
+
+// method returns the description of the unary gRPC method name, served by f
+// with the context of the call.
+func method[A, R any](name string, f func(w *Worker, ctx context.Context, args *A) (*R, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			args := new(A)
+			if err := dec(args); err != nil {
+				return nil, err
+			}
+			w := srv.(*Worker)
+			if interceptor == nil {
+				return f(w, ctx, args)
+			}
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/" + name}
+			return interceptor(ctx, args, info, func(ctx context.Context, req any) (any, error) {
+				return f(w, ctx, req.(*A))
+			})
+		},
+	}
+}
+
+// call invokes the method of the worker behind conn, within the call timeout
+// of the coordinator.
+func (c *Coordinator) call(ctx context.Context, conn grpc.ClientConnInterface, method string, args, reply any) error {
+	if c.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.callTimeout)
+		defer cancel()
+	}
+	return conn.Invoke(ctx, "/"+serviceName+"/"+method, args, reply,
+		grpc.CallContentSubtype(codecName),
+		grpc.MaxCallSendMsgSize(maxMessageSize),
+		grpc.MaxCallRecvMsgSize(maxMessageSize))
+}
@@ -0,0 +1,244 @@
+//go:build !verifier_only
+
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	"github.com/consensys/gnark/internal/utils"
+	"google.golang.org/grpc"
+)
+
+// LoadArgs are the arguments for loading a shard of points on a worker.
+type LoadArgs struct {
+	// Key identifies the shard of points, for example KeyG1A.
+	Key string
+	// Append is set to append the points to the ones already loaded under Key,
+	// instead of replacing them. Large shards are loaded in several messages.
+	Append bool
+	// Points is the raw (uncompressed) encoding of the points, as produced by
+	// curve.NewEncoder with curve.RawEncoding.
+	Points []byte
+}
+
+// LoadReply is the result of loading points on a worker.
+type LoadReply struct {
+	// NbPoints is the number of points loaded under the key.
+	NbPoints int
+}
+
+// MultiExpArgs are the arguments for computing a partial multi-exponentiation
+// on a worker.
+type MultiExpArgs struct {
+	// Key identifies the shard of points previously loaded.
+	Key string
+	// Scalars is the binary encoding of the fr.Vector of scalars. It may be
+	// shorter than the shard, in which case only the first points are used.
+	Scalars []byte
+}
+
+// MultiExpReply is the result of a partial multi-exponentiation, the raw
+// encoding of the affine result.
+type MultiExpReply struct {
+	Result []byte
+}
+
+// multiExpChunkSize is the number of points of the multi-exponentiations
+// computed by a worker between two checks of the context of the call.
+const multiExpChunkSize = 1 << 18
+
+// Worker stores shards of proving key points and computes partial
+// multi-exponentiations and batches of FFTs on request of a Coordinator.
+type Worker struct {
+	lock    sync.RWMutex
+	g1      map[string][]curve.G1Affine
+	g2      map[string][]curve.G2Affine
+	domains map[int]*fft.Domain
+	nbTasks int
+}
+
+// NewWorker returns a new worker using nbTasks goroutines per
+// multi-exponentiation. If nbTasks is not positive, runtime.NumCPU() is used.
+func NewWorker(nbTasks int) *Worker {
+	if nbTasks <= 0 {
+		nbTasks = runtime.NumCPU()
+	}
+	return &Worker{
+		g1:      make(map[string][]curve.G1Affine),
+		g2:      make(map[string][]curve.G2Affine),
+		domains: make(map[int]*fft.Domain),
+		nbTasks: nbTasks,
+	}
+}
+
+// Serve accepts connections on the listener and serves the requests of the
+// coordinators with a gRPC server, without transport security. It blocks until
+// the listener returns an error. See Register for configuring the server.
+func (w *Worker) Serve(l net.Listener) error {
+	s := grpc.NewServer(grpc.MaxRecvMsgSize(maxMessageSize), grpc.MaxSendMsgSize(maxMessageSize))
+	w.Register(s)
+	return s.Serve(l)
+}
+
+// Register registers the worker service on the gRPC server s, for instance
+// configured with TLS credentials. The server must accept messages as large as
+// the shards sent by the coordinator, see grpc.MaxRecvMsgSize and
+// grpc.MaxSendMsgSize.
+func (w *Worker) Register(s *grpc.Server) {
+	s.RegisterService(&serviceDesc, w)
+}
+
+func (w *Worker) loadG1(ctx context.Context, args *LoadArgs) (*LoadReply, error) {
+	var points []curve.G1Affine
+	if err := curve.NewDecoder(bytes.NewReader(args.Points)).Decode(&points); err != nil {
+		return nil, fmt.Errorf("decode points: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if args.Append {
+		points = append(w.g1[args.Key], points...)
+	}
+	w.g1[args.Key] = points
+	return &LoadReply{NbPoints: len(points)}, nil
+}
+
+func (w *Worker) loadG2(ctx context.Context, args *LoadArgs) (*LoadReply, error) {
+	var points []curve.G2Affine
+	if err := curve.NewDecoder(bytes.NewReader(args.Points)).Decode(&points); err != nil {
+		return nil, fmt.Errorf("decode points: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if args.Append {
+		points = append(w.g2[args.Key], points...)
+	}
+	w.g2[args.Key] = points
+	return &LoadReply{NbPoints: len(points)}, nil
+}
+
+func (w *Worker) multiExpG1(ctx context.Context, args *MultiExpArgs) (*MultiExpReply, error) {
+	w.lock.RLock()
+	points, ok := w.g1[args.Key]
+	w.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown shard %q", args.Key)
+	}
+	var scalars fr.Vector
+	if err := scalars.UnmarshalBinary(args.Scalars); err != nil {
+		return nil, fmt.Errorf("decode scalars: %w", err)
+	}
+	if len(scalars) > len(points) {
+		return nil, errors.New("more scalars than points in shard")
+	}
+	var acc, chunk curve.G1Jac
+	for start := 0; start < len(scalars); start += multiExpChunkSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		end := min(start+multiExpChunkSize, len(scalars))
+		if _, err := chunk.MultiExp(points[start:end], scalars[start:end], ecc.MultiExpConfig{NbTasks: w.nbTasks}); err != nil {
+			return nil, err
+		}
+		acc.AddAssign(&chunk)
+	}
+	var res curve.G1Affine
+	res.FromJacobian(&acc)
+	b := res.RawBytes()
+	return &MultiExpReply{Result: b[:]}, nil
+}
+
+func (w *Worker) multiExpG2(ctx context.Context, args *MultiExpArgs) (*MultiExpReply, error) {
+	w.lock.RLock()
+	points, ok := w.g2[args.Key]
+	w.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown shard %q", args.Key)
+	}
+	var scalars fr.Vector
+	if err := scalars.UnmarshalBinary(args.Scalars); err != nil {
+		return nil, fmt.Errorf("decode scalars: %w", err)
+	}
+	if len(scalars) > len(points) {
+		return nil, errors.New("more scalars than points in shard")
+	}
+	var acc, chunk curve.G2Jac
+	for start := 0; start < len(scalars); start += multiExpChunkSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		end := min(start+multiExpChunkSize, len(scalars))
+		if _, err := chunk.MultiExp(points[start:end], scalars[start:end], ecc.MultiExpConfig{NbTasks: w.nbTasks}); err != nil {
+			return nil, err
+		}
+		acc.AddAssign(&chunk)
+	}
+	var res curve.G2Affine
+	res.FromJacobian(&acc)
+	b := res.RawBytes()
+	return &MultiExpReply{Result: b[:]}, nil
+}
+
+func (w *Worker) fft(ctx context.Context, args *FFTArgs) (*FFTReply, error) {
+	var values fr.Vector
+	if err := values.UnmarshalBinary(args.Values); err != nil {
+		return nil, fmt.Errorf("decode values: %w", err)
+	}
+	if args.Size <= 0 || args.Size&(args.Size-1) != 0 || len(values)%args.Size != 0 {
+		return nil, fmt.Errorf("invalid fft size %d for %d values", args.Size, len(values))
+	}
+	domain := w.domain(args.Size)
+	nbTransforms := len(values) / args.Size
+	var cancelled atomic.Bool
+	utils.Parallelize(nbTransforms, func(start, end int) {
+		for i := start; i < end; i++ {
+			if ctx.Err() != nil {
+				cancelled.Store(true)
+				return
+			}
+			v := values[i*args.Size : (i+1)*args.Size]
+			if args.Inverse {
+				domain.FFTInverse(v, fft.DIF, fft.WithNbTasks(1))
+			} else {
+				domain.FFT(v, fft.DIF, fft.WithNbTasks(1))
+			}
+			fft.BitReverse(v)
+		}
+	}, w.nbTasks)
+	if cancelled.Load() {
+		return nil, ctx.Err()
+	}
+	data, err := values.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &FFTReply{Values: data}, nil
+}
+
+// domain returns the FFT domain of the given size, computed on first use.
+func (w *Worker) domain(size int) *fft.Domain {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	d, ok := w.domains[size]
+	if !ok {
+		d = fft.NewDomain(uint64(size))
+		w.domains[size] = d
+	}
+	return d
+}
@@ -1,9 +1,11 @@
-//go:build icicle
+//go:build icicle && !verifier_only
 
 package icicle_bn254
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"math/bits"
 	"time"
@@ -17,12 +19,14 @@ import (
 	"github.com/consensys/gnark/backend"
 	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
 	"github.com/consensys/gnark/backend/groth16/internal"
+	"github.com/consensys/gnark/backend/internal/stages"
 	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/constraint"
 	cs "github.com/consensys/gnark/constraint/bn254"
 	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/internal/utils"
 	"github.com/consensys/gnark/logger"
+	"github.com/consensys/gnark/security"
 	iciclegnark "github.com/ingonyama-zk/iciclegnark/curves/bn254"
 )
 
@@ -130,6 +134,10 @@ func (pk *ProvingKey) setupDevicePointers() error {
 }
 
 // Prove generates the proof of knowledge of a r1cs with full witness (secret + public part).
+// With [backend.WithProverSelfCheck], the proof is computed by the CPU prover.
+// The GPU prover reports its stages and honours the context and the deadline
+// as the CPU prover, but returns an error with [backend.WithProverMemoryBudget]
+// as the budget doesn't account for the device memory.
 func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...backend.ProverOption) (*groth16_bn254.Proof, error) {
 	opt, err := backend.NewProverConfig(opts...)
 	if err != nil {
@@ -138,9 +146,21 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 	if opt.HashToFieldFn == nil {
 		opt.HashToFieldFn = hash_to_field.New([]byte(constraint.CommitmentDst))
 	}
-	if opt.Accelerator != "icicle" {
+	// the quotient is computed in device memory, where the self check can't
+	// read it: the self check runs the CPU prover.
+	if opt.Accelerator != "icicle" || opt.SelfCheck {
 		return groth16_bn254.Prove(r1cs, &pk.ProvingKey, fullWitness, opts...)
 	}
+	if opt.MemoryBudget != 0 {
+		return nil, errors.New("memory budget not supported by the icicle prover")
+	}
+	if err := security.CheckProverConfig(r1cs.CurveID(), &opt); err != nil {
+		return nil, err
+	}
+
+	rec, cancel := stages.NewRecorder(&opt, nbProverStages)
+	defer cancel()
+
 	log := logger.Logger().With().Str("curve", r1cs.CurveID().String()).Str("acceleration", "icicle").Int("nbConstraints", r1cs.GetNbConstraints()).Str("backend", "groth16").Logger()
 	if pk.deviceInfo == nil {
 		log.Debug().Msg("precomputing proving key in GPU")
@@ -197,6 +217,10 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 	if err != nil {
 		return nil, err
 	}
+	rec.Done("solve")
+	if err := rec.Err(); err != nil {
+		return nil, err
+	}
 
 	solution := _solution.(*cs.R1CSSolution)
 	wireValues := []fr.Element(solution.W)
@@ -279,13 +303,12 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 
 	// sample random r and s
 	var r, s big.Int
-	var _r, _s, _kr fr.Element
-	if _, err := _r.SetRandom(); err != nil {
-		return nil, err
-	}
-	if _, err := _s.SetRandom(); err != nil {
+	var _kr fr.Element
+	rs, err := randomElements(opt.RandomSource, 2)
+	if err != nil {
 		return nil, err
 	}
+	_r, _s := rs[0], rs[1]
 	_kr.Mul(&_r, &_s).Neg(&_kr)
 
 	_r.BigInt(&r)
@@ -394,6 +417,10 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 
 	// wait for FFT to end
 	<-chHDone
+	rec.Done("quotient")
+	if err := rec.Err(); err != nil {
+		return nil, err
+	}
 
 	// schedule our proof part computations
 	if err := computeAR1(); err != nil {
@@ -408,6 +435,7 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 	if err := computeBS2(); err != nil {
 		return nil, err
 	}
+	rec.Done("multi exponentiations")
 
 	log.Debug().Dur("took", time.Since(start)).Msg("prover done")
 
@@ -421,6 +449,33 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 	return proof, nil
 }
 
+// nbProverStages is the number of stages of Prove, for progress reports.
+const nbProverStages = 3
+
+// randomElements returns n field elements sampled from src. If src is nil, the
+// elements are sampled from crypto/rand.
+func randomElements(src io.Reader, n int) ([]fr.Element, error) {
+	res := make([]fr.Element, n)
+	if src == nil {
+		for i := range res {
+			if _, err := res[i].SetRandom(); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+	// sample twice as many bytes as needed to make the modular bias negligible
+	var buf [2 * fr.Bytes]byte
+	var b big.Int
+	for i := range res {
+		if _, err := io.ReadFull(src, buf[:]); err != nil {
+			return nil, fmt.Errorf("read randomness: %w", err)
+		}
+		res[i].SetBigInt(b.SetBytes(buf[:]))
+	}
+	return res, nil
+}
+
 // if len(toRemove) == 0, returns slice
 // else, returns a new slice without the indexes in toRemove. The first value in the slice is taken as indexes as sliceFirstIndex
 // this assumes len(slice) > len(toRemove)
@@ -1,3 +1,5 @@
+//go:build !verifier_only
+
 package icicle_bn254_test
 
 import (
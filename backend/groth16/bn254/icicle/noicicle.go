@@ -1,4 +1,4 @@
-//go:build !icicle
+//go:build !icicle && !verifier_only
 
 package icicle_bn254
 
@@ -0,0 +1,117 @@
+package mpcsetup
+
+import (
+	"fmt"
+	"io"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/internal/ptau"
+)
+
+// ErrInvalidTranscript is returned when the points read from a Powers of Tau
+// transcript are not the parameters of a valid ceremony.
+var ErrInvalidTranscript = ptau.ErrInvalidTranscript
+
+// InitPhase1FromPtau initializes phase 1 of the MPC from the challenge file of
+// the Perpetual Powers of Tau ceremony (https://github.com/privacy-scaling-explorations/perpetualpowersoftau),
+// instead of running phase 1 from scratch with InitPhase1. For a ceremony of
+// power n the file is laid out as
+//
+//	[64]byte      BLAKE2b hash of the previous response
+//	[2ⁿ⁺¹-1]G1    {[τ⁰]₁, [τ¹]₁, …}
+//	[2ⁿ]G2        {[τ⁰]₂, [τ¹]₂, …}
+//	[2ⁿ]G1        {α[τ⁰]₁, α[τ¹]₁, …}
+//	[2ⁿ]G1        {β[τ⁰]₁, β[τ¹]₁, …}
+//	G2            [β]₂
+//
+// where the points are uncompressed and big-endian encoded. The parameters are
+// truncated to 2^power, which must not exceed the size of the ceremony.
+//
+// The points are checked to be in the correct subgroup and to be consistent
+// powers of the same τ, α and β. The returned Phase1 is the first link of the
+// contribution chain: it can be contributed to and passed to InitPhase2 as any
+// other Phase1.
+func InitPhase1FromPtau(r io.ReadSeeker, ceremonyPower uint8, power int) (phase1 Phase1, err error) {
+	layout, err := ptau.NewLayout(ceremonyPower)
+	if err != nil {
+		return phase1, err
+	}
+	if power < 1 || power > int(ceremonyPower) {
+		return phase1, fmt.Errorf("power %d out of range [1, %d]", power, ceremonyPower)
+	}
+	N := 1 << power
+
+	p := &phase1.Parameters
+	p.G1.Tau = make([]curve.G1Affine, 2*N-1)
+	p.G2.Tau = make([]curve.G2Affine, N)
+	p.G1.AlphaTau = make([]curve.G1Affine, N)
+	p.G1.BetaTau = make([]curve.G1Affine, N)
+
+	if err = ptau.ReadG1(r, layout.TauG1, p.G1.Tau); err != nil {
+		return phase1, fmt.Errorf("read τ powers in G1: %w", err)
+	}
+	if err = ptau.ReadG2(r, layout.TauG2, p.G2.Tau); err != nil {
+		return phase1, fmt.Errorf("read τ powers in G2: %w", err)
+	}
+	if err = ptau.ReadG1(r, layout.AlphaTauG1, p.G1.AlphaTau); err != nil {
+		return phase1, fmt.Errorf("read ατ powers in G1: %w", err)
+	}
+	if err = ptau.ReadG1(r, layout.BetaTauG1, p.G1.BetaTau); err != nil {
+		return phase1, fmt.Errorf("read βτ powers in G1: %w", err)
+	}
+	beta := []curve.G2Affine{{}}
+	if err = ptau.ReadG2(r, layout.BetaG2, beta); err != nil {
+		return phase1, fmt.Errorf("read [β]₂: %w", err)
+	}
+	p.G2.Beta = beta[0]
+
+	if err = checkPtauParameters(&phase1); err != nil {
+		return phase1, err
+	}
+
+	// the transcript is the starting point of the contribution chain, as the
+	// generators are in InitPhase1.
+	var one fr.Element
+	one.SetOne()
+	phase1.PublicKeys.Tau = newPublicKey(one, nil, 1)
+	phase1.PublicKeys.Alpha = newPublicKey(one, nil, 2)
+	phase1.PublicKeys.Beta = newPublicKey(one, nil, 3)
+	phase1.Hash = phase1.hash()
+
+	return phase1, nil
+}
+
+// checkPtauParameters checks that the phase 1 parameters read from a
+// transcript start with the generators and are consistent powers of τ, α and β.
+func checkPtauParameters(phase1 *Phase1) error {
+	p := &phase1.Parameters
+	_, _, g1, g2 := curve.Generators()
+	if !p.G1.Tau[0].Equal(&g1) || !p.G2.Tau[0].Equal(&g2) {
+		return fmt.Errorf("%w: first powers are not the generators", ErrInvalidTranscript)
+	}
+	if p.G2.Tau[1].IsInfinity() || p.G1.AlphaTau[0].IsInfinity() || p.G2.Beta.IsInfinity() {
+		return fmt.Errorf("%w: toxic parameter is zero", ErrInvalidTranscript)
+	}
+
+	tauL1, tauL2 := linearCombinationG1(p.G1.Tau)
+	if !sameRatio(tauL1, tauL2, p.G2.Tau[1], g2) {
+		return fmt.Errorf("%w: invalid powers of τ in G₁", ErrInvalidTranscript)
+	}
+	tau2L1, tau2L2 := linearCombinationG2(p.G2.Tau)
+	if !sameRatio(p.G1.Tau[1], g1, tau2L1, tau2L2) {
+		return fmt.Errorf("%w: invalid powers of τ in G₂", ErrInvalidTranscript)
+	}
+	alphaL1, alphaL2 := linearCombinationG1(p.G1.AlphaTau)
+	if !sameRatio(alphaL1, alphaL2, p.G2.Tau[1], g2) {
+		return fmt.Errorf("%w: invalid powers of α(τ) in G₁", ErrInvalidTranscript)
+	}
+	betaL1, betaL2 := linearCombinationG1(p.G1.BetaTau)
+	if !sameRatio(betaL1, betaL2, p.G2.Tau[1], g2) {
+		return fmt.Errorf("%w: invalid powers of β(τ) in G₁", ErrInvalidTranscript)
+	}
+	if !sameRatio(p.G1.BetaTau[0], g1, g2, p.G2.Beta) {
+		return fmt.Errorf("%w: [β]₁ and [β]₂ differ", ErrInvalidTranscript)
+	}
+	return nil
+}
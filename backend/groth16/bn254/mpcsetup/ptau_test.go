@@ -0,0 +1,71 @@
+//go:build !verifier_only
+
+package mpcsetup
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	native_mimc "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/internal/ptau"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitPhase1FromPtau(t *testing.T) {
+	const (
+		ceremonyPower = 10
+		power         = 9
+	)
+	assert := require.New(t)
+	file := ptau.ChallengeFile(ceremonyPower, big.NewInt(42), big.NewInt(5), big.NewInt(7))
+
+	srs1, err := InitPhase1FromPtau(bytes.NewReader(file), ceremonyPower, power)
+	assert.NoError(err)
+
+	// the transcript starts the contribution chain
+	prev := srs1.clone()
+	srs1.Contribute()
+	assert.NoError(VerifyPhase1(&prev, &srs1))
+
+	var myCircuit Circuit
+	ccs, err := frontend.Compile(curve.ID.ScalarField(), r1cs.NewBuilder, &myCircuit)
+	assert.NoError(err)
+	srs2, evals := InitPhase2(ccs.(*cs.R1CS), &srs1)
+	prev2 := srs2.clone()
+	srs2.Contribute()
+	assert.NoError(VerifyPhase2(&prev2, &srs2))
+	pk, vk := ExtractKeys(&srs1, &srs2, &evals, ccs.GetNbConstraints())
+
+	var preImage, hash fr.Element
+	{
+		m := native_mimc.NewMiMC()
+		m.Write(preImage.Marshal())
+		hash.SetBytes(m.Sum(nil))
+	}
+	witness, err := frontend.NewWitness(&Circuit{PreImage: preImage, Hash: hash}, curve.ID.ScalarField())
+	assert.NoError(err)
+	pubWitness, err := witness.Public()
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, &pk, witness)
+	assert.NoError(err)
+	assert.NoError(groth16.Verify(proof, &vk, pubWitness))
+
+	// the ceremony is too small
+	_, err = InitPhase1FromPtau(bytes.NewReader(file), ceremonyPower, ceremonyPower+1)
+	assert.Error(err)
+
+	// the points are not consecutive powers of τ
+	pointSize := curve.SizeOfG1AffineUncompressed
+	tampered := bytes.Clone(file)
+	copy(tampered[ptau.HashSize+3*pointSize:ptau.HashSize+4*pointSize], file[ptau.HashSize+2*pointSize:ptau.HashSize+3*pointSize])
+	_, err = InitPhase1FromPtau(bytes.NewReader(tampered), ceremonyPower, power)
+	assert.True(errors.Is(err, ErrInvalidTranscript), "unexpected error: %v", err)
+}
@@ -1,3 +1,6 @@
+//go:build !verifier_only
+// +build !verifier_only
+
 // Copyright 2020 ConsenSys Software Inc.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -17,6 +20,8 @@
 package groth16
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc"
 	curve "github.com/consensys/gnark-crypto/ecc/bn254"
@@ -26,41 +31,81 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen"
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/groth16/internal"
+	"github.com/consensys/gnark/backend/internal/stages"
 	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/constraint"
 	cs "github.com/consensys/gnark/constraint/bn254"
 	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/internal/utils"
 	"github.com/consensys/gnark/logger"
+	"github.com/consensys/gnark/security"
+	"io"
 	"math/big"
+	"math/bits"
 	"runtime"
+	"sync"
 	"time"
 
 	fcs "github.com/consensys/gnark/frontend/cs"
 )
 
-// Proof represents a Groth16 proof that was encoded with a ProvingKey and can be verified
-// with a valid statement and a VerifyingKey
-// Notation follows Figure 4. in DIZK paper https://eprint.iacr.org/2018/691.pdf
-type Proof struct {
-	Ar, Krs       curve.G1Affine
-	Bs            curve.G2Affine
-	Commitments   []curve.G1Affine // Pedersen commitments a la https://eprint.iacr.org/2022/1072
-	CommitmentPok curve.G1Affine   // Batched proof of knowledge of the above commitments
+// Prove generates the proof of knowledge of a r1cs with full witness (secret + public part).
+func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...backend.ProverOption) (*Proof, error) {
+	return prove(r1cs, pk, fullWitness, &proverBuffers{}, opts...)
 }
 
-// isValid ensures proof elements are in the correct subgroup
-func (proof *Proof) isValid() bool {
-	return proof.Ar.IsInSubGroup() && proof.Krs.IsInSubGroup() && proof.Bs.IsInSubGroup()
+// ProverSession proves witnesses of the same circuit with the same ProvingKey.
+// The working memory of the prover is allocated by the first proof and reused
+// by the next ones, which avoids re-allocating it when proving many
+// witnesses.
+//
+// Prove calls are serialized, a ProverSession is safe for concurrent use.
+type ProverSession struct {
+	r1cs *cs.R1CS
+	pk   *ProvingKey
+	lock sync.Mutex
+	buf  proverBuffers
 }
 
-// CurveID returns the curveID
-func (proof *Proof) CurveID() ecc.ID {
-	return curve.ID
+// NewProverSession returns a ProverSession for the constraint system and
+// the ProvingKey.
+func NewProverSession(r1cs *cs.R1CS, pk *ProvingKey) *ProverSession {
+	return &ProverSession{r1cs: r1cs, pk: pk}
 }
 
-// Prove generates the proof of knowledge of a r1cs with full witness (secret + public part).
-func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...backend.ProverOption) (*Proof, error) {
+// Prove generates the proof of knowledge of the constraint system of the
+// session with full witness, as Prove.
+func (s *ProverSession) Prove(fullWitness witness.Witness, opts ...backend.ProverOption) (*Proof, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	proof, err := prove(s.r1cs, s.pk, fullWitness, &s.buf, opts...)
+	if err != nil {
+		// the buffers may still be used by the aborted computations
+		s.buf = proverBuffers{}
+	}
+	return proof, err
+}
+
+// proverBuffers is the working memory of the prover.
+type proverBuffers struct {
+	// a, b and c are the evaluations of the quotient computation, of the size
+	// of the domain.
+	a, b, c []fr.Element
+	// wireValuesA and wireValuesB are the scalars of the multi-exponentiations
+	// in [A]₁ and [B]₁,₂.
+	wireValuesA, wireValuesB []fr.Element
+}
+
+// take returns (*s)[:n], reallocating *s if it is too small.
+func take(s *[]fr.Element, n int) []fr.Element {
+	if cap(*s) < n {
+		*s = make([]fr.Element, n)
+	}
+	*s = (*s)[:n]
+	return *s
+}
+
+func prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, buf *proverBuffers, opts ...backend.ProverOption) (*Proof, error) {
 	opt, err := backend.NewProverConfig(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("new prover config: %w", err)
@@ -68,8 +113,35 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 	if opt.HashToFieldFn == nil {
 		opt.HashToFieldFn = hash_to_field.New([]byte(constraint.CommitmentDst))
 	}
+	if err := security.CheckProverConfig(r1cs.CurveID(), &opt); err != nil {
+		return nil, err
+	}
+	// when the multi-exponentiations computed concurrently don't fit in the
+	// memory budget, they are computed one after the other.
+	sequentialMSM := false
+	if opt.MemoryBudget != 0 {
+		if memoryEstimate(r1cs, pk, false) > opt.MemoryBudget {
+			if m := memoryEstimate(r1cs, pk, true); m > opt.MemoryBudget {
+				return nil, fmt.Errorf("%w: proving needs about %d bytes, budget is %d", backend.ErrInsufficientMemoryBudget, m, opt.MemoryBudget)
+			}
+			sequentialMSM = true
+		}
+	}
 
-	log := logger.Logger().With().Str("curve", r1cs.CurveID().String()).Str("acceleration", "none").Int("nbConstraints", r1cs.GetNbConstraints()).Str("backend", "groth16").Logger()
+	rec, cancel := stages.NewRecorder(&opt, nbProverStages)
+	defer cancel()
+
+	acc, ctx := opt.AcceleratorEngine, opt.Context
+	acceleration := "none"
+	n := opt.NbTasks
+	if n == 0 {
+		n = runtime.NumCPU()
+	}
+	if acc != nil {
+		acceleration = opt.Accelerator
+	}
+
+	log := logger.Logger().With().Str("curve", r1cs.CurveID().String()).Str("acceleration", acceleration).Int("nbConstraints", r1cs.GetNbConstraints()).Str("backend", "groth16").Logger()
 
 	commitmentInfo := r1cs.CommitmentInfo.(constraint.Groth16Commitments)
 
@@ -113,6 +185,10 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 	if err != nil {
 		return nil, err
 	}
+	rec.Done("solve")
+	if err := rec.Err(); err != nil {
+		return nil, err
+	}
 
 	solution := _solution.(*cs.R1CSSolution)
 	wireValues := []fr.Element(solution.W)
@@ -130,13 +206,17 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 
 	// H (witness reduction / FFT part)
 	var h []fr.Element
-	chHDone := make(chan struct{}, 1)
+	chHDone := make(chan error, 1)
 	go func() {
-		h = computeH(solution.A, solution.B, solution.C, &pk.Domain)
+		var err error
+		h, err = computeH(ctx, acc, solution.A, solution.B, solution.C, &pk.Domain, buf, n)
+		if err == nil && opt.SelfCheck {
+			err = checkQuotient(solution.A, solution.B, solution.C, h, &pk.Domain)
+		}
 		solution.A = nil
 		solution.B = nil
 		solution.C = nil
-		chHDone <- struct{}{}
+		chHDone <- err
 	}()
 
 	// we need to copy and filter the wireValues for each multi exp
@@ -145,7 +225,7 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 	chWireValuesA, chWireValuesB := make(chan struct{}, 1), make(chan struct{}, 1)
 
 	go func() {
-		wireValuesA = make([]fr.Element, len(wireValues)-int(pk.NbInfinityA))
+		wireValuesA = take(&buf.wireValuesA, len(wireValues)-int(pk.NbInfinityA))
 		for i, j := 0, 0; j < len(wireValuesA); i++ {
 			if pk.InfinityA[i] {
 				continue
@@ -156,7 +236,7 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 		close(chWireValuesA)
 	}()
 	go func() {
-		wireValuesB = make([]fr.Element, len(wireValues)-int(pk.NbInfinityB))
+		wireValuesB = take(&buf.wireValuesB, len(wireValues)-int(pk.NbInfinityB))
 		for i, j := 0, 0; j < len(wireValuesB); i++ {
 			if pk.InfinityB[i] {
 				continue
@@ -169,13 +249,12 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 
 	// sample random r and s
 	var r, s big.Int
-	var _r, _s, _kr fr.Element
-	if _, err := _r.SetRandom(); err != nil {
-		return nil, err
-	}
-	if _, err := _s.SetRandom(); err != nil {
+	var _kr fr.Element
+	rs, err := randomElements(opt.RandomSource, 2)
+	if err != nil {
 		return nil, err
 	}
+	_r, _s := rs[0], rs[1]
 	_kr.Mul(&_r, &_s).Neg(&_kr)
 
 	_r.BigInt(&r)
@@ -186,12 +265,10 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 
 	var bs1, ar curve.G1Jac
 
-	n := runtime.NumCPU()
-
 	chBs1Done := make(chan error, 1)
 	computeBS1 := func() {
 		<-chWireValuesB
-		if _, err := bs1.MultiExp(pk.G1.B, wireValuesB, ecc.MultiExpConfig{NbTasks: n / 2}); err != nil {
+		if err := multiExpG1(ctx, acc, backend.PointsGroth16G1B, &bs1, pk.G1.B, wireValuesB, ecc.MultiExpConfig{NbTasks: max(n/2, 1)}, opt.SelfCheck); err != nil {
 			chBs1Done <- err
 			close(chBs1Done)
 			return
@@ -204,7 +281,7 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 	chArDone := make(chan error, 1)
 	computeAR1 := func() {
 		<-chWireValuesA
-		if _, err := ar.MultiExp(pk.G1.A, wireValuesA, ecc.MultiExpConfig{NbTasks: n / 2}); err != nil {
+		if err := multiExpG1(ctx, acc, backend.PointsGroth16G1A, &ar, pk.G1.A, wireValuesA, ecc.MultiExpConfig{NbTasks: max(n/2, 1)}, opt.SelfCheck); err != nil {
 			chArDone <- err
 			close(chArDone)
 			return
@@ -223,10 +300,14 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 		var krs, krs2, p1 curve.G1Jac
 		chKrs2Done := make(chan error, 1)
 		sizeH := int(pk.Domain.Cardinality - 1) // comes from the fact the deg(H)=(n-1)+(n-1)-n=n-2
-		go func() {
-			_, err := krs2.MultiExp(pk.G1.Z, h[:sizeH], ecc.MultiExpConfig{NbTasks: n / 2})
-			chKrs2Done <- err
-		}()
+		computeKRS2 := func() {
+			chKrs2Done <- multiExpG1(ctx, acc, backend.PointsGroth16G1Z, &krs2, pk.G1.Z, h[:sizeH], ecc.MultiExpConfig{NbTasks: max(n/2, 1)}, opt.SelfCheck)
+		}
+		if sequentialMSM {
+			computeKRS2()
+		} else {
+			go computeKRS2()
+		}
 
 		// filter the wire values if needed
 		// TODO Perf @Tabaie worst memory allocation offender
@@ -234,7 +315,7 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 		toRemove = append(toRemove, commitmentInfo.CommitmentIndexes())
 		_wireValues := filterHeap(wireValues[r1cs.GetNbPublicVariables():], r1cs.GetNbPublicVariables(), internal.ConcatAll(toRemove...))
 
-		if _, err := krs.MultiExp(pk.G1.K, _wireValues, ecc.MultiExpConfig{NbTasks: n / 2}); err != nil {
+		if err := multiExpG1(ctx, acc, backend.PointsGroth16G1K, &krs, pk.G1.K, _wireValues, ecc.MultiExpConfig{NbTasks: max(n/2, 1)}, opt.SelfCheck); err != nil {
 			chKrsDone <- err
 			return
 		}
@@ -280,7 +361,7 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 			nbTasks *= 2
 		}
 		<-chWireValuesB
-		if _, err := Bs.MultiExp(pk.G2.B, wireValuesB, ecc.MultiExpConfig{NbTasks: nbTasks}); err != nil {
+		if err := multiExpG2(ctx, acc, backend.PointsGroth16G2B, &Bs, pk.G2.B, wireValuesB, ecc.MultiExpConfig{NbTasks: nbTasks}, opt.SelfCheck); err != nil {
 			return err
 		}
 
@@ -294,14 +375,29 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 	}
 
 	// wait for FFT to end, as it uses all our CPUs
-	<-chHDone
+	if err := <-chHDone; err != nil {
+		return nil, err
+	}
+	rec.Done("quotient")
+	if err := rec.Err(); err != nil {
+		return nil, err
+	}
 
 	// schedule our proof part computations
-	go computeKRS()
-	go computeAR1()
-	go computeBS1()
-	if err := computeBS2(); err != nil {
-		return nil, err
+	if sequentialMSM {
+		computeAR1()
+		computeBS1()
+		if err := computeBS2(); err != nil {
+			return nil, err
+		}
+		computeKRS()
+	} else {
+		go computeKRS()
+		go computeAR1()
+		go computeBS1()
+		if err := computeBS2(); err != nil {
+			return nil, err
+		}
 	}
 
 	// wait for all parts of the proof to be computed.
@@ -309,11 +405,16 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 		return nil, err
 	}
 
+	rec.Done("multi exponentiations")
+
 	log.Debug().Dur("took", time.Since(start)).Msg("prover done")
 
 	return proof, nil
 }
 
+// nbProverStages is the number of stages of Prove, for progress reports.
+const nbProverStages = 3
+
 // if len(toRemove) == 0, returns slice
 // else, returns a new slice without the indexes in toRemove. The first value in the slice is taken as indexes as sliceFirstIndex
 // this assumes len(slice) > len(toRemove)
@@ -343,29 +444,152 @@ func filterHeap(slice []fr.Element, sliceFirstIndex int, toRemove []int) (r []fr
 	return
 }
 
-func computeH(a, b, c []fr.Element, domain *fft.Domain) []fr.Element {
+// memoryEstimate returns an estimate of the number of bytes allocated by the
+// prover: the solution of the constraint system, its filtered copies for the
+// multi-exponentiations, the vectors of the quotient computation, and the
+// working memory of the multi-exponentiations, all at once or one at a time if
+// sequential is set.
+//
+// The estimate is an upper bound of the live vectors and buckets, assuming all
+// the windows of a multi-exponentiation are processed at once. It ignores the
+// allocations of the solver and of the Pedersen commitments. On BN254 with 2¹⁸
+// constraints, it is about 30% above the peak heap and stack usage measured
+// with the default GC settings, concurrent or sequential. The budget can thus
+// be set to the memory available to the prover.
+func memoryEstimate(r1cs *cs.R1CS, pk *ProvingKey, sequential bool) uint64 {
+	nbInternal, nbSecret, nbPublic := r1cs.GetNbVariables()
+	nbWires := uint64(nbInternal + nbSecret + nbPublic)
+	nbConstraints := uint64(r1cs.GetNbConstraints())
+	vectors := (4*nbWires + 3*nbConstraints + 4*pk.Domain.Cardinality) * fr.Bytes
+	return vectors + msmWorkingMemory(pk, sequential)
+}
+
+// msmWorkingMemory returns the working memory of the multi-exponentiations of
+// the prover, computed all at once or one at a time if sequential is set.
+func msmWorkingMemory(pk *ProvingKey, sequential bool) uint64 {
+	msms := []uint64{
+		msmMemory(uint64(len(pk.G1.A)), 3*curve.SizeOfG1AffineUncompressed),
+		msmMemory(uint64(len(pk.G1.B)), 3*curve.SizeOfG1AffineUncompressed),
+		msmMemory(uint64(len(pk.G1.K)), 3*curve.SizeOfG1AffineUncompressed),
+		msmMemory(uint64(len(pk.G1.Z)), 3*curve.SizeOfG1AffineUncompressed),
+		msmMemory(uint64(len(pk.G2.B)), 3*curve.SizeOfG2AffineUncompressed),
+	}
+	var res uint64
+	for _, m := range msms {
+		if sequential {
+			res = max(res, m)
+		} else {
+			res += m
+		}
+	}
+	return res
+}
+
+// msmMemory returns an estimate of the working memory of a multi-exponentiation
+// of size m, with buckets of bucketSize bytes: the digits of the scalars, and
+// the 2ᶜ⁻¹ buckets of each of the windows of c bits, which are allocated on the
+// stacks of concurrent goroutines. With batch affine additions, gnark-crypto
+// keeps the buckets both in affine and extended Jacobian coordinates, 3 affine
+// points. It chooses c to minimize the number of operations, which is
+// approximated by log₂(m)-3 in [4, 16].
+func msmMemory(m, bucketSize uint64) uint64 {
+	if m == 0 {
+		return 0
+	}
+	c := uint64(min(max(bits.Len64(m)-3, 4), 16))
+	nbWindows := (fr.Bits + c - 1) / c
+	return m*nbWindows*2 + nbWindows*(1<<(c-1))*bucketSize
+}
+
+// EstimateResources returns an estimate of the resources needed to prove with
+// the ProvingKey. The memory is estimated as memoryEstimate with concurrent
+// multi-exponentiations, with the domain size as an upper bound of the number
+// of constraints. The multi-exponentiations of the Pedersen commitments are not
+// included. The time is extrapolated from small operations measured on the
+// first call.
+func (pk *ProvingKey) EstimateResources() backend.ResourceEstimate {
+	nbWires := uint64(len(pk.InfinityA))
+	n := pk.Domain.Cardinality
+	e := backend.ResourceEstimate{
+		Memory: (4*nbWires+7*n)*fr.Bytes + msmWorkingMemory(pk, false),
+		MultiExps: []backend.MultiExpEstimate{
+			{Size: len(pk.G1.A)},
+			{Size: len(pk.G1.B)},
+			{Size: len(pk.G1.K)},
+			{Size: len(pk.G1.Z)},
+			{G2: true, Size: len(pk.G2.B)},
+		},
+		// a, b and c to the coset, and back for h
+		FFTs: []backend.FFTEstimate{
+			{Size: n, Count: 7},
+		},
+	}
+	e.EstimateTime(calibrate())
+	return e
+}
+
+var calibration struct {
+	once sync.Once
+	c    backend.Calibration
+}
+
+// calibrate returns the time of small multi-exponentiations and FFTs on this
+// machine, measured on the first call.
+func calibrate() backend.Calibration {
+	calibration.once.Do(func() {
+		_, _, g1, g2 := curve.Generators()
+		scalars := make([]fr.Element, backend.CalibrationMultiExpSize)
+		var one fr.Element
+		one.SetOne()
+		scalars[0].SetUint64(3)
+		for i := 1; i < len(scalars); i++ {
+			scalars[i].Square(&scalars[i-1]).Add(&scalars[i], &one)
+		}
+		p1 := curve.BatchScalarMultiplicationG1(&g1, scalars)
+		p2 := curve.BatchScalarMultiplicationG2(&g2, scalars)
+
+		var r1 curve.G1Jac
+		var r2 curve.G2Jac
+		start := time.Now()
+		if _, err := r1.MultiExp(p1, scalars, ecc.MultiExpConfig{}); err == nil {
+			calibration.c.G1MultiExp = time.Since(start)
+		}
+		start = time.Now()
+		if _, err := r2.MultiExp(p2, scalars, ecc.MultiExpConfig{}); err == nil {
+			calibration.c.G2MultiExp = time.Since(start)
+		}
+
+		domain := fft.NewDomain(backend.CalibrationFFTSize)
+		v := make([]fr.Element, backend.CalibrationFFTSize)
+		copy(v, scalars)
+		start = time.Now()
+		domain.FFT(v, fft.DIF)
+		calibration.c.FFT = time.Since(start)
+	})
+	return calibration.c
+}
+
+func computeH(ctx context.Context, acc backend.Accelerator, a, b, c []fr.Element, domain *fft.Domain, buf *proverBuffers, nbTasks int) ([]fr.Element, error) {
 	// H part of Krs
 	// Compute H (hz=ab-c, where z=-2 on ker X^n+1 (z(x)=x^n-1))
 	// 	1 - _a = ifft(a), _b = ifft(b), _c = ifft(c)
 	// 	2 - ca = fft_coset(_a), ba = fft_coset(_b), cc = fft_coset(_c)
 	// 	3 - h = ifft_coset(ca o cb - cc)
 
-	n := len(a)
-
 	// add padding to ensure input length is domain cardinality
-	padding := make([]fr.Element, int(domain.Cardinality)-n)
-	a = append(a, padding...)
-	b = append(b, padding...)
-	c = append(c, padding...)
-	n = len(a)
-
-	domain.FFTInverse(a, fft.DIF)
-	domain.FFTInverse(b, fft.DIF)
-	domain.FFTInverse(c, fft.DIF)
-
-	domain.FFT(a, fft.DIT, fft.OnCoset())
-	domain.FFT(b, fft.DIT, fft.OnCoset())
-	domain.FFT(c, fft.DIT, fft.OnCoset())
+	n := int(domain.Cardinality)
+	a = pad(take(&buf.a, n), a)
+	b = pad(take(&buf.b, n), b)
+	c = pad(take(&buf.c, n), c)
+
+	for _, v := range [][]fr.Element{a, b, c} {
+		if err := fftOnDomain(ctx, acc, v, domain, backend.FFTConfig{Inverse: true, DIF: true}, nbTasks); err != nil {
+			return nil, err
+		}
+		if err := fftOnDomain(ctx, acc, v, domain, backend.FFTConfig{OnCoset: true}, nbTasks); err != nil {
+			return nil, err
+		}
+	}
 
 	var den, one fr.Element
 	one.SetOne()
@@ -380,10 +604,190 @@ func computeH(a, b, c []fr.Element, domain *fft.Domain) []fr.Element {
 				Sub(&a[i], &c[i]).
 				Mul(&a[i], &den)
 		}
-	})
+	}, nbTasks)
 
 	// ifft_coset
-	domain.FFTInverse(a, fft.DIF, fft.OnCoset())
+	if err := fftOnDomain(ctx, acc, a, domain, backend.FFTConfig{Inverse: true, DIF: true, OnCoset: true}, nbTasks); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// pad copies src into dst and sets the remaining elements of dst to zero.
+func pad(dst, src []fr.Element) []fr.Element {
+	copy(dst, src)
+	for i := len(src); i < len(dst); i++ {
+		dst[i].SetZero()
+	}
+	return dst
+}
+
+// multiExpG1 sets res to the multi-exponentiation of the points by the
+// scalars, offloaded with ctx to the accelerator if it supports it. id
+// identifies the points of the proving key, see [backend.MultiExpConfig]. If
+// selfCheck is set, the result is checked against a recomputation on the CPU.
+func multiExpG1(ctx context.Context, acc backend.Accelerator, id string, res *curve.G1Jac, points []curve.G1Affine, scalars []fr.Element, config ecc.MultiExpConfig, selfCheck bool) error {
+	computed := false
+	if acc != nil {
+		err := acc.MultiExp(ctx, curve.ID, res, points, scalars, backend.MultiExpConfig{Points: id})
+		if err != nil && !errors.Is(err, backend.ErrAcceleratorUnsupported) {
+			return err
+		}
+		computed = err == nil
+	}
+	if !computed {
+		if _, err := res.MultiExp(points, scalars, config); err != nil {
+			return err
+		}
+	}
+	if selfCheck {
+		var expected curve.G1Jac
+		if _, err := expected.MultiExp(points, scalars, config); err != nil {
+			return err
+		}
+		if !expected.Equal(res) {
+			return fmt.Errorf("proof self check: multi-exponentiation over %s differs from its recomputation", id)
+		}
+	}
+	return nil
+}
+
+// multiExpG2 sets res to the multi-exponentiation of the points by the
+// scalars, offloaded with ctx to the accelerator if it supports it. id
+// identifies the points of the proving key, see [backend.MultiExpConfig]. If
+// selfCheck is set, the result is checked against a recomputation on the CPU.
+func multiExpG2(ctx context.Context, acc backend.Accelerator, id string, res *curve.G2Jac, points []curve.G2Affine, scalars []fr.Element, config ecc.MultiExpConfig, selfCheck bool) error {
+	computed := false
+	if acc != nil {
+		err := acc.MultiExp(ctx, curve.ID, res, points, scalars, backend.MultiExpConfig{Points: id})
+		if err != nil && !errors.Is(err, backend.ErrAcceleratorUnsupported) {
+			return err
+		}
+		computed = err == nil
+	}
+	if !computed {
+		if _, err := res.MultiExp(points, scalars, config); err != nil {
+			return err
+		}
+	}
+	if selfCheck {
+		var expected curve.G2Jac
+		if _, err := expected.MultiExp(points, scalars, config); err != nil {
+			return err
+		}
+		if !expected.Equal(res) {
+			return fmt.Errorf("proof self check: multi-exponentiation over %s differs from its recomputation", id)
+		}
+	}
+	return nil
+}
+
+// checkQuotient checks that the polynomials A, B and C interpolating a, b and c
+// on the domain and the quotient H, given by its coefficients in bit-reversed
+// order, satisfy A·B - C = H·(Xⁿ - 1) at a random point.
+func checkQuotient(a, b, c, h []fr.Element, domain *fft.Domain) error {
+	n := int(domain.Cardinality)
+	var zeta, zn, one fr.Element
+	one.SetOne()
+	for zn.IsZero() {
+		// zeta must not be in the domain
+		if _, err := zeta.SetRandom(); err != nil {
+			return err
+		}
+		zn.Exp(zeta, big.NewInt(int64(n))).Sub(&zn, &one)
+	}
+
+	// Lᵢ(ζ) = ωⁱ (ζⁿ - 1) / (n (ζ - ωⁱ)) and the powers of ζ for H
+	lagrange := make([]fr.Element, n)
+	powers := make([]fr.Element, n)
+	var w fr.Element
+	w.SetOne()
+	powers[0].SetOne()
+	for i := 0; i < n; i++ {
+		lagrange[i].Sub(&zeta, &w)
+		w.Mul(&w, &domain.Generator)
+		if i > 0 {
+			powers[i].Mul(&powers[i-1], &zeta)
+		}
+	}
+	lagrange = fr.BatchInvert(lagrange)
+	var factor fr.Element
+	factor.Mul(&zn, &domain.CardinalityInv)
+	w.SetOne()
+	for i := range lagrange {
+		lagrange[i].Mul(&lagrange[i], &w).Mul(&lagrange[i], &factor)
+		w.Mul(&w, &domain.Generator)
+	}
+
+	eval := func(v []fr.Element) fr.Element {
+		var res, t fr.Element
+		for i := range v {
+			t.Mul(&v[i], &lagrange[i])
+			res.Add(&res, &t)
+		}
+		return res
+	}
+	ea, eb, ec := eval(a), eval(b), eval(c)
+	var eh, t fr.Element
+	shift := 64 - uint64(bits.TrailingZeros(uint(n)))
+	for i := range h {
+		t.Mul(&h[i], &powers[bits.Reverse64(uint64(i))>>shift])
+		eh.Add(&eh, &t)
+	}
 
-	return a
+	ea.Mul(&ea, &eb).Sub(&ea, &ec)
+	eh.Mul(&eh, &zn)
+	if !ea.Equal(&eh) {
+		return errors.New("proof self check: the quotient doesn't match the solution")
+	}
+	return nil
+}
+
+// fftOnDomain transforms a in place with nbTasks parallel tasks, offloaded with
+// ctx to the accelerator if it supports it.
+func fftOnDomain(ctx context.Context, acc backend.Accelerator, a []fr.Element, domain *fft.Domain, config backend.FFTConfig, nbTasks int) error {
+	if acc != nil {
+		if err := acc.FFT(ctx, curve.ID, a, domain, config); !errors.Is(err, backend.ErrAcceleratorUnsupported) {
+			return err
+		}
+	}
+	decimation := fft.DIT
+	if config.DIF {
+		decimation = fft.DIF
+	}
+	opts := []fft.Option{fft.WithNbTasks(nbTasks)}
+	if config.OnCoset {
+		opts = append(opts, fft.OnCoset())
+	}
+	if config.Inverse {
+		domain.FFTInverse(a, decimation, opts...)
+	} else {
+		domain.FFT(a, decimation, opts...)
+	}
+	return nil
+}
+
+// randomElements returns n field elements sampled from src. If src is nil, the
+// elements are sampled from crypto/rand.
+func randomElements(src io.Reader, n int) ([]fr.Element, error) {
+	res := make([]fr.Element, n)
+	if src == nil {
+		for i := range res {
+			if _, err := res[i].SetRandom(); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+	// sample twice as many bytes as needed to make the modular bias negligible
+	var buf [2 * fr.Bytes]byte
+	var b big.Int
+	for i := range res {
+		if _, err := io.ReadFull(src, buf[:]); err != nil {
+			return nil, fmt.Errorf("read randomness: %w", err)
+		}
+		res[i].SetBigInt(b.SetBytes(buf[:]))
+	}
+	return res, nil
 }
@@ -0,0 +1,56 @@
+//go:build !verifier_only
+
+package groth16
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+type powerCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *powerCircuit) Define(api frontend.API) error {
+	x := c.X
+	for i := 0; i < 1<<10; i++ {
+		x = api.Mul(x, x)
+	}
+	api.AssertIsEqual(x, c.Y)
+	return nil
+}
+
+func TestMemoryBudgetSequentialMultiExps(t *testing.T) {
+	assert := require.New(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &powerCircuit{})
+	assert.NoError(err)
+	system := ccs.(*cs.R1CS)
+	var pk ProvingKey
+	var vk VerifyingKey
+	assert.NoError(Setup(system, &pk, &vk))
+
+	witness, err := frontend.NewWitness(&powerCircuit{X: 1, Y: 1}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicWitness, err := witness.Public()
+	assert.NoError(err)
+
+	// the budget is too small for the concurrent multi-exponentiations, the
+	// prover computes them one at a time.
+	budget := memoryEstimate(system, &pk, true)
+	assert.Less(budget, memoryEstimate(system, &pk, false))
+	proof, err := Prove(system, &pk, witness, backend.WithProverMemoryBudget(budget))
+	assert.NoError(err)
+	assert.NoError(Verify(proof, &vk, publicWitness.Vector().(fr.Vector)))
+
+	_, err = Prove(system, &pk, witness, backend.WithProverMemoryBudget(budget-1))
+	assert.True(errors.Is(err, backend.ErrInsufficientMemoryBudget), "unexpected error: %v", err)
+}
@@ -0,0 +1,117 @@
+package groth16
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+)
+
+var errSnarkJSCommitments = errors.New("snarkjs doesn't support Pedersen commitments")
+
+// snarkJSVerifyingKey is the schema of the snarkjs verification_key.json.
+type snarkJSVerifyingKey struct {
+	Protocol      string          `json:"protocol"`
+	Curve         string          `json:"curve"`
+	NPublic       int             `json:"nPublic"`
+	VkAlpha1      [3]string       `json:"vk_alpha_1"`
+	VkBeta2       [3][2]string    `json:"vk_beta_2"`
+	VkGamma2      [3][2]string    `json:"vk_gamma_2"`
+	VkDelta2      [3][2]string    `json:"vk_delta_2"`
+	VkAlphabeta12 [2][3][2]string `json:"vk_alphabeta_12"`
+	IC            [][3]string     `json:"IC"`
+}
+
+// snarkJSProof is the schema of the snarkjs proof.json.
+type snarkJSProof struct {
+	PiA      [3]string    `json:"pi_a"`
+	PiB      [3][2]string `json:"pi_b"`
+	PiC      [3]string    `json:"pi_c"`
+	Protocol string       `json:"protocol"`
+	Curve    string       `json:"curve"`
+}
+
+// ExportJSON writes the VerifyingKey in the format of the verification_key.json
+// of snarkjs, so that it can be used by the circom tooling. The circuit must
+// not use commitments, which snarkjs doesn't support.
+func (vk *VerifyingKey) ExportJSON(w io.Writer) error {
+	if len(vk.PublicAndCommitmentCommitted) > 0 {
+		return errSnarkJSCommitments
+	}
+	e, err := curve.Pair([]curve.G1Affine{vk.G1.Alpha}, []curve.G2Affine{vk.G2.Beta})
+	if err != nil {
+		return err
+	}
+	res := snarkJSVerifyingKey{
+		Protocol: "groth16",
+		Curve:    "bn128",
+		NPublic:  len(vk.G1.K) - 1,
+		VkAlpha1: snarkJSG1(&vk.G1.Alpha),
+		VkBeta2:  snarkJSG2(&vk.G2.Beta),
+		VkGamma2: snarkJSG2(&vk.G2.Gamma),
+		VkDelta2: snarkJSG2(&vk.G2.Delta),
+		VkAlphabeta12: [2][3][2]string{
+			{
+				{e.C0.B0.A0.String(), e.C0.B0.A1.String()},
+				{e.C0.B1.A0.String(), e.C0.B1.A1.String()},
+				{e.C0.B2.A0.String(), e.C0.B2.A1.String()},
+			},
+			{
+				{e.C1.B0.A0.String(), e.C1.B0.A1.String()},
+				{e.C1.B1.A0.String(), e.C1.B1.A1.String()},
+				{e.C1.B2.A0.String(), e.C1.B2.A1.String()},
+			},
+		},
+		IC: make([][3]string, len(vk.G1.K)),
+	}
+	for i := range vk.G1.K {
+		res.IC[i] = snarkJSG1(&vk.G1.K[i])
+	}
+	return writeSnarkJS(w, res)
+}
+
+// ExportJSON writes the proof in the format of the proof.json of snarkjs. The
+// proof must not have commitments, which snarkjs doesn't support.
+func (proof *Proof) ExportJSON(w io.Writer) error {
+	if len(proof.Commitments) > 0 {
+		return errSnarkJSCommitments
+	}
+	return writeSnarkJS(w, snarkJSProof{
+		PiA:      snarkJSG1(&proof.Ar),
+		PiB:      snarkJSG2(&proof.Bs),
+		PiC:      snarkJSG1(&proof.Krs),
+		Protocol: "groth16",
+		Curve:    "bn128",
+	})
+}
+
+func writeSnarkJS(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", " ")
+	return enc.Encode(v)
+}
+
+// snarkJSG1 returns the projective coordinates of p as decimal strings.
+func snarkJSG1(p *curve.G1Affine) [3]string {
+	if p.IsInfinity() {
+		return [3]string{"0", "1", "0"}
+	}
+	return [3]string{p.X.String(), p.Y.String(), "1"}
+}
+
+// snarkJSG2 returns the projective coordinates of p as pairs of decimal
+// strings, the coordinates in 𝔽p² being written as [c0, c1].
+func snarkJSG2(p *curve.G2Affine) [3][2]string {
+	var zero, one fp.Element
+	one.SetOne()
+	if p.IsInfinity() {
+		return [3][2]string{{zero.String(), zero.String()}, {one.String(), zero.String()}, {zero.String(), zero.String()}}
+	}
+	return [3][2]string{
+		{p.X.A0.String(), p.X.A1.String()},
+		{p.Y.A0.String(), p.Y.A1.String()},
+		{one.String(), zero.String()},
+	}
+}
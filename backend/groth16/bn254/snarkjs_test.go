@@ -0,0 +1,100 @@
+//go:build !verifier_only
+
+package groth16
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+type snarkJSCircuit struct {
+	X, Y frontend.Variable `gnark:",public"`
+	Z    frontend.Variable
+}
+
+func (c *snarkJSCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.Z), c.Y)
+	return nil
+}
+
+func TestExportSnarkJS(t *testing.T) {
+	assert := require.New(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &snarkJSCircuit{})
+	assert.NoError(err)
+	var pk ProvingKey
+	var vk VerifyingKey
+	assert.NoError(Setup(ccs.(*cs.R1CS), &pk, &vk))
+	witness, err := frontend.NewWitness(&snarkJSCircuit{X: 3, Y: 15, Z: 5}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	proof, err := Prove(ccs.(*cs.R1CS), &pk, witness)
+	assert.NoError(err)
+
+	var vkBuf, proofBuf bytes.Buffer
+	assert.NoError(vk.ExportJSON(&vkBuf))
+	assert.NoError(proof.ExportJSON(&proofBuf))
+	var vkJSON snarkJSVerifyingKey
+	var proofJSON snarkJSProof
+	assert.NoError(json.Unmarshal(vkBuf.Bytes(), &vkJSON))
+	assert.NoError(json.Unmarshal(proofBuf.Bytes(), &proofJSON))
+	assert.Equal("groth16", vkJSON.Protocol)
+	assert.Equal("bn128", proofJSON.Curve)
+	assert.Equal(2, vkJSON.NPublic)
+	assert.Len(vkJSON.IC, 3)
+
+	// verify the proof as snarkjs does, from the decimal coordinates:
+	// e(-A, B)·e(α, β)·e(∑ pub_i·IC_i, γ)·e(C, δ) == 1
+	g1 := func(c [3]string) curve.G1Affine {
+		var p curve.G1Affine
+		_, err := p.X.SetString(c[0])
+		assert.NoError(err)
+		_, err = p.Y.SetString(c[1])
+		assert.NoError(err)
+		assert.Equal("1", c[2])
+		return p
+	}
+	g2 := func(c [3][2]string) curve.G2Affine {
+		var p curve.G2Affine
+		for _, e := range []struct {
+			dst *fp.Element
+			s   string
+		}{{&p.X.A0, c[0][0]}, {&p.X.A1, c[0][1]}, {&p.Y.A0, c[1][0]}, {&p.Y.A1, c[1][1]}} {
+			_, err := e.dst.SetString(e.s)
+			assert.NoError(err)
+		}
+		return p
+	}
+	public := []fr.Element{{}, {}}
+	public[0].SetUint64(3)
+	public[1].SetUint64(15)
+	vkX := g1(vkJSON.IC[0])
+	for i := range public {
+		var tmp curve.G1Affine
+		ic := g1(vkJSON.IC[i+1])
+		tmp.ScalarMultiplication(&ic, public[i].BigInt(new(big.Int)))
+		vkX.Add(&vkX, &tmp)
+	}
+	var negA curve.G1Affine
+	a := g1(proofJSON.PiA)
+	negA.Neg(&a)
+	ok, err := curve.PairingCheck(
+		[]curve.G1Affine{negA, g1(vkJSON.VkAlpha1), vkX, g1(proofJSON.PiC)},
+		[]curve.G2Affine{g2(proofJSON.PiB), g2(vkJSON.VkBeta2), g2(vkJSON.VkGamma2), g2(vkJSON.VkDelta2)})
+	assert.NoError(err)
+	assert.True(ok, "pairing check failed")
+
+	// snarkjs doesn't support commitments
+	proof.Commitments = []curve.G1Affine{{}}
+	assert.ErrorIs(proof.ExportJSON(&proofBuf), errSnarkJSCommitments)
+}
@@ -14,25 +14,31 @@
 
 // Package groth16 implements Groth16 Zero Knowledge Proof system  (aka zkSNARK).
 //
+// # Verifier-only builds
+//
+// Building with the `verifier_only` build tag excludes Setup, Prove and the
+// (de)serialization helpers of the proving keys and constraint systems from the
+// package, so that verifier applications cannot depend on them by accident.
+// Packages built on top of the prover, such as backend/queue, are excluded as
+// well.
+//
+// The prover, the setup and the proving key of the curve specific packages, as
+// well as the solver options of [backend.ProverConfig], are excluded too, so
+// that the constraint systems, the solver and the FFTs of the prover are not
+// linked in the verifier binary.
+//
 // # See also
 //
 // https://eprint.iacr.org/2016/260.pdf
 package groth16
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/witness"
-	"github.com/consensys/gnark/constraint"
-	cs_bls12377 "github.com/consensys/gnark/constraint/bls12-377"
-	cs_bls12381 "github.com/consensys/gnark/constraint/bls12-381"
-	cs_bls24315 "github.com/consensys/gnark/constraint/bls24-315"
-	cs_bls24317 "github.com/consensys/gnark/constraint/bls24-317"
-	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
-	cs_bw6633 "github.com/consensys/gnark/constraint/bw6-633"
-	cs_bw6761 "github.com/consensys/gnark/constraint/bw6-761"
 
 	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
@@ -49,7 +55,6 @@ import (
 	groth16_bls24315 "github.com/consensys/gnark/backend/groth16/bls24-315"
 	groth16_bls24317 "github.com/consensys/gnark/backend/groth16/bls24-317"
 	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
-	icicle_bn254 "github.com/consensys/gnark/backend/groth16/bn254/icicle"
 	groth16_bw6633 "github.com/consensys/gnark/backend/groth16/bw6-633"
 	groth16_bw6761 "github.com/consensys/gnark/backend/groth16/bw6-761"
 )
@@ -81,6 +86,11 @@ type ProvingKey interface {
 	// NbG2 returns the number of G2 elements in the ProvingKey
 	NbG2() int
 
+	// EstimateResources returns an estimate of the memory, the
+	// multi-exponentiations, the FFTs and the time needed to prove with the
+	// ProvingKey.
+	EstimateResources() backend.ResourceEstimate
+
 	IsDifferent(interface{}) bool
 }
 
@@ -111,48 +121,51 @@ type VerifyingKey interface {
 
 // Verify runs the groth16.Verify algorithm on provided proof with given witness
 func Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ...backend.VerifierOption) error {
+	if err := checkCurve("verifying key", vk.CurveID(), proof.CurveID()); err != nil {
+		return err
+	}
 
 	switch _proof := proof.(type) {
 	case *groth16_bls12377.Proof:
 		w, ok := publicWitness.Vector().(fr_bls12377.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return groth16_bls12377.Verify(_proof, vk.(*groth16_bls12377.VerifyingKey), w, opts...)
 	case *groth16_bls12381.Proof:
 		w, ok := publicWitness.Vector().(fr_bls12381.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return groth16_bls12381.Verify(_proof, vk.(*groth16_bls12381.VerifyingKey), w, opts...)
 	case *groth16_bn254.Proof:
 		w, ok := publicWitness.Vector().(fr_bn254.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return groth16_bn254.Verify(_proof, vk.(*groth16_bn254.VerifyingKey), w, opts...)
 	case *groth16_bw6761.Proof:
 		w, ok := publicWitness.Vector().(fr_bw6761.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return groth16_bw6761.Verify(_proof, vk.(*groth16_bw6761.VerifyingKey), w, opts...)
 	case *groth16_bls24317.Proof:
 		w, ok := publicWitness.Vector().(fr_bls24317.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return groth16_bls24317.Verify(_proof, vk.(*groth16_bls24317.VerifyingKey), w, opts...)
 	case *groth16_bls24315.Proof:
 		w, ok := publicWitness.Vector().(fr_bls24315.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return groth16_bls24315.Verify(_proof, vk.(*groth16_bls24315.VerifyingKey), w, opts...)
 	case *groth16_bw6633.Proof:
 		w, ok := publicWitness.Vector().(fr_bw6633.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return groth16_bw6633.Verify(_proof, vk.(*groth16_bw6633.VerifyingKey), w, opts...)
 	default:
@@ -160,202 +173,38 @@ func Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ..
 	}
 }
 
-// Prove runs the groth16.Prove algorithm.
-//
-// if the force flag is set:
-//
-//		will execute all the prover computations, even if the witness is invalid
-//	 will produce an invalid proof
-//		internally, the solution vector to the R1CS will be filled with random values which may impact benchmarking
-func Prove(r1cs constraint.ConstraintSystem, pk ProvingKey, fullWitness witness.Witness, opts ...backend.ProverOption) (Proof, error) {
-	switch _r1cs := r1cs.(type) {
-	case *cs_bls12377.R1CS:
-		return groth16_bls12377.Prove(_r1cs, pk.(*groth16_bls12377.ProvingKey), fullWitness, opts...)
-
-	case *cs_bls12381.R1CS:
-		return groth16_bls12381.Prove(_r1cs, pk.(*groth16_bls12381.ProvingKey), fullWitness, opts...)
-
-	case *cs_bn254.R1CS:
-		if icicle_bn254.HasIcicle {
-			return icicle_bn254.Prove(_r1cs, pk.(*icicle_bn254.ProvingKey), fullWitness, opts...)
-		}
-		return groth16_bn254.Prove(_r1cs, pk.(*groth16_bn254.ProvingKey), fullWitness, opts...)
-
-	case *cs_bw6761.R1CS:
-		return groth16_bw6761.Prove(_r1cs, pk.(*groth16_bw6761.ProvingKey), fullWitness, opts...)
-
-	case *cs_bls24317.R1CS:
-		return groth16_bls24317.Prove(_r1cs, pk.(*groth16_bls24317.ProvingKey), fullWitness, opts...)
-
-	case *cs_bls24315.R1CS:
-		return groth16_bls24315.Prove(_r1cs, pk.(*groth16_bls24315.ProvingKey), fullWitness, opts...)
-
-	case *cs_bw6633.R1CS:
-		return groth16_bw6633.Prove(_r1cs, pk.(*groth16_bw6633.ProvingKey), fullWitness, opts...)
-
-	default:
-		panic("unrecognized R1CS curve type")
-	}
-}
-
-// Setup runs groth16.Setup with provided R1CS and outputs a key pair associated with the circuit.
-//
-// Note that careful consideration must be given to this step in a production environment.
-// groth16.Setup uses some randomness to precompute the Proving and Verifying keys. If the process
-// or machine leaks this randomness, an attacker could break the ZKP protocol.
+// Rerandomize re-randomizes the proof in place with the given VerifyingKey,
+// such that it remains valid for the same public witness but is unlinkable to
+// the original proof. It doesn't need the witness, so that any party holding
+// the proof (e.g. a relayer) can re-randomize it before submission.
 //
-// Two main solutions to this deployment issues are: running the Setup through a MPC (multi party computation)
-// or using a ZKP backend like PLONK where the per-circuit Setup is deterministic.
-func Setup(r1cs constraint.ConstraintSystem) (ProvingKey, VerifyingKey, error) {
-
-	switch _r1cs := r1cs.(type) {
-	case *cs_bls12377.R1CS:
-		var pk groth16_bls12377.ProvingKey
-		var vk groth16_bls12377.VerifyingKey
-		if err := groth16_bls12377.Setup(_r1cs, &pk, &vk); err != nil {
-			return nil, nil, err
-		}
-		return &pk, &vk, nil
-	case *cs_bls12381.R1CS:
-		var pk groth16_bls12381.ProvingKey
-		var vk groth16_bls12381.VerifyingKey
-		if err := groth16_bls12381.Setup(_r1cs, &pk, &vk); err != nil {
-			return nil, nil, err
-		}
-		return &pk, &vk, nil
-	case *cs_bn254.R1CS:
-		var vk groth16_bn254.VerifyingKey
-		if icicle_bn254.HasIcicle {
-			var pk icicle_bn254.ProvingKey
-			if err := icicle_bn254.Setup(_r1cs, &pk, &vk); err != nil {
-				return nil, nil, err
-			}
-			return &pk, &vk, nil
-		}
-		var pk groth16_bn254.ProvingKey
-		if err := groth16_bn254.Setup(_r1cs, &pk, &vk); err != nil {
-			return nil, nil, err
-		}
-		return &pk, &vk, nil
-	case *cs_bw6761.R1CS:
-		var pk groth16_bw6761.ProvingKey
-		var vk groth16_bw6761.VerifyingKey
-		if err := groth16_bw6761.Setup(_r1cs, &pk, &vk); err != nil {
-			return nil, nil, err
-		}
-		return &pk, &vk, nil
-	case *cs_bls24317.R1CS:
-		var pk groth16_bls24317.ProvingKey
-		var vk groth16_bls24317.VerifyingKey
-		if err := groth16_bls24317.Setup(_r1cs, &pk, &vk); err != nil {
-			return nil, nil, err
-		}
-		return &pk, &vk, nil
-	case *cs_bls24315.R1CS:
-		var pk groth16_bls24315.ProvingKey
-		var vk groth16_bls24315.VerifyingKey
-		if err := groth16_bls24315.Setup(_r1cs, &pk, &vk); err != nil {
-			return nil, nil, err
-		}
-		return &pk, &vk, nil
-	case *cs_bw6633.R1CS:
-		var pk groth16_bw6633.ProvingKey
-		var vk groth16_bw6633.VerifyingKey
-		if err := groth16_bw6633.Setup(_r1cs, &pk, &vk); err != nil {
-			return nil, nil, err
-		}
-		return &pk, &vk, nil
-	default:
-		panic("unrecognized R1CS curve type")
+// Proofs of circuits committing to witness variables with
+// [github.com/consensys/gnark/frontend.Committer] can't be re-randomized,
+// Rerandomize returns [backend.ErrRerandomizeCommitments] for them.
+func Rerandomize(proof Proof, vk VerifyingKey) error {
+	if err := checkCurve("verifying key", vk.CurveID(), proof.CurveID()); err != nil {
+		return err
 	}
-}
-
-// DummySetup create a random ProvingKey with provided R1CS
-// it doesn't return a VerifyingKey and is use for benchmarking or test purposes only.
-func DummySetup(r1cs constraint.ConstraintSystem) (ProvingKey, error) {
-	switch _r1cs := r1cs.(type) {
-	case *cs_bls12377.R1CS:
-		var pk groth16_bls12377.ProvingKey
-		if err := groth16_bls12377.DummySetup(_r1cs, &pk); err != nil {
-			return nil, err
-		}
-		return &pk, nil
-	case *cs_bls12381.R1CS:
-		var pk groth16_bls12381.ProvingKey
-		if err := groth16_bls12381.DummySetup(_r1cs, &pk); err != nil {
-			return nil, err
-		}
-		return &pk, nil
-	case *cs_bn254.R1CS:
-		if icicle_bn254.HasIcicle {
-			var pk icicle_bn254.ProvingKey
-			if err := icicle_bn254.DummySetup(_r1cs, &pk); err != nil {
-				return nil, err
-			}
-			return &pk, nil
-		}
-		var pk groth16_bn254.ProvingKey
-		if err := groth16_bn254.DummySetup(_r1cs, &pk); err != nil {
-			return nil, err
-		}
-		return &pk, nil
-	case *cs_bw6761.R1CS:
-		var pk groth16_bw6761.ProvingKey
-		if err := groth16_bw6761.DummySetup(_r1cs, &pk); err != nil {
-			return nil, err
-		}
-		return &pk, nil
-	case *cs_bls24317.R1CS:
-		var pk groth16_bls24317.ProvingKey
-		if err := groth16_bls24317.DummySetup(_r1cs, &pk); err != nil {
-			return nil, err
-		}
-		return &pk, nil
-	case *cs_bls24315.R1CS:
-		var pk groth16_bls24315.ProvingKey
-		if err := groth16_bls24315.DummySetup(_r1cs, &pk); err != nil {
-			return nil, err
-		}
-		return &pk, nil
-	case *cs_bw6633.R1CS:
-		var pk groth16_bw6633.ProvingKey
-		if err := groth16_bw6633.DummySetup(_r1cs, &pk); err != nil {
-			return nil, err
-		}
-		return &pk, nil
+	switch _proof := proof.(type) {
+	case *groth16_bls12377.Proof:
+		return _proof.Rerandomize(vk.(*groth16_bls12377.VerifyingKey))
+	case *groth16_bls12381.Proof:
+		return _proof.Rerandomize(vk.(*groth16_bls12381.VerifyingKey))
+	case *groth16_bn254.Proof:
+		return _proof.Rerandomize(vk.(*groth16_bn254.VerifyingKey))
+	case *groth16_bw6761.Proof:
+		return _proof.Rerandomize(vk.(*groth16_bw6761.VerifyingKey))
+	case *groth16_bls24317.Proof:
+		return _proof.Rerandomize(vk.(*groth16_bls24317.VerifyingKey))
+	case *groth16_bls24315.Proof:
+		return _proof.Rerandomize(vk.(*groth16_bls24315.VerifyingKey))
+	case *groth16_bw6633.Proof:
+		return _proof.Rerandomize(vk.(*groth16_bw6633.VerifyingKey))
 	default:
 		panic("unrecognized R1CS curve type")
 	}
 }
 
-// NewProvingKey instantiates a curve-typed ProvingKey and returns an interface object
-// This function exists for serialization purposes
-func NewProvingKey(curveID ecc.ID) ProvingKey {
-	var pk ProvingKey
-	switch curveID {
-	case ecc.BN254:
-		pk = &groth16_bn254.ProvingKey{}
-		if icicle_bn254.HasIcicle {
-			pk = &icicle_bn254.ProvingKey{}
-		}
-	case ecc.BLS12_377:
-		pk = &groth16_bls12377.ProvingKey{}
-	case ecc.BLS12_381:
-		pk = &groth16_bls12381.ProvingKey{}
-	case ecc.BW6_761:
-		pk = &groth16_bw6761.ProvingKey{}
-	case ecc.BLS24_317:
-		pk = &groth16_bls24317.ProvingKey{}
-	case ecc.BLS24_315:
-		pk = &groth16_bls24315.ProvingKey{}
-	case ecc.BW6_633:
-		pk = &groth16_bw6633.ProvingKey{}
-	default:
-		panic("not implemented")
-	}
-	return pk
-}
-
 // NewVerifyingKey instantiates a curve-typed VerifyingKey and returns an interface
 // This function exists for serialization purposes
 func NewVerifyingKey(curveID ecc.ID) VerifyingKey {
@@ -408,27 +257,15 @@ func NewProof(curveID ecc.ID) Proof {
 	return proof
 }
 
-// NewCS instantiate a concrete curved-typed R1CS and return a R1CS interface
-// This method exists for (de)serialization purposes
-func NewCS(curveID ecc.ID) constraint.ConstraintSystem {
-	var r1cs constraint.ConstraintSystem
-	switch curveID {
-	case ecc.BN254:
-		r1cs = &cs_bn254.R1CS{}
-	case ecc.BLS12_377:
-		r1cs = &cs_bls12377.R1CS{}
-	case ecc.BLS12_381:
-		r1cs = &cs_bls12381.R1CS{}
-	case ecc.BW6_761:
-		r1cs = &cs_bw6761.R1CS{}
-	case ecc.BLS24_317:
-		r1cs = &cs_bls24317.R1CS{}
-	case ecc.BLS24_315:
-		r1cs = &cs_bls24315.R1CS{}
-	case ecc.BW6_633:
-		r1cs = &cs_bw6633.R1CS{}
-	default:
-		panic("not implemented")
+// errWitnessCurveMismatch is returned when the witness is not defined over the
+// curve of the other objects.
+var errWitnessCurveMismatch = fmt.Errorf("%w: %w", backend.ErrCurveMismatch, witness.ErrInvalidWitness)
+
+// checkCurve returns an error wrapping [backend.ErrCurveMismatch] if the curve
+// of the named object is not the expected one.
+func checkCurve(name string, curve, expected ecc.ID) error {
+	if curve != expected {
+		return fmt.Errorf("%w: %s is defined over %s, expected %s", backend.ErrCurveMismatch, name, curve, expected)
 	}
-	return r1cs
+	return nil
 }
@@ -0,0 +1,353 @@
+//go:build !verifier_only
+
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groth16
+
+import (
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	cs_bls12377 "github.com/consensys/gnark/constraint/bls12-377"
+	cs_bls12381 "github.com/consensys/gnark/constraint/bls12-381"
+	cs_bls24315 "github.com/consensys/gnark/constraint/bls24-315"
+	cs_bls24317 "github.com/consensys/gnark/constraint/bls24-317"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	cs_bw6633 "github.com/consensys/gnark/constraint/bw6-633"
+	cs_bw6761 "github.com/consensys/gnark/constraint/bw6-761"
+	"github.com/consensys/gnark/internal/utils"
+	"github.com/consensys/gnark/security"
+
+	groth16_bls12377 "github.com/consensys/gnark/backend/groth16/bls12-377"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+	groth16_bls24315 "github.com/consensys/gnark/backend/groth16/bls24-315"
+	groth16_bls24317 "github.com/consensys/gnark/backend/groth16/bls24-317"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	icicle_bn254 "github.com/consensys/gnark/backend/groth16/bn254/icicle"
+	groth16_bw6633 "github.com/consensys/gnark/backend/groth16/bw6-633"
+	groth16_bw6761 "github.com/consensys/gnark/backend/groth16/bw6-761"
+)
+
+// Prove runs the groth16.Prove algorithm.
+//
+// if the force flag is set:
+//
+//		will execute all the prover computations, even if the witness is invalid
+//	 will produce an invalid proof
+//		internally, the solution vector to the R1CS will be filled with random values which may impact benchmarking
+func Prove(r1cs constraint.ConstraintSystem, pk ProvingKey, fullWitness witness.Witness, opts ...backend.ProverOption) (Proof, error) {
+	if err := checkCurve("proving key", pk.CurveID(), utils.FieldToCurve(r1cs.Field())); err != nil {
+		return nil, err
+	}
+	switch _r1cs := r1cs.(type) {
+	case *cs_bls12377.R1CS:
+		return groth16_bls12377.Prove(_r1cs, pk.(*groth16_bls12377.ProvingKey), fullWitness, opts...)
+
+	case *cs_bls12381.R1CS:
+		return groth16_bls12381.Prove(_r1cs, pk.(*groth16_bls12381.ProvingKey), fullWitness, opts...)
+
+	case *cs_bn254.R1CS:
+		if icicle_bn254.HasIcicle {
+			return icicle_bn254.Prove(_r1cs, pk.(*icicle_bn254.ProvingKey), fullWitness, opts...)
+		}
+		return groth16_bn254.Prove(_r1cs, pk.(*groth16_bn254.ProvingKey), fullWitness, opts...)
+
+	case *cs_bw6761.R1CS:
+		return groth16_bw6761.Prove(_r1cs, pk.(*groth16_bw6761.ProvingKey), fullWitness, opts...)
+
+	case *cs_bls24317.R1CS:
+		return groth16_bls24317.Prove(_r1cs, pk.(*groth16_bls24317.ProvingKey), fullWitness, opts...)
+
+	case *cs_bls24315.R1CS:
+		return groth16_bls24315.Prove(_r1cs, pk.(*groth16_bls24315.ProvingKey), fullWitness, opts...)
+
+	case *cs_bw6633.R1CS:
+		return groth16_bw6633.Prove(_r1cs, pk.(*groth16_bw6633.ProvingKey), fullWitness, opts...)
+
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+}
+
+// ProverSession proves witnesses of the same circuit with a pinned ProvingKey.
+// On the CPU, it reuses the working memory of the prover from one proof to the
+// next. The ICICLE prover manages its own device memory, so that its session
+// only serializes the calls. It is meant for high-throughput pipelines proving
+// many witnesses of a circuit.
+type ProverSession struct {
+	prove func(fullWitness witness.Witness, opts ...backend.ProverOption) (Proof, error)
+}
+
+// NewProverSession returns a ProverSession for the constraint system and the
+// ProvingKey.
+func NewProverSession(r1cs constraint.ConstraintSystem, pk ProvingKey) *ProverSession {
+	switch _r1cs := r1cs.(type) {
+	case *cs_bls12377.R1CS:
+		session := groth16_bls12377.NewProverSession(_r1cs, pk.(*groth16_bls12377.ProvingKey))
+		return &ProverSession{prove: func(fullWitness witness.Witness, opts ...backend.ProverOption) (Proof, error) {
+			return session.Prove(fullWitness, opts...)
+		}}
+
+	case *cs_bls12381.R1CS:
+		session := groth16_bls12381.NewProverSession(_r1cs, pk.(*groth16_bls12381.ProvingKey))
+		return &ProverSession{prove: func(fullWitness witness.Witness, opts ...backend.ProverOption) (Proof, error) {
+			return session.Prove(fullWitness, opts...)
+		}}
+
+	case *cs_bn254.R1CS:
+		if icicle_bn254.HasIcicle {
+			var lock sync.Mutex
+			return &ProverSession{prove: func(fullWitness witness.Witness, opts ...backend.ProverOption) (Proof, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				return icicle_bn254.Prove(_r1cs, pk.(*icicle_bn254.ProvingKey), fullWitness, opts...)
+			}}
+		}
+		session := groth16_bn254.NewProverSession(_r1cs, pk.(*groth16_bn254.ProvingKey))
+		return &ProverSession{prove: func(fullWitness witness.Witness, opts ...backend.ProverOption) (Proof, error) {
+			return session.Prove(fullWitness, opts...)
+		}}
+
+	case *cs_bw6761.R1CS:
+		session := groth16_bw6761.NewProverSession(_r1cs, pk.(*groth16_bw6761.ProvingKey))
+		return &ProverSession{prove: func(fullWitness witness.Witness, opts ...backend.ProverOption) (Proof, error) {
+			return session.Prove(fullWitness, opts...)
+		}}
+
+	case *cs_bls24317.R1CS:
+		session := groth16_bls24317.NewProverSession(_r1cs, pk.(*groth16_bls24317.ProvingKey))
+		return &ProverSession{prove: func(fullWitness witness.Witness, opts ...backend.ProverOption) (Proof, error) {
+			return session.Prove(fullWitness, opts...)
+		}}
+
+	case *cs_bls24315.R1CS:
+		session := groth16_bls24315.NewProverSession(_r1cs, pk.(*groth16_bls24315.ProvingKey))
+		return &ProverSession{prove: func(fullWitness witness.Witness, opts ...backend.ProverOption) (Proof, error) {
+			return session.Prove(fullWitness, opts...)
+		}}
+
+	case *cs_bw6633.R1CS:
+		session := groth16_bw6633.NewProverSession(_r1cs, pk.(*groth16_bw6633.ProvingKey))
+		return &ProverSession{prove: func(fullWitness witness.Witness, opts ...backend.ProverOption) (Proof, error) {
+			return session.Prove(fullWitness, opts...)
+		}}
+
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+}
+
+// Prove runs the groth16.Prove algorithm on the full witness with the
+// constraint system and ProvingKey of the session. Calls are serialized, the
+// session is safe for concurrent use.
+func (s *ProverSession) Prove(fullWitness witness.Witness, opts ...backend.ProverOption) (Proof, error) {
+	return s.prove(fullWitness, opts...)
+}
+
+// Setup runs groth16.Setup with provided R1CS and outputs a key pair associated with the circuit.
+//
+// Note that careful consideration must be given to this step in a production environment.
+// groth16.Setup uses some randomness to precompute the Proving and Verifying keys. If the process
+// or machine leaks this randomness, an attacker could break the ZKP protocol.
+//
+// Two main solutions to this deployment issues are: running the Setup through a MPC (multi party computation)
+// or using a ZKP backend like PLONK where the per-circuit Setup is deterministic.
+//
+// See [backend.WithSetupSecurityLevel] for checking the curve against a
+// security level.
+func Setup(r1cs constraint.ConstraintSystem, opts ...backend.SetupOption) (ProvingKey, VerifyingKey, error) {
+	opt, err := backend.NewSetupConfig(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := security.CheckSetupConfig(utils.FieldToCurve(r1cs.Field()), 0, &opt); err != nil {
+		return nil, nil, err
+	}
+
+	switch _r1cs := r1cs.(type) {
+	case *cs_bls12377.R1CS:
+		var pk groth16_bls12377.ProvingKey
+		var vk groth16_bls12377.VerifyingKey
+		if err := groth16_bls12377.Setup(_r1cs, &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	case *cs_bls12381.R1CS:
+		var pk groth16_bls12381.ProvingKey
+		var vk groth16_bls12381.VerifyingKey
+		if err := groth16_bls12381.Setup(_r1cs, &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	case *cs_bn254.R1CS:
+		var vk groth16_bn254.VerifyingKey
+		if icicle_bn254.HasIcicle {
+			var pk icicle_bn254.ProvingKey
+			if err := icicle_bn254.Setup(_r1cs, &pk, &vk); err != nil {
+				return nil, nil, err
+			}
+			return &pk, &vk, nil
+		}
+		var pk groth16_bn254.ProvingKey
+		if err := groth16_bn254.Setup(_r1cs, &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	case *cs_bw6761.R1CS:
+		var pk groth16_bw6761.ProvingKey
+		var vk groth16_bw6761.VerifyingKey
+		if err := groth16_bw6761.Setup(_r1cs, &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	case *cs_bls24317.R1CS:
+		var pk groth16_bls24317.ProvingKey
+		var vk groth16_bls24317.VerifyingKey
+		if err := groth16_bls24317.Setup(_r1cs, &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	case *cs_bls24315.R1CS:
+		var pk groth16_bls24315.ProvingKey
+		var vk groth16_bls24315.VerifyingKey
+		if err := groth16_bls24315.Setup(_r1cs, &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	case *cs_bw6633.R1CS:
+		var pk groth16_bw6633.ProvingKey
+		var vk groth16_bw6633.VerifyingKey
+		if err := groth16_bw6633.Setup(_r1cs, &pk, &vk); err != nil {
+			return nil, nil, err
+		}
+		return &pk, &vk, nil
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+}
+
+// DummySetup create a random ProvingKey with provided R1CS
+// it doesn't return a VerifyingKey and is use for benchmarking or test purposes only.
+func DummySetup(r1cs constraint.ConstraintSystem) (ProvingKey, error) {
+	switch _r1cs := r1cs.(type) {
+	case *cs_bls12377.R1CS:
+		var pk groth16_bls12377.ProvingKey
+		if err := groth16_bls12377.DummySetup(_r1cs, &pk); err != nil {
+			return nil, err
+		}
+		return &pk, nil
+	case *cs_bls12381.R1CS:
+		var pk groth16_bls12381.ProvingKey
+		if err := groth16_bls12381.DummySetup(_r1cs, &pk); err != nil {
+			return nil, err
+		}
+		return &pk, nil
+	case *cs_bn254.R1CS:
+		if icicle_bn254.HasIcicle {
+			var pk icicle_bn254.ProvingKey
+			if err := icicle_bn254.DummySetup(_r1cs, &pk); err != nil {
+				return nil, err
+			}
+			return &pk, nil
+		}
+		var pk groth16_bn254.ProvingKey
+		if err := groth16_bn254.DummySetup(_r1cs, &pk); err != nil {
+			return nil, err
+		}
+		return &pk, nil
+	case *cs_bw6761.R1CS:
+		var pk groth16_bw6761.ProvingKey
+		if err := groth16_bw6761.DummySetup(_r1cs, &pk); err != nil {
+			return nil, err
+		}
+		return &pk, nil
+	case *cs_bls24317.R1CS:
+		var pk groth16_bls24317.ProvingKey
+		if err := groth16_bls24317.DummySetup(_r1cs, &pk); err != nil {
+			return nil, err
+		}
+		return &pk, nil
+	case *cs_bls24315.R1CS:
+		var pk groth16_bls24315.ProvingKey
+		if err := groth16_bls24315.DummySetup(_r1cs, &pk); err != nil {
+			return nil, err
+		}
+		return &pk, nil
+	case *cs_bw6633.R1CS:
+		var pk groth16_bw6633.ProvingKey
+		if err := groth16_bw6633.DummySetup(_r1cs, &pk); err != nil {
+			return nil, err
+		}
+		return &pk, nil
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+}
+
+// NewProvingKey instantiates a curve-typed ProvingKey and returns an interface object
+// This function exists for serialization purposes
+func NewProvingKey(curveID ecc.ID) ProvingKey {
+	var pk ProvingKey
+	switch curveID {
+	case ecc.BN254:
+		pk = &groth16_bn254.ProvingKey{}
+		if icicle_bn254.HasIcicle {
+			pk = &icicle_bn254.ProvingKey{}
+		}
+	case ecc.BLS12_377:
+		pk = &groth16_bls12377.ProvingKey{}
+	case ecc.BLS12_381:
+		pk = &groth16_bls12381.ProvingKey{}
+	case ecc.BW6_761:
+		pk = &groth16_bw6761.ProvingKey{}
+	case ecc.BLS24_317:
+		pk = &groth16_bls24317.ProvingKey{}
+	case ecc.BLS24_315:
+		pk = &groth16_bls24315.ProvingKey{}
+	case ecc.BW6_633:
+		pk = &groth16_bw6633.ProvingKey{}
+	default:
+		panic("not implemented")
+	}
+	return pk
+}
+
+// NewCS instantiate a concrete curved-typed R1CS and return a R1CS interface
+// This method exists for (de)serialization purposes
+func NewCS(curveID ecc.ID) constraint.ConstraintSystem {
+	var r1cs constraint.ConstraintSystem
+	switch curveID {
+	case ecc.BN254:
+		r1cs = &cs_bn254.R1CS{}
+	case ecc.BLS12_377:
+		r1cs = &cs_bls12377.R1CS{}
+	case ecc.BLS12_381:
+		r1cs = &cs_bls12381.R1CS{}
+	case ecc.BW6_761:
+		r1cs = &cs_bw6761.R1CS{}
+	case ecc.BLS24_317:
+		r1cs = &cs_bls24317.R1CS{}
+	case ecc.BLS24_315:
+		r1cs = &cs_bls24315.R1CS{}
+	case ecc.BW6_633:
+		r1cs = &cs_bw6633.R1CS{}
+	default:
+		panic("not implemented")
+	}
+	return r1cs
+}
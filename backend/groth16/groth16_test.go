@@ -1,20 +1,42 @@
+//go:build !verifier_only
+
 package groth16_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"math/rand"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/consensys/gnark"
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/groth16/internal"
 	"github.com/consensys/gnark/constraint"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/consensys/gnark/test"
+	"github.com/rs/zerolog"
 )
 
+func TestCommitmentDst(t *testing.T) {
+	// the verifier hashes the commitments with its own copy of the tag
+	assert := test.NewAssert(t)
+	assert.Equal(constraint.CommitmentDst, internal.CommitmentDst)
+}
+
 func TestCustomHashToField(t *testing.T) {
 	assert := test.NewAssert(t)
 	assignment := &commitmentCircuit{X: 1}
@@ -51,6 +73,460 @@ func TestCustomHashToField(t *testing.T) {
 	}
 }
 
+func TestDeterministicProver(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := &commitmentCircuit{X: 1}
+	for _, curve := range getCurves() {
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, &commitmentCircuit{})
+			assert.NoError(err)
+			pk, vk, err := groth16.Setup(ccs)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			pubWitness, err := witness.Public()
+			assert.NoError(err)
+
+			// the same random source must give the same proof, whatever the
+			// number of parallel tasks of the solver, the FFTs and the
+			// multi-exponentiations.
+			var proofs [][]byte
+			for _, nbTasks := range []int{1, 3, 8} {
+				proof, err := groth16.Prove(ccs, pk, witness,
+					backend.WithNbTasks(nbTasks),
+					backend.WithProverHashToFieldFunction(constantHash{}),
+					backend.WithProverRandomSource(rand.New(rand.NewSource(42)))) //#nosec G404 -- test only
+				assert.NoError(err)
+				assert.NoError(groth16.Verify(proof, vk, pubWitness, backend.WithVerifierHashToFieldFunction(constantHash{}), backend.WithVerifierNbTasks(nbTasks)))
+				var buf bytes.Buffer
+				_, err = proof.WriteTo(&buf)
+				assert.NoError(err)
+				proofs = append(proofs, buf.Bytes())
+			}
+			for i := 1; i < len(proofs); i++ {
+				assert.Equal(proofs[0], proofs[i], "proofs differ with the number of tasks")
+			}
+		}, curve.String())
+	}
+}
+
+func TestRerandomize(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range getCurves() {
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, &refCircuit{nbConstraints: 2})
+			assert.NoError(err)
+			pk, vk, err := groth16.Setup(ccs)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(&refCircuit{X: 2, Y: 16}, curve.ScalarField())
+			assert.NoError(err)
+			pubWitness, err := witness.Public()
+			assert.NoError(err)
+
+			proof, err := groth16.Prove(ccs, pk, witness)
+			assert.NoError(err)
+			var before, after bytes.Buffer
+			_, err = proof.WriteTo(&before)
+			assert.NoError(err)
+
+			assert.NoError(groth16.Rerandomize(proof, vk))
+			_, err = proof.WriteTo(&after)
+			assert.NoError(err)
+			assert.NotEqual(before.Bytes(), after.Bytes(), "proof was not re-randomized")
+			assert.NoError(groth16.Verify(proof, vk, pubWitness))
+
+			// re-randomizing with [δ]₂ of another setup invalidates the proof
+			_, otherVk, err := groth16.Setup(ccs)
+			assert.NoError(err)
+			assert.NoError(groth16.Rerandomize(proof, otherVk))
+			assert.Error(groth16.Verify(proof, vk, pubWitness))
+		}, curve.String())
+	}
+}
+
+func TestRerandomizeCommitments(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &commitmentCircuit{})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	witness, err := frontend.NewWitness(&commitmentCircuit{X: 1}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, witness, backend.WithProverHashToFieldFunction(constantHash{}))
+	assert.NoError(err)
+
+	// the commitment would link the re-randomized proof to the original one
+	err = groth16.Rerandomize(proof, vk)
+	assert.True(errors.Is(err, backend.ErrRerandomizeCommitments), err)
+}
+
+func TestProverSession(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range getCurves() {
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, &commitmentCircuit{})
+			assert.NoError(err)
+			pk, vk, err := groth16.Setup(ccs)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(&commitmentCircuit{X: 1}, curve.ScalarField())
+			assert.NoError(err)
+			pubWitness, err := witness.Public()
+			assert.NoError(err)
+
+			session := groth16.NewProverSession(ccs, pk)
+			// an aborted proof doesn't prevent the next ones
+			_, err = session.Prove(witness,
+				backend.WithProverHashToFieldFunction(constantHash{}),
+				backend.WithProverDeadline(time.Now().Add(-time.Second)))
+			assert.Error(err)
+
+			const nbProofs = 4
+			proofs := make([]groth16.Proof, nbProofs)
+			errs := make([]error, nbProofs)
+			var wg sync.WaitGroup
+			for i := 0; i < nbProofs; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					proofs[i], errs[i] = session.Prove(witness, backend.WithProverHashToFieldFunction(constantHash{}))
+				}(i)
+			}
+			wg.Wait()
+			for i := range proofs {
+				assert.NoError(errs[i])
+				assert.NoError(groth16.Verify(proofs[i], vk, pubWitness, backend.WithVerifierHashToFieldFunction(constantHash{})))
+			}
+		}, curve.String())
+	}
+}
+
+func TestUnsatisfiedConstraintError(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &refCircuit{nbConstraints: 2})
+	assert.NoError(err)
+	pk, _, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	witness, err := frontend.NewWitness(&refCircuit{X: 2, Y: 15}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	_, err = groth16.Prove(ccs, pk, witness)
+	assert.Error(err)
+	assert.True(errors.Is(err, backend.ErrUnsatisfiedConstraint), "unexpected error: %v", err)
+
+	// the cause of the unsatisfied constraint is reachable from the error
+	var uErr *cs_bn254.UnsatisfiedConstraintError
+	assert.True(errors.As(err, &uErr), "unexpected error: %v", err)
+	assert.NotNil(uErr.Err)
+	assert.True(errors.Is(err, uErr.Err), "cause not reachable: %v", err)
+}
+
+func TestTypedErrors(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &refCircuit{nbConstraints: 2})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	witness, err := frontend.NewWitness(&refCircuit{X: 2, Y: 16}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, witness)
+	assert.NoError(err)
+
+	// the full witness has too many values for the verifier
+	err = groth16.Verify(proof, vk, witness)
+	assert.True(errors.Is(err, backend.ErrInvalidWitnessSize), "unexpected error: %v", err)
+
+	// keys and witnesses of another curve
+	ccsBLS, err := frontend.Compile(ecc.BLS12_381.ScalarField(), r1cs.NewBuilder, &refCircuit{nbConstraints: 2})
+	assert.NoError(err)
+	pkBLS, vkBLS, err := groth16.Setup(ccsBLS)
+	assert.NoError(err)
+	witnessBLS, err := frontend.NewWitness(&refCircuit{X: 2, Y: 16}, ecc.BLS12_381.ScalarField())
+	assert.NoError(err)
+	publicWitnessBLS, err := witnessBLS.Public()
+	assert.NoError(err)
+
+	_, err = groth16.Prove(ccs, pkBLS, witness)
+	assert.True(errors.Is(err, backend.ErrCurveMismatch), "unexpected error: %v", err)
+	_, err = groth16.Prove(ccs, pk, witnessBLS)
+	assert.True(errors.Is(err, backend.ErrCurveMismatch), "unexpected error: %v", err)
+	err = groth16.Verify(proof, vkBLS, publicWitnessBLS)
+	assert.True(errors.Is(err, backend.ErrCurveMismatch), "unexpected error: %v", err)
+	err = groth16.Verify(proof, vk, publicWitnessBLS)
+	assert.True(errors.Is(err, backend.ErrCurveMismatch), "unexpected error: %v", err)
+}
+
+func TestSelfCheck(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := &refCircuit{X: 2, Y: 16}
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, &refCircuit{nbConstraints: 2})
+			assert.NoError(err)
+			pk, _, err := groth16.Setup(ccs)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			_, err = groth16.Prove(ccs, pk, witness,
+				backend.WithProverSelfCheck())
+			assert.NoError(err)
+		}, curve.String())
+	}
+
+	// a faulty accelerator corrupts a multi-exponentiation or the quotient of
+	// the BN254 proof
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &refCircuit{nbConstraints: 2})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	witness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicWitness, err := witness.Public()
+	assert.NoError(err)
+
+	for _, corruptFFT := range []bool{false, true} {
+		acc := &faultyAccelerator{corruptFFT: corruptFFT}
+		proof, err := groth16.Prove(ccs, pk, witness,
+			backend.WithProverAcceleratorEngine(acc))
+		assert.NoError(err, "the corruption is only detected by verifying")
+		assert.Error(groth16.Verify(proof, vk, publicWitness))
+
+		acc.corrupted.Store(false)
+		_, err = groth16.Prove(ccs, pk, witness,
+			backend.WithProverAcceleratorEngine(acc),
+			backend.WithProverSelfCheck())
+		assert.Error(err, "self check didn't detect the corruption (FFT: %t)", corruptFFT)
+		assert.True(acc.corrupted.Load())
+	}
+}
+
+func TestIgnoreUnsatisfiedConstraints(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &refCircuit{nbConstraints: 2})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+
+	// the witness doesn't satisfy the constraints, the proof must not verify
+	witness, err := frontend.NewWitness(&refCircuit{X: 2, Y: 15}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicWitness, err := witness.Public()
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, witness, backend.WithProverIgnoreUnsatisfiedConstraints())
+	assert.NoError(err)
+	assert.Error(groth16.Verify(proof, vk, publicWitness))
+}
+
+func TestProverSolverOptions(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &hintCircuit{})
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	witness, err := frontend.NewWitness(&hintCircuit{X: 3, Y: 9}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicWitness, err := witness.Public()
+	assert.NoError(err)
+
+	// the hint isn't registered, it must be given to the prover
+	_, err = groth16.Prove(ccs, pk, witness)
+	assert.Error(err)
+	proof, err := groth16.Prove(ccs, pk, witness,
+		backend.WithHints(squareHint),
+		backend.WithSolverLogger(zerolog.Nop()),
+		backend.WithNbTasks(1))
+	assert.NoError(err)
+	assert.NoError(groth16.Verify(proof, vk, publicWitness, backend.WithVerifierNbTasks(1)))
+
+	witness, err = frontend.NewWitness(&hintCircuit{X: 3, Y: 10}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	_, err = groth16.Prove(ccs, pk, witness, backend.WithHints(squareHint), backend.WithProverIgnoreUnsatisfiedConstraints())
+	assert.NoError(err)
+
+	// WithSolverOptions replaces the options of a previous WithSolverOptions,
+	// but keeps the hints given with WithHints
+	witness, err = frontend.NewWitness(&hintCircuit{X: 3, Y: 9}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	_, err = groth16.Prove(ccs, pk, witness,
+		backend.WithSolverOptions(solver.WithHints(squareHint)),
+		backend.WithSolverOptions())
+	assert.Error(err)
+	_, err = groth16.Prove(ccs, pk, witness,
+		backend.WithHints(squareHint),
+		backend.WithSolverOptions())
+	assert.NoError(err)
+}
+
+func TestMemoryBudget(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &refCircuit{nbConstraints: 2})
+	assert.NoError(err)
+	pk, _, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	witness, err := frontend.NewWitness(&refCircuit{X: 2, Y: 16}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	_, err = groth16.Prove(ccs, pk, witness, backend.WithProverMemoryBudget(1))
+	assert.True(errors.Is(err, backend.ErrInsufficientMemoryBudget), "unexpected error: %v", err)
+	_, err = groth16.Prove(ccs, pk, witness, backend.WithProverMemoryBudget(1<<30))
+	assert.NoError(err)
+}
+
+func TestEstimateResources(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &refCircuit{nbConstraints: 40})
+	assert.NoError(err)
+	pk, _, err := groth16.Setup(ccs)
+	assert.NoError(err)
+
+	e := pk.EstimateResources()
+	assert.True(e.Memory > 0, "no memory estimated")
+	assert.Equal(5, len(e.MultiExps))
+	assert.Equal(1, len(e.FFTs))
+	assert.Equal(7, e.FFTs[0].Count)
+	assert.True(e.FFTs[0].Size >= uint64(ccs.GetNbConstraints()), "FFT smaller than the constraint system")
+	nbG2 := 0
+	for _, m := range e.MultiExps {
+		if m.G2 {
+			nbG2++
+		}
+	}
+	assert.Equal(1, nbG2)
+	assert.True(e.Time > 0, "no time estimated")
+}
+
+func TestProverProgressAndCancellation(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := &commitmentCircuit{X: 1}
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, &commitmentCircuit{})
+			assert.NoError(err)
+			pk, vk, err := groth16.Setup(ccs)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			publicWitness, err := witness.Public()
+			assert.NoError(err)
+
+			var progress []float64
+			proof, err := groth16.Prove(ccs, pk, witness,
+				backend.WithProverHashToFieldFunction(constantHash{}),
+				backend.WithProverProgress(func(stage string, p float64) {
+					progress = append(progress, p)
+				}))
+			assert.NoError(err)
+			assert.NoError(groth16.Verify(proof, vk, publicWitness, backend.WithVerifierHashToFieldFunction(constantHash{})))
+			assert.Equal(3, len(progress))
+			for i := 1; i < len(progress); i++ {
+				assert.True(progress[i-1] < progress[i], "progress is not increasing")
+			}
+			assert.Equal(1.0, progress[len(progress)-1])
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err = groth16.Prove(ccs, pk, witness,
+				backend.WithProverHashToFieldFunction(constantHash{}),
+				backend.WithProverContext(ctx))
+			assert.True(errors.Is(err, context.Canceled), "unexpected error: %v", err)
+		}, curve.String())
+	}
+}
+
+func TestProverDeadline(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := &commitmentCircuit{X: 1}
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, &commitmentCircuit{})
+			assert.NoError(err)
+			pk, vk, err := groth16.Setup(ccs)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			publicWitness, err := witness.Public()
+			assert.NoError(err)
+
+			var timings []time.Duration
+			proof, err := groth16.Prove(ccs, pk, witness,
+				backend.WithProverHashToFieldFunction(constantHash{}),
+				backend.WithProverDeadline(time.Now().Add(time.Hour)),
+				backend.WithProverStageTimings(func(stage string, elapsed time.Duration) {
+					timings = append(timings, elapsed)
+				}))
+			assert.NoError(err)
+			assert.NoError(groth16.Verify(proof, vk, publicWitness, backend.WithVerifierHashToFieldFunction(constantHash{})))
+			assert.Equal(3, len(timings))
+			for i := 1; i < len(timings); i++ {
+				assert.True(timings[i-1] <= timings[i], "timings are not increasing")
+			}
+
+			deadline := time.Now().Add(-time.Second)
+			_, err = groth16.Prove(ccs, pk, witness,
+				backend.WithProverHashToFieldFunction(constantHash{}),
+				backend.WithProverDeadline(deadline))
+			assert.True(errors.Is(err, context.DeadlineExceeded), "unexpected error: %v", err)
+			var deadlineErr *backend.DeadlineExceededError
+			assert.True(errors.As(err, &deadlineErr), "unexpected error: %v", err)
+			assert.True(deadlineErr.Deadline.Equal(deadline))
+			assert.True(len(deadlineErr.Completed) < 3, "all stages completed")
+		}, curve.String())
+	}
+}
+
+func TestProverAccelerator(t *testing.T) {
+	assert := test.NewAssert(t)
+	acc := new(cpuAccelerator)
+	backend.RegisterAccelerator("test", acc)
+	defer backend.RegisterAccelerator("test", nil)
+
+	assignment := &commitmentCircuit{X: 1}
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, &commitmentCircuit{})
+			assert.NoError(err)
+			pk, vk, err := groth16.Setup(ccs)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			publicWitness, err := witness.Public()
+			assert.NoError(err)
+
+			// the accelerator only supports BN254, the other curves fall back
+			// to the CPU.
+			proof, err := groth16.Prove(ccs, pk, witness,
+				backend.WithProverHashToFieldFunction(constantHash{}),
+				backend.WithProverAccelerator("test"))
+			assert.NoError(err)
+			assert.NoError(groth16.Verify(proof, vk, publicWitness, backend.WithVerifierHashToFieldFunction(constantHash{})))
+		}, curve.String())
+	}
+	assert.Equal(int64(5), acc.nbMultiExp.Load())
+	assert.Equal(int64(7), acc.nbFFT.Load())
+}
+
+func TestProverAcceleratorContext(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &commitmentCircuit{})
+	assert.NoError(err)
+	pk, _, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	witness, err := frontend.NewWitness(&commitmentCircuit{X: 1}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the accelerator gets the context of the prover, and its cancellation
+	// aborts the proof.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	acc := &cancellingAccelerator{cancel: cancel}
+	_, err = groth16.Prove(ccs, pk, witness,
+		backend.WithProverHashToFieldFunction(constantHash{}),
+		backend.WithProverContext(ctx),
+		backend.WithProverAcceleratorEngine(acc))
+	assert.ErrorIs(err, context.Canceled)
+}
+
 //--------------------//
 //     benches		  //
 //--------------------//
@@ -168,6 +644,26 @@ func (c *commitmentCircuit) Define(api frontend.API) error {
 	return nil
 }
 
+type hintCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *hintCircuit) Define(api frontend.API) error {
+	res, err := api.Compiler().NewHint(squareHint, 1, c.X)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(res[0], api.Mul(c.X, c.X))
+	api.AssertIsEqual(res[0], c.Y)
+	return nil
+}
+
+func squareHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	outputs[0].Mul(inputs[0], inputs[0])
+	return nil
+}
+
 type constantHash struct{}
 
 func (h constantHash) Write(p []byte) (n int, err error) { return len(p), nil }
@@ -182,3 +678,100 @@ func getCurves() []ecc.ID {
 	}
 	return gnark.Curves()
 }
+
+// cpuAccelerator computes the offloaded operations with gnark-crypto, on BN254
+// only, and counts them.
+type cpuAccelerator struct {
+	nbMultiExp, nbFFT atomic.Int64
+}
+
+func (a *cpuAccelerator) MultiExp(ctx context.Context, curve ecc.ID, res, points, scalars any, _ backend.MultiExpConfig) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if curve != ecc.BN254 {
+		return backend.ErrAcceleratorUnsupported
+	}
+	a.nbMultiExp.Add(1)
+	var err error
+	switch res := res.(type) {
+	case *bn254.G1Jac:
+		_, err = res.MultiExp(points.([]bn254.G1Affine), scalars.([]fr_bn254.Element), ecc.MultiExpConfig{})
+	case *bn254.G2Jac:
+		_, err = res.MultiExp(points.([]bn254.G2Affine), scalars.([]fr_bn254.Element), ecc.MultiExpConfig{})
+	default:
+		return backend.ErrAcceleratorUnsupported
+	}
+	return err
+}
+
+// faultyAccelerator corrupts the first BN254 G1 multi-exponentiation, or the
+// quotient if corruptFFT is set.
+type faultyAccelerator struct {
+	cpuAccelerator
+	corruptFFT bool
+	corrupted  atomic.Bool
+}
+
+func (a *faultyAccelerator) MultiExp(ctx context.Context, curve ecc.ID, res, points, scalars any, config backend.MultiExpConfig) error {
+	if err := a.cpuAccelerator.MultiExp(ctx, curve, res, points, scalars, config); err != nil {
+		return err
+	}
+	if res, ok := res.(*bn254.G1Jac); ok && !a.corruptFFT && a.corrupted.CompareAndSwap(false, true) {
+		g1, _, _, _ := bn254.Generators()
+		res.AddAssign(&g1)
+	}
+	return nil
+}
+
+func (a *faultyAccelerator) FFT(ctx context.Context, curve ecc.ID, v, domain any, config backend.FFTConfig) error {
+	if err := a.cpuAccelerator.FFT(ctx, curve, v, domain, config); err != nil {
+		return err
+	}
+	if v, ok := v.([]fr_bn254.Element); ok && a.corruptFFT && config.Inverse && config.OnCoset && a.corrupted.CompareAndSwap(false, true) {
+		v[0].SetOne()
+	}
+	return nil
+}
+
+func (a *cpuAccelerator) FFT(ctx context.Context, curve ecc.ID, v, domain any, config backend.FFTConfig) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if curve != ecc.BN254 {
+		return backend.ErrAcceleratorUnsupported
+	}
+	a.nbFFT.Add(1)
+	d := domain.(*fft.Domain)
+	decimation := fft.DIT
+	if config.DIF {
+		decimation = fft.DIF
+	}
+	var opts []fft.Option
+	if config.OnCoset {
+		opts = append(opts, fft.OnCoset())
+	}
+	if config.Inverse {
+		d.FFTInverse(v.([]fr_bn254.Element), decimation, opts...)
+	} else {
+		d.FFT(v.([]fr_bn254.Element), decimation, opts...)
+	}
+	return nil
+}
+
+// cancellingAccelerator is a cpuAccelerator which cancels the context of the
+// prover on its first call.
+type cancellingAccelerator struct {
+	cpuAccelerator
+	cancel context.CancelFunc
+}
+
+func (a *cancellingAccelerator) MultiExp(ctx context.Context, curve ecc.ID, res, points, scalars any, config backend.MultiExpConfig) error {
+	a.cancel()
+	return a.cpuAccelerator.MultiExp(ctx, curve, res, points, scalars, config)
+}
+
+func (a *cancellingAccelerator) FFT(ctx context.Context, curve ecc.ID, v, domain any, config backend.FFTConfig) error {
+	a.cancel()
+	return a.cpuAccelerator.FFT(ctx, curve, v, domain, config)
+}
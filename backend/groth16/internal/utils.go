@@ -1,5 +1,10 @@
 package internal
 
+// CommitmentDst is the domain separation tag of the hash of the commitments to
+// the public inputs. It is constraint.CommitmentDst, duplicated so that the
+// verifier doesn't depend on the constraint package.
+const CommitmentDst = "bsb22-commitment"
+
 func ConcatAll(slices ...[]int) []int { // copyright note: written by GitHub Copilot
 	totalLen := 0
 	for _, s := range slices {
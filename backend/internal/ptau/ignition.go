@@ -0,0 +1,183 @@
+package ptau
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// The transcript of the Aztec Ignition ceremony
+// (https://github.com/AztecProtocol/ignition-verification) is split in files,
+// each laid out as
+//
+//	IgnitionManifest
+//	[NumG1Points]G1   {[τˢ⁺¹]₁, [τˢ⁺²]₁, …}, s = StartFrom
+//	[NumG2Points]G2   {[τ]₂, …}, in the first file only
+//	[64]byte          BLAKE2b checksum of the preceding bytes
+//
+// where the manifest fields are big-endian uint32 and the coordinates of the
+// points are encoded on 4 64-bit limbs, the least significant first, each limb
+// being big-endian. The G2 coordinates are given as (A0, A1). [τ⁰]₁ and [τ⁰]₂,
+// the generators, are not in the transcript.
+
+const (
+	// IgnitionManifestSize is the size of the manifest heading a transcript
+	// file of the Aztec Ignition ceremony.
+	IgnitionManifestSize = 28
+
+	ignitionG1Size = 2 * fp.Bytes
+	ignitionG2Size = 4 * fp.Bytes
+)
+
+// IgnitionManifest is the manifest heading a transcript file of the Aztec
+// Ignition ceremony.
+type IgnitionManifest struct {
+	TranscriptNumber uint32
+	TotalTranscripts uint32
+	TotalG1Points    uint32
+	TotalG2Points    uint32
+	NumG1Points      uint32
+	NumG2Points      uint32
+	// StartFrom is the number of powers of τ in G1 of the previous files.
+	StartFrom uint32
+}
+
+// G1Offset returns the offset of the G1 points in the transcript file.
+func (m *IgnitionManifest) G1Offset() int64 {
+	return IgnitionManifestSize
+}
+
+// G2Offset returns the offset of the G2 points in the transcript file.
+func (m *IgnitionManifest) G2Offset() int64 {
+	return IgnitionManifestSize + int64(m.NumG1Points)*ignitionG1Size
+}
+
+// ReadIgnitionManifest reads the manifest of a transcript file of the Aztec
+// Ignition ceremony.
+func ReadIgnitionManifest(r io.ReadSeeker) (IgnitionManifest, error) {
+	var m IgnitionManifest
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return m, err
+	}
+	err := binary.Read(r, binary.BigEndian, &m)
+	return m, err
+}
+
+// ReadIgnitionG1 reads len(points) consecutive G1 points of an Ignition
+// transcript file at offset. The points are checked to be on the curve and in
+// the correct subgroup.
+func ReadIgnitionG1(r io.ReadSeeker, offset int64, points []curve.G1Affine) error {
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	return readPoints(r, len(points), ignitionG1Size, func(i int, buf []byte) error {
+		var err error
+		p := &points[i]
+		if p.X, err = ignitionFp(buf[0:]); err != nil {
+			return err
+		}
+		if p.Y, err = ignitionFp(buf[fp.Bytes:]); err != nil {
+			return err
+		}
+		if !p.IsOnCurve() || !p.IsInSubGroup() {
+			return errors.New("invalid G1 point")
+		}
+		return nil
+	})
+}
+
+// ReadIgnitionG2 reads len(points) consecutive G2 points of an Ignition
+// transcript file at offset. The points are checked to be on the curve and in
+// the correct subgroup.
+func ReadIgnitionG2(r io.ReadSeeker, offset int64, points []curve.G2Affine) error {
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	return readPoints(r, len(points), ignitionG2Size, func(i int, buf []byte) error {
+		p := &points[i]
+		for j, e := range []*fp.Element{&p.X.A0, &p.X.A1, &p.Y.A0, &p.Y.A1} {
+			var err error
+			if *e, err = ignitionFp(buf[j*fp.Bytes:]); err != nil {
+				return err
+			}
+		}
+		if !p.IsOnCurve() || !p.IsInSubGroup() {
+			return errors.New("invalid G2 point")
+		}
+		return nil
+	})
+}
+
+// ignitionFp decodes a base field element from its limbs, the least
+// significant first, each limb being big-endian.
+func ignitionFp(buf []byte) (fp.Element, error) {
+	var b [fp.Bytes]byte
+	for i := 0; i < fp.Bytes/8; i++ {
+		copy(b[fp.Bytes-8*(i+1):fp.Bytes-8*i], buf[8*i:8*(i+1)])
+	}
+	return fp.BigEndian.Element(&b)
+}
+
+// putIgnitionFp encodes e as ignitionFp decodes it.
+func putIgnitionFp(buf []byte, e fp.Element) {
+	var b [fp.Bytes]byte
+	fp.BigEndian.PutElement(&b, e)
+	for i := 0; i < fp.Bytes/8; i++ {
+		copy(buf[8*i:8*(i+1)], b[fp.Bytes-8*(i+1):fp.Bytes-8*i])
+	}
+}
+
+// IgnitionTranscripts returns the transcript files of an Ignition ceremony of
+// nbTranscripts files of nbPoints powers of τ in G1 each, for the toxic waste
+// τ. The checksums are left zero. It is meant for tests.
+func IgnitionTranscripts(nbTranscripts, nbPoints int, tau *big.Int) [][]byte {
+	_, _, g1, g2 := curve.Generators()
+	var t, ti fr.Element
+	t.SetBigInt(tau)
+	ti.Set(&t)
+
+	res := make([][]byte, nbTranscripts)
+	for k := range res {
+		m := IgnitionManifest{
+			TranscriptNumber: uint32(k),
+			TotalTranscripts: uint32(nbTranscripts),
+			TotalG1Points:    uint32(nbTranscripts * nbPoints),
+			TotalG2Points:    1,
+			NumG1Points:      uint32(nbPoints),
+			StartFrom:        uint32(k * nbPoints),
+		}
+		if k == 0 {
+			m.NumG2Points = 1
+		}
+		var buf bytes.Buffer
+		_ = binary.Write(&buf, binary.BigEndian, &m)
+
+		raw := make([]byte, ignitionG2Size)
+		for i := 0; i < nbPoints; i++ {
+			var p curve.G1Affine
+			var bs big.Int
+			p.ScalarMultiplication(&g1, ti.BigInt(&bs))
+			putIgnitionFp(raw[0:], p.X)
+			putIgnitionFp(raw[fp.Bytes:], p.Y)
+			buf.Write(raw[:ignitionG1Size])
+			ti.Mul(&ti, &t)
+		}
+		if k == 0 {
+			var p curve.G2Affine
+			p.ScalarMultiplication(&g2, tau)
+			for j, e := range []fp.Element{p.X.A0, p.X.A1, p.Y.A0, p.Y.A1} {
+				putIgnitionFp(raw[j*fp.Bytes:], e)
+			}
+			buf.Write(raw)
+		}
+		buf.Write(make([]byte, 64)) // checksum
+		res[k] = buf.Bytes()
+	}
+	return res
+}
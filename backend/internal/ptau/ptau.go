@@ -0,0 +1,153 @@
+// Package ptau reads the BN254 transcripts of public Powers of Tau ceremonies,
+// for the backends which start their setup from them: the challenge files of
+// the Perpetual Powers of Tau ceremony
+// (https://github.com/privacy-scaling-explorations/perpetualpowersoftau) and
+// the transcript files of the Aztec Ignition ceremony (see
+// [ReadIgnitionManifest]). For a Perpetual Powers of Tau ceremony of power n
+// the challenge file is laid out as
+//
+//	[64]byte      BLAKE2b hash of the previous response
+//	[2ⁿ⁺¹-1]G1    {[τ⁰]₁, [τ¹]₁, …}
+//	[2ⁿ]G2        {[τ⁰]₂, [τ¹]₂, …}
+//	[2ⁿ]G1        {α[τ⁰]₁, α[τ¹]₁, …}
+//	[2ⁿ]G1        {β[τ⁰]₁, β[τ¹]₁, …}
+//	G2            [β]₂
+//
+// where the points are uncompressed and big-endian encoded.
+package ptau
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// HashSize is the size of the hash heading the challenge file.
+const HashSize = 64
+
+// ErrInvalidTranscript is returned when the points read from a transcript are
+// not the parameters of a valid ceremony.
+var ErrInvalidTranscript = errors.New("invalid powers of tau transcript")
+
+// Layout holds the offsets of the sections of a challenge file.
+type Layout struct {
+	TauG1, TauG2, AlphaTauG1, BetaTauG1, BetaG2 int64
+}
+
+// NewLayout returns the layout of the challenge file of a ceremony of the given
+// power.
+func NewLayout(power uint8) (Layout, error) {
+	if power >= 62 {
+		return Layout{}, fmt.Errorf("invalid ceremony power %d", power)
+	}
+	n := int64(1) << power
+	var l Layout
+	l.TauG1 = HashSize
+	l.TauG2 = l.TauG1 + (2*n-1)*curve.SizeOfG1AffineUncompressed
+	l.AlphaTauG1 = l.TauG2 + n*curve.SizeOfG2AffineUncompressed
+	l.BetaTauG1 = l.AlphaTauG1 + n*curve.SizeOfG1AffineUncompressed
+	l.BetaG2 = l.BetaTauG1 + n*curve.SizeOfG1AffineUncompressed
+	return l, nil
+}
+
+// ReadG1 reads len(points) consecutive G1 points at offset. The points are
+// checked to be on the curve and in the correct subgroup.
+func ReadG1(r io.ReadSeeker, offset int64, points []curve.G1Affine) error {
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	return readPoints(bufio.NewReader(r), len(points), curve.SizeOfG1AffineUncompressed, func(i int, buf []byte) error {
+		_, err := points[i].SetBytes(buf)
+		return err
+	})
+}
+
+// ReadG2 reads len(points) consecutive G2 points at offset. The points are
+// checked to be on the curve and in the correct subgroup.
+func ReadG2(r io.ReadSeeker, offset int64, points []curve.G2Affine) error {
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	return readPoints(bufio.NewReader(r), len(points), curve.SizeOfG2AffineUncompressed, func(i int, buf []byte) error {
+		_, err := points[i].SetBytes(buf)
+		return err
+	})
+}
+
+// readPoints reads n points of pointSize bytes from r, and decodes them with
+// setBytes.
+func readPoints(r io.Reader, n, pointSize int, setBytes func(i int, buf []byte) error) error {
+	buf := make([]byte, n*pointSize)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return err
+	}
+	errs := make([]error, n)
+	utils.Parallelize(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			errs[i] = setBytes(i, buf[i*pointSize:(i+1)*pointSize])
+		}
+	})
+	for i := range errs {
+		if errs[i] != nil {
+			return fmt.Errorf("point %d: %w", i, errs[i])
+		}
+	}
+	return nil
+}
+
+// ChallengeFile returns the challenge file of a ceremony of the given power
+// for the toxic waste τ, α and β. It is meant for tests.
+func ChallengeFile(power uint8, tau, alpha, beta *big.Int) []byte {
+	n := 1 << power
+	_, _, g1, g2 := curve.Generators()
+
+	var buf bytes.Buffer
+	buf.Write(make([]byte, HashSize)) // hash of the previous response
+
+	var t, a, b fr.Element
+	t.SetBigInt(tau)
+	a.SetBigInt(alpha)
+	b.SetBigInt(beta)
+	taus := make([]fr.Element, 2*n-1)
+	taus[0].SetOne()
+	for i := 1; i < len(taus); i++ {
+		taus[i].Mul(&taus[i-1], &t)
+	}
+
+	writeG1 := func(s fr.Element) {
+		var p curve.G1Affine
+		var bs big.Int
+		p.ScalarMultiplication(&g1, s.BigInt(&bs))
+		raw := p.RawBytes()
+		buf.Write(raw[:])
+	}
+	writeG2 := func(s fr.Element) {
+		var p curve.G2Affine
+		var bs big.Int
+		p.ScalarMultiplication(&g2, s.BigInt(&bs))
+		raw := p.RawBytes()
+		buf.Write(raw[:])
+	}
+	for i := 0; i < 2*n-1; i++ {
+		writeG1(taus[i])
+	}
+	for i := 0; i < n; i++ {
+		writeG2(taus[i])
+	}
+	for _, s := range []fr.Element{a, b} {
+		for i := 0; i < n; i++ {
+			var st fr.Element
+			st.Mul(&taus[i], &s)
+			writeG1(st)
+		}
+	}
+	writeG2(b)
+	return buf.Bytes()
+}
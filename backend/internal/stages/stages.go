@@ -0,0 +1,64 @@
+// Package stages records the completion of the stages of the provers, and
+// reports it to the callbacks of the prover configuration.
+package stages
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/consensys/gnark/backend"
+)
+
+// Recorder records the completion of the stages of a prover.
+type Recorder struct {
+	opt      *backend.ProverConfig
+	start    time.Time
+	nbStages int
+
+	lock      sync.Mutex
+	completed []backend.StageTiming
+}
+
+// NewRecorder returns a recorder for a prover of nbStages stages. If the
+// configuration has a deadline, its context is replaced by a context with the
+// deadline, to be released with the returned function.
+func NewRecorder(opt *backend.ProverConfig, nbStages int) (*Recorder, context.CancelFunc) {
+	cancel := func() {}
+	if !opt.Deadline.IsZero() {
+		opt.Context, cancel = context.WithDeadline(opt.Context, opt.Deadline)
+	}
+	return &Recorder{opt: opt, start: time.Now(), nbStages: nbStages}, cancel
+}
+
+// Done records the completion of the stage and reports it to the callbacks.
+// It is safe for concurrent use.
+func (r *Recorder) Done(stage string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	elapsed := time.Since(r.start)
+	r.completed = append(r.completed, backend.StageTiming{Stage: stage, Elapsed: elapsed})
+	if r.opt.Progress != nil {
+		r.opt.Progress(stage, float64(len(r.completed))/float64(r.nbStages))
+	}
+	if r.opt.StageTimings != nil {
+		r.opt.StageTimings(stage, elapsed)
+	}
+}
+
+// Err returns the error of the context of the prover, as a
+// [*backend.DeadlineExceededError] if the deadline is exceeded.
+func (r *Recorder) Err() error {
+	err := r.opt.Context.Err()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	deadline, _ := r.opt.Context.Deadline()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return &backend.DeadlineExceededError{
+		Deadline:  deadline,
+		Completed: append([]backend.StageTiming(nil), r.completed...),
+	}
+}
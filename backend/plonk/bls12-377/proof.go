@@ -0,0 +1,55 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package plonk
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+)
+
+// Proof is a PLONK proof. It carries the commitments bound in the Fiat-Shamir
+// transcript, in the order in which they are bound:
+//   - gamma: LRO (after the public data)
+//   - alpha: Bsb22Commitments, then Z
+//   - zeta: H
+//
+// followed by the opening proofs of the claimed evaluations.
+type Proof struct {
+
+	// Commitments to the solution vectors
+	LRO [3]kzg.Digest
+
+	// Commitment to Z, the permutation polynomial
+	Z kzg.Digest
+
+	// Commitments to h1, h2, h3 such that h = h1 + Xⁿ⁺²*h2 + X²⁽ⁿ⁺²⁾*h3 is the quotient polynomial
+	H [3]kzg.Digest
+
+	Bsb22Commitments []kzg.Digest
+
+	// Batch opening proof of linearizedPolynomial, l, r, o, s1, s2, qCPrime
+	BatchedProof kzg.BatchOpeningProof
+
+	// Opening proof of Z at zeta*mu
+	ZShiftedOpening kzg.OpeningProof
+}
+
+// CurveID returns the curveID
+func (proof *Proof) CurveID() ecc.ID {
+	return curve.ID
+}
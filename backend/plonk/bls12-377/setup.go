@@ -1,3 +1,6 @@
+//go:build !verifier_only
+// +build !verifier_only
+
 // Copyright 2020 ConsenSys Software Inc.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -23,40 +26,13 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/iop"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/plonk/internal"
+	"github.com/consensys/gnark/backend/plonk/permutation"
 	"github.com/consensys/gnark/constraint"
 	cs "github.com/consensys/gnark/constraint/bls12-377"
 )
 
-// VerifyingKey stores the data needed to verify a proof:
-// * The commitment scheme
-// * Commitments of ql prepended with as many ones as there are public inputs
-// * Commitments of qr, qm, qo, qk prepended with as many zeroes as there are public inputs
-// * Commitments to S1, S2, S3
-type VerifyingKey struct {
-	// Size circuit
-	Size              uint64
-	SizeInv           fr.Element
-	Generator         fr.Element
-	NbPublicVariables uint64
-
-	// Commitment scheme that is used for an instantiation of PLONK
-	Kzg kzg.VerifyingKey
-
-	// cosetShift generator of the coset on the small domain
-	CosetShift fr.Element
-
-	// S commitments to S1, S2, S3
-	S [3]kzg.Digest
-
-	// Commitments to ql, qr, qm, qo, qcp prepended with as many zeroes (ones for l) as there are public inputs.
-	// In particular Qk is not complete.
-	Ql, Qr, Qm, Qo, Qk kzg.Digest
-	Qcp                []kzg.Digest
-
-	CommitmentConstraintIndexes []uint64
-}
-
 // Trace stores a plonk trace as columns
 type Trace struct {
 	// Constants describing a plonk circuit. The first entries
@@ -78,14 +54,6 @@ type Trace struct {
 	S []int64
 }
 
-// ProvingKey stores the data needed to generate a proof
-type ProvingKey struct {
-	Kzg, KzgLagrange kzg.ProvingKey
-
-	// Verifying Key is embedded into the proving key (needed by Prove)
-	Vk *VerifyingKey
-}
-
 func Setup(spr *cs.SparseR1CS, srs, srsLagrange kzg.SRS) (*ProvingKey, *VerifyingKey, error) {
 
 	var pk ProvingKey
@@ -96,17 +64,17 @@ func Setup(spr *cs.SparseR1CS, srs, srsLagrange kzg.SRS) (*ProvingKey, *Verifyin
 	// step 0: set the fft domains
 	domain := initFFTDomain(spr)
 	if domain.Cardinality < 2 {
-		return nil, nil, fmt.Errorf("circuit has only %d constraints; unsupported by the current implementation", spr.GetNbConstraints())
+		return nil, nil, fmt.Errorf("%w: circuit has only %d constraints; unsupported by the current implementation", backend.ErrDomainTooSmall, spr.GetNbConstraints())
 	}
 
 	// check the size of the kzg srs.
 	if len(srs.Pk.G1) < (int(domain.Cardinality) + 3) { // + 3 for the kzg.Open of blinded poly
-		return nil, nil, fmt.Errorf("kzg srs is too small: got %d, need %d", len(srs.Pk.G1), domain.Cardinality+3)
+		return nil, nil, fmt.Errorf("kzg %w: got %d, need %d", backend.ErrSRSTooSmall, len(srs.Pk.G1), domain.Cardinality+3)
 	}
 
 	// same for the lagrange form
 	if len(srsLagrange.Pk.G1) != int(domain.Cardinality) {
-		return nil, nil, fmt.Errorf("kzg srs lagrange is too small: got %d, need %d", len(srsLagrange.Pk.G1), domain.Cardinality)
+		return nil, nil, fmt.Errorf("kzg lagrange %w: got %d, need %d", backend.ErrSRSTooSmall, len(srsLagrange.Pk.G1), domain.Cardinality)
 	}
 
 	// step 1: set the verifying key
@@ -136,16 +104,6 @@ func Setup(spr *cs.SparseR1CS, srs, srsLagrange kzg.SRS) (*ProvingKey, *Verifyin
 	return &pk, &vk, nil
 }
 
-// NbPublicWitness returns the expected public witness size (number of field elements)
-func (vk *VerifyingKey) NbPublicWitness() int {
-	return int(vk.NbPublicVariables)
-}
-
-// VerifyingKey returns pk.Vk
-func (pk *ProvingKey) VerifyingKey() interface{} {
-	return pk.Vk
-}
-
 // NewTrace returns a new Trace object from the constraint system.
 // It fills the constant columns ql, qr, qm, qo, qk, and qcp with the
 // coefficients of the constraints.
@@ -266,23 +224,13 @@ func initFFTDomain(spr *cs.SparseR1CS) *fft.Domain {
 //	s. (l∥r∥o) = (l∥r∥o)
 //
 // , where l∥r∥o is the concatenation of the indices of l, r, o in
-// ql.l+qr.r+qm.l.r+qo.O+k = 0.
-//
-// The permutation is encoded as a slice s of size 3*size(l), where the
-// i-th entry of l∥r∥o is sent to the s[i]-th entry, so it acts on a tab
-// like this: for i in tab: tab[i] = tab[permutation[i]]
+// ql.l+qr.r+qm.l.r+qo.O+k = 0. See [permutation.Build] for the encoding.
 func buildPermutation(spr *cs.SparseR1CS, trace *Trace, nbVariables int) {
 
 	// nbVariables := spr.NbInternalVariables + len(spr.Public) + len(spr.Secret)
 	sizeSolution := len(trace.Ql.Coefficients())
 	sizePermutation := 3 * sizeSolution
 
-	// init permutation
-	permutation := make([]int64, sizePermutation)
-	for i := 0; i < len(permutation); i++ {
-		permutation[i] = -1
-	}
-
 	// init LRO position -> variable_ID
 	lro := make([]int, sizePermutation) // position -> variable_ID
 	for i := 0; i < len(spr.Public); i++ {
@@ -301,30 +249,7 @@ func buildPermutation(spr *cs.SparseR1CS, trace *Trace, nbVariables int) {
 		j++
 	}
 
-	// init cycle:
-	// map ID -> last position the ID was seen
-	cycle := make([]int64, nbVariables)
-	for i := 0; i < len(cycle); i++ {
-		cycle[i] = -1
-	}
-
-	for i := 0; i < len(lro); i++ {
-		if cycle[lro[i]] != -1 {
-			// if != -1, it means we already encountered this value
-			// so we need to set the corresponding permutation index.
-			permutation[i] = cycle[lro[i]]
-		}
-		cycle[lro[i]] = int64(i)
-	}
-
-	// complete the Permutation by filling the first IDs encountered
-	for i := 0; i < sizePermutation; i++ {
-		if permutation[i] == -1 {
-			permutation[i] = cycle[lro[i]]
-		}
-	}
-
-	trace.S = permutation
+	trace.S = permutation.Build(lro, nbVariables)
 }
 
 // computePermutationPolynomials computes the LDE (Lagrange basis) of the permutation.
@@ -0,0 +1,81 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package plonk
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+// VerifyingKey stores the data needed to verify a proof:
+// * The commitment scheme
+// * Commitments of ql prepended with as many ones as there are public inputs
+// * Commitments of qr, qm, qo, qk prepended with as many zeroes as there are public inputs
+// * Commitments to S1, S2, S3
+type VerifyingKey struct {
+	// Size circuit
+	Size              uint64
+	SizeInv           fr.Element
+	Generator         fr.Element
+	NbPublicVariables uint64
+
+	// Commitment scheme that is used for an instantiation of PLONK
+	Kzg kzg.VerifyingKey
+
+	// cosetShift generator of the coset on the small domain
+	CosetShift fr.Element
+
+	// S commitments to S1, S2, S3
+	S [3]kzg.Digest
+
+	// Commitments to ql, qr, qm, qo, qcp prepended with as many zeroes (ones for l) as there are public inputs.
+	// In particular Qk is not complete.
+	Ql, Qr, Qm, Qo, Qk kzg.Digest
+	Qcp                []kzg.Digest
+
+	CommitmentConstraintIndexes []uint64
+}
+
+// ProvingKey stores the data needed to generate a proof
+type ProvingKey struct {
+	Kzg, KzgLagrange kzg.ProvingKey
+
+	// Verifying Key is embedded into the proving key (needed by Prove)
+	Vk *VerifyingKey
+}
+
+// NbPublicWitness returns the expected public witness size (number of field elements)
+func (vk *VerifyingKey) NbPublicWitness() int {
+	return int(vk.NbPublicVariables)
+}
+
+// VerifyingKey returns pk.Vk
+func (pk *ProvingKey) VerifyingKey() interface{} {
+	return pk.Vk
+}
+
+// CurveID returns the curveID
+func (pk *ProvingKey) CurveID() ecc.ID {
+	return curve.ID
+}
+
+// CurveID returns the curveID
+func (vk *VerifyingKey) CurveID() ecc.ID {
+	return curve.ID
+}
@@ -40,7 +40,6 @@ import (
 
 var (
 	errAlgebraicRelation = errors.New("algebraic relation does not hold")
-	errInvalidWitness    = errors.New("witness length is invalid")
 )
 
 func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...backend.VerifierOption) error {
@@ -57,7 +56,7 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 	}
 
 	if len(publicWitness) != int(vk.NbPublicVariables) {
-		return errInvalidWitness
+		return fmt.Errorf("%w, got %d, expected %d", backend.ErrInvalidWitnessSize, len(publicWitness), vk.NbPublicVariables)
 	}
 
 	// transcript to derive the challenge
@@ -251,7 +250,7 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 		_s1, coeffZ,
 		zh, zetaNPlusTwoZh, zetaNPlusTwoSquareZh,
 	)
-	if _, err := linearizedPolynomialDigest.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+	if _, err := linearizedPolynomialDigest.MultiExp(points, scalars, ecc.MultiExpConfig{NbTasks: cfg.NbTasks}); err != nil {
 		return err
 	}
 
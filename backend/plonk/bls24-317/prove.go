@@ -1,3 +1,6 @@
+//go:build !verifier_only
+// +build !verifier_only
+
 // Copyright 2020 ConsenSys Software Inc.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -21,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"hash"
+	"io"
 	"math/big"
 	"math/bits"
 	"runtime"
@@ -42,6 +46,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/kzg"
 	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
 	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/internal/stages"
 	"github.com/consensys/gnark/backend/witness"
 
 	"github.com/consensys/gnark/constraint"
@@ -50,6 +55,7 @@ import (
 	fcs "github.com/consensys/gnark/frontend/cs"
 	"github.com/consensys/gnark/internal/utils"
 	"github.com/consensys/gnark/logger"
+	"github.com/consensys/gnark/security"
 )
 
 const (
@@ -86,26 +92,22 @@ const (
 	order_blinding_R = 1
 	order_blinding_O = 1
 	order_blinding_Z = 2
-)
-
-type Proof struct {
-
-	// Commitments to the solution vectors
-	LRO [3]kzg.Digest
-
-	// Commitment to Z, the permutation polynomial
-	Z kzg.Digest
 
-	// Commitments to h1, h2, h3 such that h = h1 + Xⁿ⁺²*h2 + X²⁽ⁿ⁺²⁾*h3 is the quotient polynomial
-	H [3]kzg.Digest
-
-	Bsb22Commitments []kzg.Digest
-
-	// Batch opening proof of linearizedPolynomial, l, r, o, s1, s2, qCPrime
-	BatchedProof kzg.BatchOpeningProof
+	// number of random coefficients of the blinding polynomials
+	nb_blinding_coefficients = order_blinding_L + order_blinding_R + order_blinding_O + order_blinding_Z + 4
+)
 
-	// Opening proof of Z at zeta*mu
-	ZShiftedOpening kzg.OpeningProof
+// quotientDomainSize returns the size of the domain on which the quotient
+// polynomial is computed, where n is the size of the small domain.
+//
+// The constraints are of degree at most 4 in the blinded polynomials, which are
+// of degree at most n+order_blinding_Z, so the quotient h of the constraints by
+// Xⁿ-1 is of degree at most 3(n+1)+2. It is split in the 3 parts h₁, h₂, h₃ of
+// the proof, of size n+2, so the domain is the next power of 2 superior to
+// 3(n+2). The numerator is evaluated on as many cosets of the small domain as
+// the ratio of the sizes of the domains.
+func quotientDomainSize(n uint64) uint64 {
+	return ecc.NextPowerOfTwo(3 * (n + order_blinding_Z))
 }
 
 func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...backend.ProverOption) (*Proof, error) {
@@ -120,51 +122,181 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 	if err != nil {
 		return nil, fmt.Errorf("get prover options: %w", err)
 	}
+	if opt.SecurityLevel != 0 {
+		if err := security.CheckProverConfig(spr.CurveID(), &opt); err != nil {
+			return nil, err
+		}
+		if err := security.CheckSRS(spr.CurveID(), len(pk.Kzg.G1), security.Level(opt.SecurityLevel)); err != nil {
+			return nil, err
+		}
+	}
 
 	start := time.Now()
+	rec, cancel := stages.NewRecorder(&opt, nbProverSteps)
+	defer cancel()
 
 	// init instance
-	g, ctx := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(opt.Context)
 	instance, err := newInstance(ctx, spr, pk, fullWitness, &opt)
 	if err != nil {
 		return nil, fmt.Errorf("new instance: %w", err)
 	}
+	instance.stages = rec
 
 	// solve constraints
-	g.Go(instance.solveConstraints)
+	g.Go(instance.step("solve", instance.solveConstraints))
 
 	// complete qk
-	g.Go(instance.completeQk)
+	g.Go(instance.step("complete qk", instance.completeQk))
 
 	// init blinding polynomials
-	g.Go(instance.initBlindingPolynomials)
+	g.Go(instance.step("blinding polynomials", instance.initBlindingPolynomials))
 
 	// derive gamma, beta (copy constraint)
-	g.Go(instance.deriveGammaAndBeta)
+	g.Go(instance.step("derive gamma beta", instance.deriveGammaAndBeta))
 
 	// compute accumulating ratio for the copy constraint
-	g.Go(instance.buildRatioCopyConstraint)
+	g.Go(instance.step("copy constraint", instance.buildRatioCopyConstraint))
 
 	// compute h
-	g.Go(instance.computeQuotient)
+	g.Go(instance.step("quotient", instance.computeQuotient))
 
 	// open Z (blinded) at ωζ (proof.ZShiftedOpening)
-	g.Go(instance.openZ)
+	g.Go(instance.step("open z", instance.openZ))
 
 	// linearized polynomial
-	g.Go(instance.computeLinearizedPolynomial)
+	g.Go(instance.step("linearized polynomial", instance.computeLinearizedPolynomial))
 
 	// Batch opening
-	g.Go(instance.batchOpening)
+	g.Go(instance.step("batch opening", instance.batchOpening))
 
 	if err := g.Wait(); err != nil {
+		if ctxErr := rec.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, err
 	}
 
+	if opt.SelfCheck {
+		if err := instance.selfCheck(); err != nil {
+			return nil, err
+		}
+	}
+
 	log.Debug().Dur("took", time.Since(start)).Msg("prover done")
 	return instance.proof, nil
 }
 
+// nbProverSteps is the number of steps run by Prove, for progress reports.
+const nbProverSteps = 9
+
+// step wraps a step of the prover so that its completion is recorded and
+// reported to the callbacks.
+func (s *instance) step(name string, f func() error) func() error {
+	return func() error {
+		if err := f(); err != nil {
+			return err
+		}
+		s.stages.Done(name)
+		return nil
+	}
+}
+
+// memoryEstimate returns an estimate of the number of bytes allocated by the
+// prover: the polynomials of the trace and of the solution on the small
+// domain, the quotient and its numerator on the big domain, and the solution
+// of the constraint system.
+func (s *instance) memoryEstimate() uint64 {
+	nbInternal, nbSecret, nbPublic := s.spr.GetNbVariables()
+	n, N := s.domain0.Cardinality, s.domain1.Cardinality
+	nbPolys := uint64(id_Qci + 2*len(s.commitmentInfo))
+	return (nbPolys*n + 2*N + uint64(nbInternal+nbSecret+nbPublic)) * fr.Bytes
+}
+
+// EstimateResources returns an estimate of the resources needed to prove with
+// the ProvingKey. The memory is estimated as instance.memoryEstimate, with at
+// most three wires per constraint. The FFTs are dominated by the evaluation of
+// the numerator of the quotient on the cosets of the small domain, and the
+// sizes of the multi-exponentiations are rounded to the size of the domain.
+// The time is extrapolated from small operations measured on the first call.
+func (pk *ProvingKey) EstimateResources() backend.ResourceEstimate {
+	n := pk.Vk.Size
+	N := quotientDomainSize(n)
+	rho := int(N / n)
+	nbCommitments := len(pk.Vk.Qcp)
+	nbPolys := id_Qci + 2*nbCommitments
+	e := backend.ResourceEstimate{
+		Memory: (uint64(nbPolys)*n + 2*N + 3*n) * fr.Bytes,
+		FFTs: []backend.FFTEstimate{
+			{Size: n, Count: (2*rho + 1) * nbPolys},
+			{Size: N, Count: 1},
+		},
+	}
+	// L, R, O, Z, the BSB22 commitments, H₁, H₂, H₃, the linearized
+	// polynomial and the two openings
+	for i := 0; i < 10+nbCommitments; i++ {
+		e.MultiExps = append(e.MultiExps, backend.MultiExpEstimate{Size: int(n)})
+	}
+	e.EstimateTime(calibrate())
+	return e
+}
+
+var calibration struct {
+	once sync.Once
+	c    backend.Calibration
+}
+
+// calibrate returns the time of small multi-exponentiations and FFTs on this
+// machine, measured on the first call.
+func calibrate() backend.Calibration {
+	calibration.once.Do(func() {
+		_, _, g1, _ := curve.Generators()
+		scalars := make([]fr.Element, backend.CalibrationMultiExpSize)
+		var one fr.Element
+		one.SetOne()
+		scalars[0].SetUint64(3)
+		for i := 1; i < len(scalars); i++ {
+			scalars[i].Square(&scalars[i-1]).Add(&scalars[i], &one)
+		}
+		points := curve.BatchScalarMultiplicationG1(&g1, scalars)
+
+		var r curve.G1Jac
+		start := time.Now()
+		if _, err := r.MultiExp(points, scalars, ecc.MultiExpConfig{}); err == nil {
+			calibration.c.G1MultiExp = time.Since(start)
+		}
+
+		domain := fft.NewDomain(backend.CalibrationFFTSize)
+		v := make([]fr.Element, backend.CalibrationFFTSize)
+		copy(v, scalars)
+		start = time.Now()
+		domain.FFT(v, fft.DIF)
+		calibration.c.FFT = time.Since(start)
+	})
+	return calibration.c
+}
+
+// selfCheck verifies the proof against the verifying key of pk. As the
+// verifier checks all the KZG commitments and openings with pairings, it
+// detects a proof corrupted by a faulty MSM.
+func (s *instance) selfCheck() error {
+	fw, ok := s.fullWitness.Vector().(fr.Vector)
+	if !ok {
+		return witness.ErrInvalidWitness
+	}
+	vOpts := []backend.VerifierOption{
+		backend.WithVerifierChallengeHashFunction(s.opt.ChallengeHash),
+		backend.WithVerifierKZGFoldingHashFunction(s.opt.KZGFoldingHash),
+	}
+	if s.opt.HashToFieldFn != nil {
+		vOpts = append(vOpts, backend.WithVerifierHashToFieldFunction(s.opt.HashToFieldFn))
+	}
+	if err := Verify(s.proof, s.pk.Vk, fw[:len(s.spr.Public)], vOpts...); err != nil {
+		return fmt.Errorf("proof self check: %w", err)
+	}
+	return nil
+}
+
 // represents a Prover instance
 type instance struct {
 	ctx context.Context
@@ -173,6 +305,10 @@ type instance struct {
 	proof *Proof
 	spr   *cs.SparseR1CS
 	opt   *backend.ProverConfig
+	acc   backend.Accelerator // nil if the prover runs on the CPU only
+
+	// number of parallel tasks of the FFTs and the multi-exponentiations
+	nbTasks int
 
 	fs             *fiatshamir.Transcript
 	kzgFoldingHash hash.Hash // for KZG folding
@@ -189,6 +325,11 @@ type instance struct {
 
 	fullWitness witness.Witness
 
+	// randomness sampled before the parallel steps start: the coefficients of
+	// the blinding polynomials followed by two blinding values per bsb22
+	// commitment
+	randomness []fr.Element
+
 	// bsb22 commitment stuff
 	commitmentInfo constraint.PlonkCommitments
 	commitmentVal  []fr.Element
@@ -208,9 +349,16 @@ type instance struct {
 	chLinearizedPolynomial,
 	chGammaBeta chan struct{}
 
+	// error of the restoration of the polynomials in canonical form, set
+	// before chRestoreLRO is closed
+	restoreErr error
+
 	domain0, domain1 *fft.Domain
 
 	trace *Trace
+
+	// completion of the steps, for progress reports
+	stages *stages.Recorder
 }
 
 func newInstance(ctx context.Context, spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts *backend.ProverConfig) (*instance, error) {
@@ -223,6 +371,8 @@ func newInstance(ctx context.Context, spr *cs.SparseR1CS, pk *ProvingKey, fullWi
 		proof:                  &Proof{},
 		spr:                    spr,
 		opt:                    opts,
+		acc:                    opts.AcceleratorEngine,
+		nbTasks:                opts.NbTasks,
 		fullWitness:            fullWitness,
 		bp:                     make([]*iop.Polynomial, nb_blinding_polynomials),
 		fs:                     fiatshamir.NewTranscript(opts.ChallengeHash, "gamma", "beta", "alpha", "zeta"),
@@ -238,21 +388,34 @@ func newInstance(ctx context.Context, spr *cs.SparseR1CS, pk *ProvingKey, fullWi
 		chLinearizedPolynomial: make(chan struct{}, 1),
 		chRestoreLRO:           make(chan struct{}, 1),
 	}
+	if s.nbTasks == 0 {
+		s.nbTasks = runtime.NumCPU()
+	}
 	s.initBSB22Commitments()
 	s.x = make([]*iop.Polynomial, id_Qci+2*len(s.commitmentInfo))
 
+	nbRandomness := nb_blinding_coefficients + 2*len(s.commitmentInfo)
+	if opts.NoBlinding {
+		// all blinding factors set to zero, the proof is not zero-knowledge
+		s.randomness = make([]fr.Element, nbRandomness)
+	} else {
+		var err error
+		if s.randomness, err = randomElements(opts.RandomSource, nbRandomness); err != nil {
+			return nil, err
+		}
+	}
+
 	// init fft domains
 	nbConstraints := spr.GetNbConstraints()
 	sizeSystem := uint64(nbConstraints + len(spr.Public)) // len(spr.Public) is for the placeholder constraints
 	s.domain0 = fft.NewDomain(sizeSystem)
 
-	// h, the quotient polynomial is of degree 3(n+1)+2, so it's in a 3(n+2) dim vector space,
-	// the domain is the next power of 2 superior to 3(n+2). 4*domainNum is enough in all cases
-	// except when n<6.
-	if sizeSystem < 6 {
-		s.domain1 = fft.NewDomain(8*sizeSystem, fft.WithoutPrecompute())
-	} else {
-		s.domain1 = fft.NewDomain(4*sizeSystem, fft.WithoutPrecompute())
+	s.domain1 = fft.NewDomain(quotientDomainSize(s.domain0.Cardinality), fft.WithoutPrecompute())
+
+	if opts.MemoryBudget != 0 {
+		if m := s.memoryEstimate(); m > opts.MemoryBudget {
+			return nil, fmt.Errorf("%w: proving needs about %d bytes, budget is %d", backend.ErrInsufficientMemoryBudget, m, opts.MemoryBudget)
+		}
 	}
 
 	// build trace
@@ -262,10 +425,14 @@ func newInstance(ctx context.Context, spr *cs.SparseR1CS, pk *ProvingKey, fullWi
 }
 
 func (s *instance) initBlindingPolynomials() error {
-	s.bp[id_Bl] = getRandomPolynomial(order_blinding_L)
-	s.bp[id_Br] = getRandomPolynomial(order_blinding_R)
-	s.bp[id_Bo] = getRandomPolynomial(order_blinding_O)
-	s.bp[id_Bz] = getRandomPolynomial(order_blinding_Z)
+	r := s.randomness
+	s.bp[id_Bl] = getRandomPolynomial(order_blinding_L, r)
+	r = r[order_blinding_L+1:]
+	s.bp[id_Br] = getRandomPolynomial(order_blinding_R, r)
+	r = r[order_blinding_R+1:]
+	s.bp[id_Bo] = getRandomPolynomial(order_blinding_O, r)
+	r = r[order_blinding_O+1:]
+	s.bp[id_Bz] = getRandomPolynomial(order_blinding_Z, r)
 	close(s.chbp)
 	return nil
 }
@@ -295,14 +462,11 @@ func (s *instance) bsb22Hint(_ *big.Int, ins, outs []*big.Int) error {
 	for i := range ins {
 		committedValues[offset+commitmentInfo.Committed[i]].SetBigInt(ins[i])
 	}
-	if _, err = committedValues[offset+commitmentInfo.CommitmentIndex].SetRandom(); err != nil { // Commitment injection constraint has qcp = 0. Safe to use for blinding.
-		return err
-	}
-	if _, err = committedValues[offset+s.spr.GetNbConstraints()-1].SetRandom(); err != nil { // Last constraint has qcp = 0. Safe to use for blinding
-		return err
-	}
+	blinding := s.randomness[nb_blinding_coefficients+2*commDepth:]
+	committedValues[offset+commitmentInfo.CommitmentIndex] = blinding[0] // Commitment injection constraint has qcp = 0. Safe to use for blinding.
+	committedValues[offset+s.spr.GetNbConstraints()-1] = blinding[1]     // Last constraint has qcp = 0. Safe to use for blinding
 	s.cCommitments[commDepth] = iop.NewPolynomial(&committedValues, iop.Form{Basis: iop.Lagrange, Layout: iop.Regular})
-	if s.proof.Bsb22Commitments[commDepth], err = kzg.Commit(s.cCommitments[commDepth].Coefficients(), s.pk.KzgLagrange); err != nil {
+	if s.proof.Bsb22Commitments[commDepth], err = kzgCommit(s.ctx, s.acc, s.cCommitments[commDepth].Coefficients(), s.pk.KzgLagrange, s.nbTasks); err != nil {
 		return err
 	}
 
@@ -450,7 +614,7 @@ func (s *instance) deriveGammaAndBeta() error {
 // /!\ The polynomial p is supposed to be in Lagrange form.
 func (s *instance) commitToPolyAndBlinding(p, b *iop.Polynomial) (commit curve.G1Affine, err error) {
 
-	commit, err = kzg.Commit(p.Coefficients(), s.pk.KzgLagrange)
+	commit, err = kzgCommit(s.ctx, s.acc, p.Coefficients(), s.pk.KzgLagrange, s.nbTasks)
 
 	// we add in the blinding contribution
 	n := int(s.domain0.Cardinality)
@@ -529,13 +693,13 @@ func (s *instance) computeQuotient() (err error) {
 		return err
 	}
 
-	s.h, err = divideByXMinusOne(numerator, [2]*fft.Domain{s.domain0, s.domain1})
+	s.h, err = divideByXMinusOne(s.ctx, s.acc, numerator, [2]*fft.Domain{s.domain0, s.domain1}, s.nbTasks)
 	if err != nil {
 		return err
 	}
 
 	// commit to h
-	if err := commitToQuotient(s.h1(), s.h2(), s.h3(), s.proof, s.pk.Kzg); err != nil {
+	if err := commitToQuotient(s.ctx, s.acc, s.h1(), s.h2(), s.h3(), s.proof, s.pk.Kzg, s.nbTasks); err != nil {
 		return err
 	}
 
@@ -549,6 +713,9 @@ func (s *instance) computeQuotient() (err error) {
 		return errContextDone
 	case <-s.chRestoreLRO:
 	}
+	if s.restoreErr != nil {
+		return s.restoreErr
+	}
 
 	close(s.chH)
 
@@ -601,7 +768,7 @@ func (s *instance) openZ() (err error) {
 	zetaShifted.Mul(&s.zeta, &s.pk.Vk.Generator)
 	s.blindedZ = getBlindedCoefficients(s.x[id_Z], s.bp[id_Bz])
 	// open z at zeta
-	s.proof.ZShiftedOpening, err = kzg.Open(s.blindedZ, zetaShifted, s.pk.Kzg)
+	s.proof.ZShiftedOpening, err = kzgOpen(s.ctx, s.acc, s.blindedZ, zetaShifted, s.pk.Kzg)
 	if err != nil {
 		return err
 	}
@@ -670,6 +837,11 @@ func (s *instance) computeLinearizedPolynomial() error {
 
 	wg.Wait()
 
+	if err := changeBasis(s.ctx, s.acc, s.trace.Qk, s.domain0, iop.Canonical, s.nbTasks); err != nil {
+		return err
+	}
+	s.trace.Qk.ToRegular()
+
 	s.linearizedPolynomial = s.innerComputeLinearizedPoly(
 		blzeta,
 		brzeta,
@@ -686,7 +858,7 @@ func (s *instance) computeLinearizedPolynomial() error {
 	)
 
 	var err error
-	s.linearizedPolynomialDigest, err = kzg.Commit(s.linearizedPolynomial, s.pk.Kzg, runtime.NumCPU()*2)
+	s.linearizedPolynomialDigest, err = kzgCommit(s.ctx, s.acc, s.linearizedPolynomial, s.pk.Kzg, s.nbTasks*2)
 	if err != nil {
 		return err
 	}
@@ -732,7 +904,9 @@ func (s *instance) batchOpening() error {
 	digestsToOpen[5] = s.pk.Vk.S[1]
 
 	var err error
-	s.proof.BatchedProof, err = kzg.BatchOpenSinglePoint(
+	s.proof.BatchedProof, err = kzgBatchOpenSinglePoint(
+		s.ctx,
+		s.acc,
 		polysToOpen,
 		digestsToOpen,
 		s.zeta,
@@ -921,10 +1095,17 @@ func (s *instance) computeNumerator() (*iop.Polynomial, error) {
 		// (Ql, Qr, Qm, Qo, S1, S2, S3, Qcp, Qc) and ID, LOne
 		// we could pre-compute theses rho*2 FFTs and store them
 		// at the cost of a huge memory footprint.
+		var fftErr error
+		var fftErrLock sync.Mutex
 		batchApply(s.x, func(p *iop.Polynomial) {
-			nbTasks := calculateNbTasks(len(s.x)-1) * 2
+			nbTasks := calculateNbTasks(s.nbTasks, len(s.x)-1) * 2
 			// shift polynomials to be in the correct coset
-			p.ToCanonical(s.domain0, nbTasks)
+			if err := changeBasis(s.ctx, s.acc, p, s.domain0, iop.Canonical, nbTasks); err != nil {
+				fftErrLock.Lock()
+				fftErr = err
+				fftErrLock.Unlock()
+				return
+			}
 
 			// scale by shifter[i]
 			var w []fr.Element
@@ -942,10 +1123,19 @@ func (s *instance) computeNumerator() (*iop.Polynomial, error) {
 			}, nbTasks)
 
 			// fft in the correct coset
-			p.ToLagrange(s.domain0, nbTasks).ToRegular()
+			if err := changeBasis(s.ctx, s.acc, p, s.domain0, iop.Lagrange, nbTasks); err != nil {
+				fftErrLock.Lock()
+				fftErr = err
+				fftErrLock.Unlock()
+				return
+			}
+			p.ToRegular()
 		})
 
 		wgBuf.Wait()
+		if fftErr != nil {
+			return nil, fftErr
+		}
 		if _, err := iop.Evaluate(
 			allConstraints,
 			buf,
@@ -987,11 +1177,18 @@ func (s *instance) computeNumerator() (*iop.Polynomial, error) {
 		}
 		cs.Inverse(&cs)
 
+		var restoreErrLock sync.Mutex
 		batchApply(s.x, func(p *iop.Polynomial) {
 			if p == nil {
 				return
 			}
-			p.ToCanonical(s.domain0, 8).ToRegular()
+			if err := changeBasis(s.ctx, s.acc, p, s.domain0, iop.Canonical, 8); err != nil {
+				restoreErrLock.Lock()
+				s.restoreErr = err
+				restoreErrLock.Unlock()
+				return
+			}
+			p.ToRegular()
 			scalePowers(p, cs)
 		})
 
@@ -1011,8 +1208,8 @@ func (s *instance) computeNumerator() (*iop.Polynomial, error) {
 
 }
 
-func calculateNbTasks(n int) int {
-	nbAvailableCPU := runtime.NumCPU() - n
+func calculateNbTasks(nbCPU, n int) int {
+	nbAvailableCPU := nbCPU - n
 	if nbAvailableCPU < 0 {
 		nbAvailableCPU = 1
 	}
@@ -1104,23 +1301,46 @@ func commitBlindingFactor(n int, b *iop.Polynomial, key kzg.ProvingKey) curve.G1
 	return res
 }
 
-// return a random polynomial of degree n, if n==-1 cancel the blinding
-func getRandomPolynomial(n int) *iop.Polynomial {
+// return a random polynomial of degree n with coefficients taken from random,
+// if n==-1 cancel the blinding
+func getRandomPolynomial(n int, random []fr.Element) *iop.Polynomial {
 	var a []fr.Element
 	if n == -1 {
 		a := make([]fr.Element, 1)
 		a[0].SetZero()
 	} else {
 		a = make([]fr.Element, n+1)
-		for i := 0; i <= n; i++ {
-			a[i].SetRandom()
-		}
+		copy(a, random[:n+1])
 	}
 	res := iop.NewPolynomial(&a, iop.Form{
 		Basis: iop.Canonical, Layout: iop.Regular})
 	return res
 }
 
+// randomElements returns n field elements sampled from src. If src is nil, the
+// elements are sampled from crypto/rand.
+func randomElements(src io.Reader, n int) ([]fr.Element, error) {
+	res := make([]fr.Element, n)
+	if src == nil {
+		for i := range res {
+			if _, err := res[i].SetRandom(); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+	// sample twice as many bytes as needed to make the modular bias negligible
+	var buf [2 * fr.Bytes]byte
+	var b big.Int
+	for i := range res {
+		if _, err := io.ReadFull(src, buf[:]); err != nil {
+			return nil, fmt.Errorf("read randomness: %w", err)
+		}
+		res[i].SetBigInt(b.SetBytes(buf[:]))
+	}
+	return res, nil
+}
+
 func coefficients(p []*iop.Polynomial) [][]fr.Element {
 	res := make([][]fr.Element, len(p))
 	for i, pI := range p {
@@ -1129,31 +1349,213 @@ func coefficients(p []*iop.Polynomial) [][]fr.Element {
 	return res
 }
 
-func commitToQuotient(h1, h2, h3 []fr.Element, proof *Proof, kzgPk kzg.ProvingKey) error {
+func commitToQuotient(ctx context.Context, acc backend.Accelerator, h1, h2, h3 []fr.Element, proof *Proof, kzgPk kzg.ProvingKey, nbTasks int) error {
 	g := new(errgroup.Group)
 
 	g.Go(func() (err error) {
-		proof.H[0], err = kzg.Commit(h1, kzgPk)
+		proof.H[0], err = kzgCommit(ctx, acc, h1, kzgPk, nbTasks)
 		return
 	})
 
 	g.Go(func() (err error) {
-		proof.H[1], err = kzg.Commit(h2, kzgPk)
+		proof.H[1], err = kzgCommit(ctx, acc, h2, kzgPk, nbTasks)
 		return
 	})
 
 	g.Go(func() (err error) {
-		proof.H[2], err = kzg.Commit(h3, kzgPk)
+		proof.H[2], err = kzgCommit(ctx, acc, h3, kzgPk, nbTasks)
 		return
 	})
 
 	return g.Wait()
 }
 
+// kzgCommit returns the KZG commitment of p, as kzg.Commit, offloading the
+// multi-exponentiation to the accelerator if it supports it. ctx is given to
+// the accelerator.
+func kzgCommit(ctx context.Context, acc backend.Accelerator, p []fr.Element, pk kzg.ProvingKey, nbTasks ...int) (kzg.Digest, error) {
+	if acc != nil && len(p) <= len(pk.G1) {
+		var res curve.G1Jac
+		err := acc.MultiExp(ctx, curve.ID, &res, pk.G1[:len(p)], p, backend.MultiExpConfig{})
+		if err == nil {
+			var digest kzg.Digest
+			digest.FromJacobian(&res)
+			return digest, nil
+		}
+		if !errors.Is(err, backend.ErrAcceleratorUnsupported) {
+			return kzg.Digest{}, err
+		}
+	}
+	return kzg.Commit(p, pk, nbTasks...)
+}
+
+// kzgOpen returns the KZG opening proof of p at point. It is kzg.Open, except
+// that the quotient is committed to on the accelerator if one is set.
+func kzgOpen(ctx context.Context, acc backend.Accelerator, p []fr.Element, point fr.Element, pk kzg.ProvingKey) (kzg.OpeningProof, error) {
+	if acc == nil {
+		return kzg.Open(p, point, pk)
+	}
+	if len(p) == 0 || len(p) > len(pk.G1) {
+		return kzg.OpeningProof{}, kzg.ErrInvalidPolynomialSize
+	}
+	res := kzg.OpeningProof{ClaimedValue: evaluate(p, point)}
+	q := make([]fr.Element, len(p))
+	copy(q, p)
+	var err error
+	res.H, err = kzgCommit(ctx, acc, divideByXMinusA(q, res.ClaimedValue, point), pk)
+	return res, err
+}
+
+// kzgBatchOpenSinglePoint returns the KZG batch opening proof of the
+// polynomials at point. It is kzg.BatchOpenSinglePoint, except that the
+// quotient is committed to on the accelerator if one is set. In that case the
+// folding challenge γ, which gnark-crypto doesn't expose, is derived here and
+// checked against kzg.FoldProof.
+func kzgBatchOpenSinglePoint(ctx context.Context, acc backend.Accelerator, polynomials [][]fr.Element, digests []kzg.Digest, point fr.Element, hf hash.Hash, pk kzg.ProvingKey, dataTranscript ...[]byte) (kzg.BatchOpeningProof, error) {
+	if acc == nil {
+		return kzg.BatchOpenSinglePoint(polynomials, digests, point, hf, pk, dataTranscript...)
+	}
+	if len(digests) != len(polynomials) {
+		return kzg.BatchOpeningProof{}, kzg.ErrInvalidNbDigests
+	}
+	largestPoly := 0
+	for _, p := range polynomials {
+		if len(p) == 0 || len(p) > len(pk.G1) {
+			return kzg.BatchOpeningProof{}, kzg.ErrInvalidPolynomialSize
+		}
+		largestPoly = max(largestPoly, len(p))
+	}
+
+	var res kzg.BatchOpeningProof
+	res.ClaimedValues = make([]fr.Element, len(polynomials))
+	var wg sync.WaitGroup
+	wg.Add(len(polynomials))
+	for i := range polynomials {
+		go func(i int) {
+			res.ClaimedValues[i] = evaluate(polynomials[i], point)
+			wg.Done()
+		}(i)
+	}
+	wg.Wait()
+
+	// derive the folding challenge γ as kzg.BatchOpenSinglePoint, bound to the
+	// point, the commitments and the claimed values
+	fs := fiatshamir.NewTranscript(hf, "gamma")
+	if err := fs.Bind("gamma", point.Marshal()); err != nil {
+		return kzg.BatchOpeningProof{}, err
+	}
+	for i := range digests {
+		if err := fs.Bind("gamma", digests[i].Marshal()); err != nil {
+			return kzg.BatchOpeningProof{}, err
+		}
+	}
+	for i := range res.ClaimedValues {
+		if err := fs.Bind("gamma", res.ClaimedValues[i].Marshal()); err != nil {
+			return kzg.BatchOpeningProof{}, err
+		}
+	}
+	for i := range dataTranscript {
+		if err := fs.Bind("gamma", dataTranscript[i]); err != nil {
+			return kzg.BatchOpeningProof{}, err
+		}
+	}
+	gammaBytes, err := fs.ComputeChallenge("gamma")
+	if err != nil {
+		return kzg.BatchOpeningProof{}, err
+	}
+	var gamma fr.Element
+	gamma.SetBytes(gammaBytes)
+
+	// ∑ᵢγⁱfᵢ and ∑ᵢγⁱfᵢ(a)
+	var foldedEvaluation fr.Element
+	for i := len(polynomials) - 1; i >= 0; i-- {
+		foldedEvaluation.Mul(&foldedEvaluation, &gamma).Add(&foldedEvaluation, &res.ClaimedValues[i])
+	}
+	folded := make([]fr.Element, largestPoly)
+	copy(folded, polynomials[0])
+	gammaI := gamma
+	for i := 1; i < len(polynomials); i++ {
+		p := polynomials[i]
+		utils.Parallelize(len(p), func(start, end int) {
+			var t fr.Element
+			for j := start; j < end; j++ {
+				t.Mul(&p[j], &gammaI)
+				folded[j].Add(&folded[j], &t)
+			}
+		})
+		gammaI.Mul(&gammaI, &gamma)
+	}
+
+	// the folding must match the one of the verifier
+	hf.Reset()
+	foldedProof, _, err := kzg.FoldProof(digests, &res, point, hf, dataTranscript...)
+	if err != nil {
+		return kzg.BatchOpeningProof{}, err
+	}
+	if !foldedProof.ClaimedValue.Equal(&foldedEvaluation) {
+		return kzg.BatchOpeningProof{}, errors.New("batch opening: folding challenge mismatch")
+	}
+
+	res.H, err = kzgCommit(ctx, acc, divideByXMinusA(folded, foldedEvaluation, point), pk)
+	return res, err
+}
+
+// evaluate returns p(x), p being in canonical basis.
+func evaluate(p []fr.Element, x fr.Element) fr.Element {
+	var res fr.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(&res, &x).Add(&res, &p[i])
+	}
+	return res
+}
+
+// divideByXMinusA returns (f-f(a))/(X-a), f being in canonical basis. The
+// memory of f is reused for the result.
+func divideByXMinusA(f []fr.Element, fa, a fr.Element) []fr.Element {
+	f[0].Sub(&f[0], &fa)
+	var t fr.Element
+	for i := len(f) - 2; i >= 0; i-- {
+		t.Mul(&f[i+1], &a)
+		f[i].Add(&f[i], &t)
+	}
+	return f[1:]
+}
+
+// changeBasis converts p to the canonical basis (from the Lagrange basis or the
+// Lagrange basis on the coset) or to the Lagrange basis (from the canonical
+// basis) on the domain, as p.ToCanonical and p.ToLagrange, offloading the FFT
+// to the accelerator if it supports it. ctx is given to the accelerator.
+func changeBasis(ctx context.Context, acc backend.Accelerator, p *iop.Polynomial, d *fft.Domain, to iop.Basis, nbTasks int) error {
+	offload := (to == iop.Canonical && p.Basis != iop.Canonical) || (to == iop.Lagrange && p.Basis == iop.Canonical)
+	if acc != nil && offload && uint64(len(p.Coefficients())) == d.Cardinality {
+		dif := p.Layout == iop.Regular
+		config := backend.FFTConfig{Inverse: to == iop.Canonical, DIF: dif, OnCoset: p.Basis == iop.LagrangeCoset}
+		err := acc.FFT(ctx, curve.ID, p.Coefficients(), d, config)
+		if err == nil {
+			p.Basis = to
+			if dif {
+				p.Layout = iop.BitReverse
+			} else {
+				p.Layout = iop.Regular
+			}
+			return nil
+		}
+		if !errors.Is(err, backend.ErrAcceleratorUnsupported) {
+			return err
+		}
+	}
+	if to == iop.Canonical {
+		p.ToCanonical(d, nbTasks)
+	} else {
+		p.ToLagrange(d, nbTasks)
+	}
+	return nil
+}
+
 // divideByXMinusOne
 // The input must be in LagrangeCoset.
 // The result is in Canonical Regular. (in place using a)
-func divideByXMinusOne(a *iop.Polynomial, domains [2]*fft.Domain) (*iop.Polynomial, error) {
+func divideByXMinusOne(ctx context.Context, acc backend.Accelerator, a *iop.Polynomial, domains [2]*fft.Domain, nbTasks int) (*iop.Polynomial, error) {
 
 	// check that the basis is LagrangeCoset
 	if a.Basis != iop.LagrangeCoset || a.Layout != iop.BitReverse {
@@ -1173,10 +1575,13 @@ func divideByXMinusOne(a *iop.Polynomial, domains [2]*fft.Domain) (*iop.Polynomi
 			iRev := bits.Reverse64(uint64(i)) >> nn
 			r[i].Mul(&r[i], &xnMinusOneInverseLagrangeCoset[int(iRev)%rho])
 		}
-	})
+	}, nbTasks)
 
 	// since a is in bit reverse order, ToRegular shouldn't do anything
-	a.ToCanonical(domains[1]).ToRegular()
+	if err := changeBasis(ctx, acc, a, domains[1], iop.Canonical, nbTasks); err != nil {
+		return nil, err
+	}
+	a.ToRegular()
 
 	return a, nil
 
@@ -1274,8 +1679,6 @@ func (s *instance) innerComputeLinearizedPoly(lZeta, rZeta, oZeta, alpha, beta,
 
 	s3canonical := s.trace.S3.Coefficients()
 
-	s.trace.Qk.ToCanonical(s.domain0).ToRegular()
-
 	// the hi are all of the same length
 	h1 := s.h1()
 	h2 := s.h2()
@@ -0,0 +1,201 @@
+// Package ptau_bn254 loads the KZG SRS of the BN254 PLONK backend from the
+// transcript of a public Powers of Tau ceremony, so that users don't have to
+// trust a locally generated SRS.
+//
+// Two formats are supported. [NewSRS] reads the uncompressed challenge file of
+// the Perpetual Powers of Tau ceremony (https://github.com/privacy-scaling-explorations/perpetualpowersoftau).
+// For a ceremony of power n it is laid out as
+//
+//	[64]byte      BLAKE2b hash of the previous response
+//	[2ⁿ⁺¹-1]G1    {[τ⁰]₁, [τ¹]₁, …}
+//	[2ⁿ]G2        {[τ⁰]₂, [τ¹]₂, …}
+//	[2ⁿ]G1        {α[τ⁰]₁, α[τ¹]₁, …}
+//	[2ⁿ]G1        {β[τ⁰]₁, β[τ¹]₁, …}
+//	G2            [β]₂
+//
+// where the points are uncompressed and big-endian encoded.
+//
+// [NewSRSFromIgnition] reads the transcript files of the Aztec Ignition
+// ceremony (https://github.com/AztecProtocol/ignition-verification). Each file
+// starts with a manifest of big-endian uint32 giving the number of the file and
+// its number of points, followed by a range of the powers [τⁱ]₁, i ≥ 1, and,
+// in the first file only, by [τ]₂.
+//
+// Only the powers of τ in G1 and the first two powers of τ in G2 are needed for
+// the KZG SRS.
+package ptau_bn254
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/consensys/gnark/backend/internal/ptau"
+)
+
+// ErrInvalidTranscript is returned when the points read from the transcript are
+// not the powers of τ of a valid ceremony.
+var ErrInvalidTranscript = ptau.ErrInvalidTranscript
+
+// NewSRS reads the challenge file of a Powers of Tau ceremony of the given
+// power and returns the canonical and Lagrange forms of the KZG SRS, of the
+// given sizes. The sizes needed for a constraint system are given by
+// plonk.SRSSize.
+//
+// The points are checked to be on the curve and in the correct subgroup, and
+// to be the consecutive powers of the same τ.
+func NewSRS(r io.ReadSeeker, power uint8, sizeCanonical, sizeLagrange int) (canonical, lagrange *kzg.SRS, err error) {
+	layout, err := ptau.NewLayout(power)
+	if err != nil {
+		return nil, nil, err
+	}
+	nbG1 := int64(1)<<(power+1) - 1
+	if sizeCanonical < 2 || int64(sizeCanonical) > nbG1 {
+		return nil, nil, fmt.Errorf("canonical SRS size %d out of range [2, %d] for a ceremony of power %d", sizeCanonical, nbG1, power)
+	}
+	if bits.OnesCount(uint(sizeLagrange)) != 1 || sizeLagrange > sizeCanonical {
+		return nil, nil, fmt.Errorf("lagrange SRS size %d must be a power of 2 smaller than the canonical size", sizeLagrange)
+	}
+
+	canonical = new(kzg.SRS)
+
+	// [τⁱ]₁
+	canonical.Pk.G1 = make([]curve.G1Affine, sizeCanonical)
+	if err = ptau.ReadG1(r, layout.TauG1, canonical.Pk.G1); err != nil {
+		return nil, nil, fmt.Errorf("read τ powers in G1: %w", err)
+	}
+
+	// [τ⁰]₂, [τ¹]₂
+	if err = ptau.ReadG2(r, layout.TauG2, canonical.Vk.G2[:]); err != nil {
+		return nil, nil, fmt.Errorf("read τ powers in G2: %w", err)
+	}
+
+	return finalize(canonical, sizeLagrange)
+}
+
+// NewSRSFromIgnition reads the transcript files of the Aztec Ignition ceremony,
+// given in order, and returns the canonical and Lagrange forms of the KZG SRS,
+// of the given sizes. The sizes needed for a constraint system are given by
+// plonk.SRSSize. Only the files holding the sizeCanonical-1 first powers of τ
+// are read.
+//
+// The points are checked to be on the curve and in the correct subgroup, and
+// to be the consecutive powers of the same τ.
+func NewSRSFromIgnition(transcripts []io.ReadSeeker, sizeCanonical, sizeLagrange int) (canonical, lagrange *kzg.SRS, err error) {
+	if sizeCanonical < 2 {
+		return nil, nil, fmt.Errorf("canonical SRS size %d smaller than 2", sizeCanonical)
+	}
+	if bits.OnesCount(uint(sizeLagrange)) != 1 || sizeLagrange > sizeCanonical {
+		return nil, nil, fmt.Errorf("lagrange SRS size %d must be a power of 2 smaller than the canonical size", sizeLagrange)
+	}
+
+	canonical = new(kzg.SRS)
+	_, _, g1, g2 := curve.Generators()
+
+	// [τ⁰]₁ is not in the transcript
+	canonical.Pk.G1 = make([]curve.G1Affine, sizeCanonical)
+	canonical.Pk.G1[0] = g1
+	canonical.Vk.G2[0] = g2
+	read := 1
+	for i := 0; i < len(transcripts) && read < sizeCanonical; i++ {
+		m, err := ptau.ReadIgnitionManifest(transcripts[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("transcript %d: read manifest: %w", i, err)
+		}
+		if m.TranscriptNumber != uint32(i) || m.StartFrom != uint32(read-1) {
+			return nil, nil, fmt.Errorf("transcript %d: manifest of transcript %d starting from %d, want %d", i, m.TranscriptNumber, m.StartFrom, read-1)
+		}
+		n := min(int(m.NumG1Points), sizeCanonical-read)
+
+		// [τⁱ]₁
+		if err = ptau.ReadIgnitionG1(transcripts[i], m.G1Offset(), canonical.Pk.G1[read:read+n]); err != nil {
+			return nil, nil, fmt.Errorf("transcript %d: read τ powers in G1: %w", i, err)
+		}
+		read += n
+
+		// [τ]₂
+		if i == 0 {
+			if m.NumG2Points == 0 {
+				return nil, nil, fmt.Errorf("transcript 0: %w: no G2 point", ErrInvalidTranscript)
+			}
+			if err = ptau.ReadIgnitionG2(transcripts[i], m.G2Offset(), canonical.Vk.G2[1:]); err != nil {
+				return nil, nil, fmt.Errorf("transcript 0: read τ in G2: %w", err)
+			}
+		}
+	}
+	if read < sizeCanonical {
+		return nil, nil, fmt.Errorf("the transcripts hold %d powers of τ, want %d", read, sizeCanonical)
+	}
+
+	return finalize(canonical, sizeLagrange)
+}
+
+// finalize checks the powers of τ of the canonical SRS read from a transcript,
+// completes its verifying key, and returns it with the Lagrange SRS of the
+// given size.
+func finalize(canonical *kzg.SRS, sizeLagrange int) (_, lagrange *kzg.SRS, err error) {
+	if err = checkPowers(canonical); err != nil {
+		return nil, nil, err
+	}
+	canonical.Vk.G1 = canonical.Pk.G1[0]
+	canonical.Vk.Lines[0] = curve.PrecomputeLines(canonical.Vk.G2[0])
+	canonical.Vk.Lines[1] = curve.PrecomputeLines(canonical.Vk.G2[1])
+
+	lagrange = &kzg.SRS{Vk: canonical.Vk}
+	lagrange.Pk.G1 = make([]curve.G1Affine, sizeLagrange)
+	copy(lagrange.Pk.G1, canonical.Pk.G1)
+	if lagrange.Pk.G1, err = kzg.ToLagrangeG1(lagrange.Pk.G1); err != nil {
+		return nil, nil, err
+	}
+
+	return canonical, lagrange, nil
+}
+
+// checkPowers checks that the SRS starts with the generators and that its
+// points are consecutive powers of the same τ. The latter is checked on a
+// random linear combination of the G1 points:
+//
+//	e(∑ρⁱ[τⁱ]₁, [τ]₂) = e(∑ρⁱ[τⁱ⁺¹]₁, [1]₂)
+func checkPowers(srs *kzg.SRS) error {
+	_, _, g1, g2 := curve.Generators()
+	if !srs.Pk.G1[0].Equal(&g1) || !srs.Vk.G2[0].Equal(&g2) {
+		return fmt.Errorf("%w: first powers are not the generators", ErrInvalidTranscript)
+	}
+	if srs.Vk.G2[1].IsInfinity() {
+		return fmt.Errorf("%w: τ is zero", ErrInvalidTranscript)
+	}
+
+	n := len(srs.Pk.G1) - 1
+	var rho fr.Element
+	if _, err := rho.SetRandom(); err != nil {
+		return err
+	}
+	rhos := make([]fr.Element, n)
+	rhos[0].SetOne()
+	for i := 1; i < n; i++ {
+		rhos[i].Mul(&rhos[i-1], &rho)
+	}
+
+	var left, right curve.G1Affine
+	config := ecc.MultiExpConfig{}
+	if _, err := left.MultiExp(srs.Pk.G1[:n], rhos, config); err != nil {
+		return err
+	}
+	if _, err := right.MultiExp(srs.Pk.G1[1:], rhos, config); err != nil {
+		return err
+	}
+	right.Neg(&right)
+
+	ok, err := curve.PairingCheck([]curve.G1Affine{left, right}, []curve.G2Affine{srs.Vk.G2[1], g2})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: points are not consecutive powers of τ", ErrInvalidTranscript)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+//go:build !verifier_only
+
+package ptau_bn254_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark/backend/internal/ptau"
+	"github.com/consensys/gnark/backend/plonk"
+	ptau_bn254 "github.com/consensys/gnark/backend/plonk/bn254/ptau"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test"
+)
+
+type circuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *circuit) Define(api frontend.API) error {
+	x := c.X
+	for i := 0; i < 10; i++ {
+		x = api.Mul(x, x)
+	}
+	api.AssertIsEqual(x, c.Y)
+	return nil
+}
+
+func TestNewSRS(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &circuit{})
+	assert.NoError(err)
+	sizeCanonical, sizeLagrange := plonk.SRSSize(ccs)
+
+	const power = 5
+	file := ptau.ChallengeFile(power, big.NewInt(42), big.NewInt(5), big.NewInt(7))
+
+	canonical, lagrange, err := ptau_bn254.NewSRS(bytes.NewReader(file), power, sizeCanonical, sizeLagrange)
+	assert.NoError(err)
+	assert.Equal(sizeCanonical, len(canonical.Pk.G1))
+	assert.Equal(sizeLagrange, len(lagrange.Pk.G1))
+
+	pk, vk, err := plonk.Setup(ccs, canonical, lagrange)
+	assert.NoError(err)
+	y := new(big.Int).Exp(big.NewInt(3), new(big.Int).Lsh(big.NewInt(1), 10), ecc.BN254.ScalarField())
+	witness, err := frontend.NewWitness(&circuit{X: 3, Y: y}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicWitness, err := witness.Public()
+	assert.NoError(err)
+	proof, err := plonk.Prove(ccs, pk, witness)
+	assert.NoError(err)
+	assert.NoError(plonk.Verify(proof, vk, publicWitness))
+
+	// the ceremony is too small
+	_, _, err = ptau_bn254.NewSRS(bytes.NewReader(file), 2, sizeCanonical, sizeLagrange)
+	assert.Error(err)
+
+	// the points are not consecutive powers of τ
+	pointSize := curve.SizeOfG1AffineUncompressed
+	tampered := bytes.Clone(file)
+	copy(tampered[ptau.HashSize+3*pointSize:ptau.HashSize+4*pointSize], file[ptau.HashSize+2*pointSize:ptau.HashSize+3*pointSize])
+	_, _, err = ptau_bn254.NewSRS(bytes.NewReader(tampered), power, sizeCanonical, sizeLagrange)
+	assert.True(errors.Is(err, ptau_bn254.ErrInvalidTranscript), "unexpected error: %v", err)
+}
+
+func TestNewSRSFromIgnition(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &circuit{})
+	assert.NoError(err)
+	sizeCanonical, sizeLagrange := plonk.SRSSize(ccs)
+
+	// the powers of τ span several transcripts
+	const nbPoints = 8
+	files := ptau.IgnitionTranscripts((sizeCanonical+nbPoints-1)/nbPoints, nbPoints, big.NewInt(42))
+	transcripts := func(files [][]byte) []io.ReadSeeker {
+		res := make([]io.ReadSeeker, len(files))
+		for i := range files {
+			res[i] = bytes.NewReader(files[i])
+		}
+		return res
+	}
+
+	canonical, lagrange, err := ptau_bn254.NewSRSFromIgnition(transcripts(files), sizeCanonical, sizeLagrange)
+	assert.NoError(err)
+	assert.Equal(sizeCanonical, len(canonical.Pk.G1))
+	assert.Equal(sizeLagrange, len(lagrange.Pk.G1))
+
+	pk, vk, err := plonk.Setup(ccs, canonical, lagrange)
+	assert.NoError(err)
+	y := new(big.Int).Exp(big.NewInt(3), new(big.Int).Lsh(big.NewInt(1), 10), ecc.BN254.ScalarField())
+	witness, err := frontend.NewWitness(&circuit{X: 3, Y: y}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicWitness, err := witness.Public()
+	assert.NoError(err)
+	proof, err := plonk.Prove(ccs, pk, witness)
+	assert.NoError(err)
+	assert.NoError(plonk.Verify(proof, vk, publicWitness))
+
+	// the SRS is the one of the Perpetual Powers of Tau ceremony with the same τ
+	file := ptau.ChallengeFile(5, big.NewInt(42), big.NewInt(5), big.NewInt(7))
+	expected, _, err := ptau_bn254.NewSRS(bytes.NewReader(file), 5, sizeCanonical, sizeLagrange)
+	assert.NoError(err)
+	assert.Equal(expected.Pk.G1, canonical.Pk.G1)
+	assert.Equal(expected.Vk.G2, canonical.Vk.G2)
+
+	// missing and swapped transcripts
+	_, _, err = ptau_bn254.NewSRSFromIgnition(transcripts(files[:1]), sizeCanonical, sizeLagrange)
+	assert.Error(err)
+	swapped := append([][]byte{files[1], files[0]}, files[2:]...)
+	_, _, err = ptau_bn254.NewSRSFromIgnition(transcripts(swapped), sizeCanonical, sizeLagrange)
+	assert.Error(err)
+
+	// the points are not consecutive powers of τ
+	pointSize := 2 * fp.Bytes
+	tampered := bytes.Clone(files[0])
+	copy(tampered[ptau.IgnitionManifestSize+3*pointSize:ptau.IgnitionManifestSize+4*pointSize], files[0][ptau.IgnitionManifestSize+2*pointSize:ptau.IgnitionManifestSize+3*pointSize])
+	_, _, err = ptau_bn254.NewSRSFromIgnition(transcripts(append([][]byte{tampered}, files[1:]...)), sizeCanonical, sizeLagrange)
+	assert.True(errors.Is(err, ptau_bn254.ErrInvalidTranscript), "unexpected error: %v", err)
+}
@@ -0,0 +1,102 @@
+// Package permutation builds the copy constraints of PLONK-like
+// arithmetizations.
+//
+// The wires of the constraint system are laid out in a table of columns (for
+// vanilla PLONK the columns are l, r and o) and a copy constraint states that
+// two positions of the table hold the same wire. All copy constraints are
+// encoded by a single permutation of the positions of the table whose cycles
+// are exactly the sets of positions holding the same wire. The permutation is
+// independent of the field and the number of columns, so it can be shared
+// between the backends.
+package permutation
+
+import (
+	"fmt"
+)
+
+// Build builds the permutation associated with the wires of the table.
+//
+// The input wires is the concatenation of the columns of the table, i.e. the
+// i-th entry of wires is the ID of the wire at position i. The wire IDs must be
+// in the range [0, nbWires).
+//
+// The permutation s is composed of cycles of maximum length such that
+//
+//	s.wires = wires
+//
+// It is encoded as a slice of the same size as wires, where the position i is
+// sent to the position s[i], so it acts on a table like this: for i in table:
+// table[i] = table[s[i]].
+func Build(wires []int, nbWires int) []int64 {
+	permutation := make([]int64, len(wires))
+	for i := range permutation {
+		permutation[i] = -1
+	}
+
+	// map wire ID -> last position the wire was seen
+	cycle := make([]int64, nbWires)
+	for i := range cycle {
+		cycle[i] = -1
+	}
+
+	for i := range wires {
+		if cycle[wires[i]] != -1 {
+			// if != -1, it means we already encountered this wire
+			// so we need to set the corresponding permutation index.
+			permutation[i] = cycle[wires[i]]
+		}
+		cycle[wires[i]] = int64(i)
+	}
+
+	// complete the permutation by closing the cycles at the first positions
+	// the wires were encountered
+	for i := range permutation {
+		if permutation[i] == -1 {
+			permutation[i] = cycle[wires[i]]
+		}
+	}
+
+	return permutation
+}
+
+// Check checks that permutation encodes the copy constraints of the table
+// wires, i.e. that it is a permutation of the positions and that its cycles are
+// exactly the sets of positions holding the same wire.
+func Check(wires []int, permutation []int64) error {
+	if len(wires) != len(permutation) {
+		return fmt.Errorf("permutation size %d doesn't match the number of positions %d", len(permutation), len(wires))
+	}
+
+	// the permutation must be a bijection only mapping positions holding the
+	// same wire
+	hit := make([]bool, len(permutation))
+	for i := range permutation {
+		if permutation[i] < 0 || permutation[i] >= int64(len(wires)) {
+			return fmt.Errorf("position %d is sent out of range to %d", i, permutation[i])
+		}
+		if hit[permutation[i]] {
+			return fmt.Errorf("position %d is the image of several positions", permutation[i])
+		}
+		hit[permutation[i]] = true
+		if wires[permutation[i]] != wires[i] {
+			return fmt.Errorf("position %d with wire %d is sent to position %d with wire %d", i, wires[i], permutation[i], wires[permutation[i]])
+		}
+	}
+
+	// walk the cycles and check that every wire is in a single cycle
+	visited := make([]bool, len(permutation))
+	seen := make(map[int]struct{})
+	for i := range permutation {
+		if visited[i] {
+			continue
+		}
+		if _, ok := seen[wires[i]]; ok {
+			return fmt.Errorf("wire %d is split over several cycles", wires[i])
+		}
+		seen[wires[i]] = struct{}{}
+		for j := int64(i); !visited[j]; j = permutation[j] {
+			visited[j] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package permutation
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	// columns l, r, o of size 4
+	wires := []int{
+		0, 1, 2, 0,
+		1, 3, 3, 4,
+		2, 4, 0, 5,
+	}
+	permutation := Build(wires, 6)
+	expected := []int64{
+		10, 4, 8, 0,
+		1, 6, 5, 9,
+		2, 7, 3, 11,
+	}
+	if len(permutation) != len(expected) {
+		t.Fatalf("permutation size %d, expected %d", len(permutation), len(expected))
+	}
+	for i := range expected {
+		if permutation[i] != expected[i] {
+			t.Fatalf("permutation[%d] = %d, expected %d", i, permutation[i], expected[i])
+		}
+	}
+	if err := Check(wires, permutation); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuildRandom(t *testing.T) {
+	const nbWires = 50
+	const size = 3 * 64
+	wires := make([]int, size)
+	for i := range wires {
+		wires[i] = rand.Intn(nbWires) //#nosec G404 weak rng is fine here
+	}
+	permutation := Build(wires, nbWires)
+	if err := Check(wires, permutation); err != nil {
+		t.Fatal(err)
+	}
+
+	// swapping the images of two positions holding different wires breaks the
+	// copy constraints
+	for i := 1; i < size; i++ {
+		if wires[i] != wires[0] {
+			permutation[0], permutation[i] = permutation[i], permutation[0]
+			break
+		}
+	}
+	if err := Check(wires, permutation); err == nil {
+		t.Fatal("expected error for permutation mixing wires")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	wires := []int{0, 0, 0, 1}
+	for _, tc := range []struct {
+		name        string
+		permutation []int64
+		valid       bool
+	}{
+		{"valid", []int64{2, 0, 1, 3}, true},
+		{"wrong size", []int64{2, 0, 1}, false},
+		{"out of range", []int64{2, 0, 4, 3}, false},
+		{"not a bijection", []int64{1, 0, 1, 3}, false},
+		{"mixing wires", []int64{3, 0, 1, 2}, false},
+		{"split cycle", []int64{1, 0, 2, 3}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Check(wires, tc.permutation)
+			if tc.valid && err != nil {
+				t.Fatal(err)
+			}
+			if !tc.valid && err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
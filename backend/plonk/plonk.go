@@ -14,27 +14,31 @@
 
 // Package plonk implements PLONK Zero Knowledge Proof system.
 //
+// # Verifier-only builds
+//
+// Building with the `verifier_only` build tag excludes Setup, Prove and the
+// (de)serialization helpers of the proving keys and constraint systems from the
+// package, so that verifier applications cannot depend on them by accident.
+//
+// The prover and the setup of the curve specific packages, as well as the solver
+// options of [backend.ProverConfig], are excluded too, so that the constraint
+// systems, the solver and the prover computations are not linked in the
+// verifier binary. The curve specific ProvingKey types remain, as they only
+// hold the KZG keys and the verifying key.
+//
 // # See also
 //
 // https://eprint.iacr.org/2019/953
 package plonk
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/consensys/gnark-crypto/ecc"
-	"github.com/consensys/gnark-crypto/kzg"
 	"github.com/consensys/gnark/backend"
-	"github.com/consensys/gnark/constraint"
 
 	"github.com/consensys/gnark/backend/witness"
-	cs_bls12377 "github.com/consensys/gnark/constraint/bls12-377"
-	cs_bls12381 "github.com/consensys/gnark/constraint/bls12-381"
-	cs_bls24315 "github.com/consensys/gnark/constraint/bls24-315"
-	cs_bls24317 "github.com/consensys/gnark/constraint/bls24-317"
-	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
-	cs_bw6633 "github.com/consensys/gnark/constraint/bw6-633"
-	cs_bw6761 "github.com/consensys/gnark/constraint/bw6-761"
 
 	plonk_bls12377 "github.com/consensys/gnark/backend/plonk/bls12-377"
 	plonk_bls12381 "github.com/consensys/gnark/backend/plonk/bls12-381"
@@ -52,162 +56,106 @@ import (
 	fr_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
 	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
 
-	kzg_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
-	kzg_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
-	kzg_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/kzg"
-	kzg_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/kzg"
-	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
-	kzg_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/kzg"
-	kzg_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/kzg"
-
 	gnarkio "github.com/consensys/gnark/io"
 )
 
+type plonkObject interface {
+	gnarkio.WriterRawTo
+	io.WriterTo
+	io.ReaderFrom
+	CurveID() ecc.ID
+}
+
 // Proof represents a Plonk proof generated by plonk.Prove
 //
 // it's underlying implementation is curve specific (see gnark/internal/backend)
+//
+// Besides the binary encoding of WriteTo and ReadFrom, a proof can be encoded
+// with encoding/json; the coordinates of the points and the claimed values are
+// then encoded in base 10, as strings when they exceed 15 digits. The proof to
+// decode into is obtained with NewProof.
 type Proof interface {
-	io.WriterTo
-	io.ReaderFrom
-	gnarkio.WriterRawTo
+	plonkObject
 }
 
 // ProvingKey represents a plonk ProvingKey
 //
 // it's underlying implementation is strongly typed with the curve (see gnark/internal/backend)
 type ProvingKey interface {
-	io.WriterTo
-	io.ReaderFrom
-	gnarkio.WriterRawTo
+	plonkObject
 	gnarkio.UnsafeReaderFrom
 	VerifyingKey() interface{}
+
+	// EstimateResources returns an estimate of the memory, the
+	// multi-exponentiations, the FFTs and the time needed to prove with the
+	// ProvingKey.
+	EstimateResources() backend.ResourceEstimate
 }
 
 // VerifyingKey represents a plonk VerifyingKey
 //
 // it's underlying implementation is strongly typed with the curve (see gnark/internal/backend)
 type VerifyingKey interface {
-	io.WriterTo
-	io.ReaderFrom
-	gnarkio.WriterRawTo
+	plonkObject
 	gnarkio.UnsafeReaderFrom
 	NbPublicWitness() int // number of elements expected in the public witness
 	ExportSolidity(w io.Writer) error
 }
 
-// Setup prepares the public data associated to a circuit + public inputs.
-// The kzg SRS must be provided in canonical and lagrange form.
-// For test purposes, see test/unsafekzg package. With an existing SRS generated through MPC in canonical form,
-// gnark-crypto offers the ToLagrangeG1 method to convert it to lagrange form.
-func Setup(ccs constraint.ConstraintSystem, srs, srsLagrange kzg.SRS) (ProvingKey, VerifyingKey, error) {
-
-	switch tccs := ccs.(type) {
-	case *cs_bn254.SparseR1CS:
-		return plonk_bn254.Setup(tccs, *srs.(*kzg_bn254.SRS), *srsLagrange.(*kzg_bn254.SRS))
-	case *cs_bls12381.SparseR1CS:
-		return plonk_bls12381.Setup(tccs, *srs.(*kzg_bls12381.SRS), *srsLagrange.(*kzg_bls12381.SRS))
-	case *cs_bls12377.SparseR1CS:
-		return plonk_bls12377.Setup(tccs, *srs.(*kzg_bls12377.SRS), *srsLagrange.(*kzg_bls12377.SRS))
-	case *cs_bw6761.SparseR1CS:
-		return plonk_bw6761.Setup(tccs, *srs.(*kzg_bw6761.SRS), *srsLagrange.(*kzg_bw6761.SRS))
-	case *cs_bls24317.SparseR1CS:
-		return plonk_bls24317.Setup(tccs, *srs.(*kzg_bls24317.SRS), *srsLagrange.(*kzg_bls24317.SRS))
-	case *cs_bls24315.SparseR1CS:
-		return plonk_bls24315.Setup(tccs, *srs.(*kzg_bls24315.SRS), *srsLagrange.(*kzg_bls24315.SRS))
-	case *cs_bw6633.SparseR1CS:
-		return plonk_bw6633.Setup(tccs, *srs.(*kzg_bw6633.SRS), *srsLagrange.(*kzg_bw6633.SRS))
-	default:
-		panic("unrecognized SparseR1CS curve type")
-	}
-
-}
-
-// Prove generates PLONK proof from a circuit, associated preprocessed public data, and the witness
-// if the force flag is set:
-//
-//		will execute all the prover computations, even if the witness is invalid
-//	 will produce an invalid proof
-//		internally, the solution vector to the SparseR1CS will be filled with random values which may impact benchmarking
-func Prove(ccs constraint.ConstraintSystem, pk ProvingKey, fullWitness witness.Witness, opts ...backend.ProverOption) (Proof, error) {
-
-	switch tccs := ccs.(type) {
-	case *cs_bn254.SparseR1CS:
-		return plonk_bn254.Prove(tccs, pk.(*plonk_bn254.ProvingKey), fullWitness, opts...)
-
-	case *cs_bls12381.SparseR1CS:
-		return plonk_bls12381.Prove(tccs, pk.(*plonk_bls12381.ProvingKey), fullWitness, opts...)
-
-	case *cs_bls12377.SparseR1CS:
-		return plonk_bls12377.Prove(tccs, pk.(*plonk_bls12377.ProvingKey), fullWitness, opts...)
-
-	case *cs_bw6761.SparseR1CS:
-		return plonk_bw6761.Prove(tccs, pk.(*plonk_bw6761.ProvingKey), fullWitness, opts...)
-
-	case *cs_bw6633.SparseR1CS:
-		return plonk_bw6633.Prove(tccs, pk.(*plonk_bw6633.ProvingKey), fullWitness, opts...)
-
-	case *cs_bls24317.SparseR1CS:
-		return plonk_bls24317.Prove(tccs, pk.(*plonk_bls24317.ProvingKey), fullWitness, opts...)
-
-	case *cs_bls24315.SparseR1CS:
-		return plonk_bls24315.Prove(tccs, pk.(*plonk_bls24315.ProvingKey), fullWitness, opts...)
-
-	default:
-		panic("unrecognized SparseR1CS curve type")
-	}
-}
-
 // Verify verifies a PLONK proof, from the proof, preprocessed public data, and public witness.
 func Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ...backend.VerifierOption) error {
+	if err := checkCurve("verifying key", vk.CurveID(), proof.CurveID()); err != nil {
+		return err
+	}
 
 	switch _proof := proof.(type) {
 
 	case *plonk_bn254.Proof:
 		w, ok := publicWitness.Vector().(fr_bn254.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return plonk_bn254.Verify(_proof, vk.(*plonk_bn254.VerifyingKey), w, opts...)
 
 	case *plonk_bls12381.Proof:
 		w, ok := publicWitness.Vector().(fr_bls12381.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return plonk_bls12381.Verify(_proof, vk.(*plonk_bls12381.VerifyingKey), w, opts...)
 
 	case *plonk_bls12377.Proof:
 		w, ok := publicWitness.Vector().(fr_bls12377.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return plonk_bls12377.Verify(_proof, vk.(*plonk_bls12377.VerifyingKey), w, opts...)
 
 	case *plonk_bw6761.Proof:
 		w, ok := publicWitness.Vector().(fr_bw6761.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return plonk_bw6761.Verify(_proof, vk.(*plonk_bw6761.VerifyingKey), w, opts...)
 
 	case *plonk_bw6633.Proof:
 		w, ok := publicWitness.Vector().(fr_bw6633.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return plonk_bw6633.Verify(_proof, vk.(*plonk_bw6633.VerifyingKey), w, opts...)
 
 	case *plonk_bls24317.Proof:
 		w, ok := publicWitness.Vector().(fr_bls24317.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return plonk_bls24317.Verify(_proof, vk.(*plonk_bls24317.VerifyingKey), w, opts...)
 
 	case *plonk_bls24315.Proof:
 		w, ok := publicWitness.Vector().(fr_bls24315.Vector)
 		if !ok {
-			return witness.ErrInvalidWitness
+			return errWitnessCurveMismatch
 		}
 		return plonk_bls24315.Verify(_proof, vk.(*plonk_bls24315.VerifyingKey), w, opts...)
 
@@ -216,57 +164,6 @@ func Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ..
 	}
 }
 
-// NewCS instantiate a concrete curved-typed SparseR1CS and return a ConstraintSystem interface
-// This method exists for (de)serialization purposes
-func NewCS(curveID ecc.ID) constraint.ConstraintSystem {
-	var r1cs constraint.ConstraintSystem
-	switch curveID {
-	case ecc.BN254:
-		r1cs = &cs_bn254.SparseR1CS{}
-	case ecc.BLS12_377:
-		r1cs = &cs_bls12377.SparseR1CS{}
-	case ecc.BLS12_381:
-		r1cs = &cs_bls12381.SparseR1CS{}
-	case ecc.BW6_761:
-		r1cs = &cs_bw6761.SparseR1CS{}
-	case ecc.BLS24_317:
-		r1cs = &cs_bls24317.SparseR1CS{}
-	case ecc.BLS24_315:
-		r1cs = &cs_bls24315.SparseR1CS{}
-	case ecc.BW6_633:
-		r1cs = &cs_bw6633.SparseR1CS{}
-	default:
-		panic("not implemented")
-	}
-	return r1cs
-}
-
-// NewProvingKey instantiates a curve-typed ProvingKey and returns an interface
-// This function exists for serialization purposes
-func NewProvingKey(curveID ecc.ID) ProvingKey {
-	var pk ProvingKey
-	switch curveID {
-	case ecc.BN254:
-		pk = &plonk_bn254.ProvingKey{}
-	case ecc.BLS12_377:
-		pk = &plonk_bls12377.ProvingKey{}
-	case ecc.BLS12_381:
-		pk = &plonk_bls12381.ProvingKey{}
-	case ecc.BW6_761:
-		pk = &plonk_bw6761.ProvingKey{}
-	case ecc.BLS24_317:
-		pk = &plonk_bls24317.ProvingKey{}
-	case ecc.BLS24_315:
-		pk = &plonk_bls24315.ProvingKey{}
-	case ecc.BW6_633:
-		pk = &plonk_bw6633.ProvingKey{}
-	default:
-		panic("not implemented")
-	}
-
-	return pk
-}
-
 // NewProof instantiates a curve-typed ProvingKey and returns an interface
 // This function exists for serialization purposes
 func NewProof(curveID ecc.ID) Proof {
@@ -319,15 +216,15 @@ func NewVerifyingKey(curveID ecc.ID) VerifyingKey {
 	return vk
 }
 
-// SRSSize returns the required size of the kzg SRS for a given constraint system
-// Note that the SRS size in Lagrange form is a power of 2,
-// and the SRS size in canonical form need few extra elements (3) to account for the blinding factors
-func SRSSize(ccs constraint.ConstraintSystem) (sizeCanonical, sizeLagrange int) {
-	nbConstraints := ccs.GetNbConstraints()
-	sizeSystem := nbConstraints + ccs.GetNbPublicVariables()
+// errWitnessCurveMismatch is returned when the witness is not defined over the
+// curve of the other objects.
+var errWitnessCurveMismatch = fmt.Errorf("%w: %w", backend.ErrCurveMismatch, witness.ErrInvalidWitness)
 
-	sizeLagrange = int(ecc.NextPowerOfTwo(uint64(sizeSystem)))
-	sizeCanonical = sizeLagrange + 3
-
-	return
+// checkCurve returns an error wrapping [backend.ErrCurveMismatch] if the curve
+// of the named object is not the expected one.
+func checkCurve(name string, curve, expected ecc.ID) error {
+	if curve != expected {
+		return fmt.Errorf("%w: %s is defined over %s, expected %s", backend.ErrCurveMismatch, name, curve, expected)
+	}
+	return nil
 }
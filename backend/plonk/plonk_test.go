@@ -1,17 +1,29 @@
+//go:build !verifier_only
+
 package plonk_test
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"math/rand"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/consensys/gnark"
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
 	"github.com/consensys/gnark-crypto/kzg"
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/plonk"
 	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/scs"
 	"github.com/consensys/gnark/test"
@@ -39,6 +51,8 @@ func TestProver(t *testing.T) {
 
 			pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
 			assert.NoError(err)
+			assert.Equal(curve, pk.CurveID())
+			assert.Equal(curve, vk.CurveID())
 
 			// write the PK to ensure it is not mutated
 			_, err = pk.WriteTo(&b1)
@@ -46,6 +60,7 @@ func TestProver(t *testing.T) {
 
 			proof, err := plonk.Prove(ccs, pk, fullWitness)
 			assert.NoError(err)
+			assert.Equal(curve, proof.CurveID())
 
 			// check pk
 			_, err = pk.WriteTo(&b2)
@@ -60,8 +75,8 @@ func TestProver(t *testing.T) {
 			err = plonk.Verify(proof, vk, fullWitness)
 			assert.Error(err)
 
-			// check that error contains "witness length is invalid"
-			assert.Contains(err.Error(), "witness length is invalid")
+			// check that error is an invalid witness size
+			assert.ErrorIs(err, backend.ErrInvalidWitnessSize)
 
 		})
 
@@ -108,6 +123,340 @@ func TestCustomHashToField(t *testing.T) {
 	}
 }
 
+func TestDeterministicProver(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := &commitmentCircuit{X: 1}
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &commitmentCircuit{})
+			assert.NoError(err)
+			srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+			assert.NoError(err)
+			pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			pubWitness, err := witness.Public()
+			assert.NoError(err)
+
+			// the same random source must give the same proof, whatever the
+			// number of parallel tasks of the solver, the FFTs and the
+			// multi-exponentiations.
+			var proofs [][]byte
+			for _, nbTasks := range []int{1, 3, 8} {
+				proof, err := plonk.Prove(ccs, pk, witness,
+					backend.WithNbTasks(nbTasks),
+					backend.WithProverHashToFieldFunction(constantHash{}),
+					backend.WithProverRandomSource(rand.New(rand.NewSource(42)))) //#nosec G404 -- test only
+				assert.NoError(err)
+				assert.NoError(plonk.Verify(proof, vk, pubWitness, backend.WithVerifierHashToFieldFunction(constantHash{}), backend.WithVerifierNbTasks(nbTasks)))
+				var buf bytes.Buffer
+				_, err = proof.WriteTo(&buf)
+				assert.NoError(err)
+				proofs = append(proofs, buf.Bytes())
+			}
+			for i := 1; i < len(proofs); i++ {
+				assert.Equal(proofs[0], proofs[i], "proofs differ with the number of tasks")
+			}
+		}, curve.String())
+	}
+}
+
+func TestNoBlinding(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := &commitmentCircuit{X: 1}
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &commitmentCircuit{})
+			assert.NoError(err)
+			srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+			assert.NoError(err)
+			pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			pubWitness, err := witness.Public()
+			assert.NoError(err)
+
+			// without blinding the proof is valid and does not depend on any
+			// randomness.
+			var proofs [2][]byte
+			for i := range proofs {
+				proof, err := plonk.Prove(ccs, pk, witness,
+					backend.WithProverHashToFieldFunction(constantHash{}),
+					backend.WithProverNoBlinding())
+				assert.NoError(err)
+				assert.NoError(plonk.Verify(proof, vk, pubWitness, backend.WithVerifierHashToFieldFunction(constantHash{})))
+				var buf bytes.Buffer
+				_, err = proof.WriteTo(&buf)
+				assert.NoError(err)
+				proofs[i] = buf.Bytes()
+			}
+			assert.Equal(proofs[0], proofs[1], "unblinded proofs differ")
+		}, curve.String())
+	}
+}
+
+func TestSelfCheck(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := &commitmentCircuit{X: 1}
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &commitmentCircuit{})
+			assert.NoError(err)
+			srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+			assert.NoError(err)
+			pk, _, err := plonk.Setup(ccs, srs, srsLagrange)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			_, err = plonk.Prove(ccs, pk, witness,
+				backend.WithProverHashToFieldFunction(constantHash{}),
+				backend.WithProverSelfCheck())
+			assert.NoError(err)
+		}, curve.String())
+	}
+
+	// a faulty accelerator corrupts a commitment of the BN254 proof
+	acc := new(faultyAccelerator)
+	backend.RegisterAccelerator("faulty", acc)
+	defer backend.RegisterAccelerator("faulty", nil)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &commitmentCircuit{})
+	assert.NoError(err)
+	srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+	assert.NoError(err)
+	pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+	assert.NoError(err)
+	witness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicWitness, err := witness.Public()
+	assert.NoError(err)
+
+	proof, err := plonk.Prove(ccs, pk, witness,
+		backend.WithProverHashToFieldFunction(constantHash{}),
+		backend.WithProverAccelerator("faulty"))
+	assert.NoError(err, "the corruption is only detected by verifying")
+	assert.Error(plonk.Verify(proof, vk, publicWitness, backend.WithVerifierHashToFieldFunction(constantHash{})))
+
+	acc.corrupted.Store(false)
+	_, err = plonk.Prove(ccs, pk, witness,
+		backend.WithProverHashToFieldFunction(constantHash{}),
+		backend.WithProverAccelerator("faulty"),
+		backend.WithProverSelfCheck())
+	assert.Error(err, "self check didn't detect the corrupted commitment")
+	assert.True(acc.corrupted.Load())
+}
+
+func TestProofSerialization(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := &commitmentCircuit{X: 1}
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &commitmentCircuit{})
+			assert.NoError(err)
+			srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+			assert.NoError(err)
+			pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			publicWitness, err := witness.Public()
+			assert.NoError(err)
+			proof, err := plonk.Prove(ccs, pk, witness, backend.WithProverHashToFieldFunction(constantHash{}))
+			assert.NoError(err)
+
+			// binary
+			var buf bytes.Buffer
+			_, err = proof.WriteTo(&buf)
+			assert.NoError(err)
+			binProof := plonk.NewProof(curve)
+			_, err = binProof.ReadFrom(&buf)
+			assert.NoError(err)
+			assert.Equal(proof, binProof)
+
+			// json
+			data, err := json.Marshal(proof)
+			assert.NoError(err)
+			jsonProof := plonk.NewProof(curve)
+			assert.NoError(json.Unmarshal(data, jsonProof))
+			assert.Equal(proof, jsonProof)
+
+			assert.NoError(plonk.Verify(jsonProof, vk, publicWitness, backend.WithVerifierHashToFieldFunction(constantHash{})))
+		}, curve.String())
+	}
+}
+
+func TestEstimateResources(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &commitmentCircuit{})
+	assert.NoError(err)
+	srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+	assert.NoError(err)
+	pk, _, err := plonk.Setup(ccs, srs, srsLagrange)
+	assert.NoError(err)
+
+	e := pk.EstimateResources()
+	assert.True(e.Memory > 0, "no memory estimated")
+	// L, R, O, Z, H₁, H₂, H₃, the linearized polynomial, the two openings and
+	// the commitment
+	assert.Equal(11, len(e.MultiExps))
+	for _, m := range e.MultiExps {
+		assert.False(m.G2)
+		assert.True(m.Size >= ccs.GetNbConstraints(), "multi-exponentiation smaller than the constraint system")
+	}
+	assert.Equal(2, len(e.FFTs))
+	assert.True(e.Time > 0, "no time estimated")
+}
+
+func TestProverProgressAndCancellation(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := &commitmentCircuit{X: 1}
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &commitmentCircuit{})
+			assert.NoError(err)
+			srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+			assert.NoError(err)
+			pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			publicWitness, err := witness.Public()
+			assert.NoError(err)
+
+			var progress []float64
+			proof, err := plonk.Prove(ccs, pk, witness,
+				backend.WithProverHashToFieldFunction(constantHash{}),
+				backend.WithProverProgress(func(stage string, p float64) {
+					progress = append(progress, p)
+				}))
+			assert.NoError(err)
+			assert.NoError(plonk.Verify(proof, vk, publicWitness, backend.WithVerifierHashToFieldFunction(constantHash{})))
+			assert.Equal(9, len(progress))
+			for i := 1; i < len(progress); i++ {
+				assert.True(progress[i-1] < progress[i], "progress is not increasing")
+			}
+			assert.Equal(1.0, progress[len(progress)-1])
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err = plonk.Prove(ccs, pk, witness,
+				backend.WithProverHashToFieldFunction(constantHash{}),
+				backend.WithProverContext(ctx))
+			assert.True(errors.Is(err, context.Canceled), "unexpected error: %v", err)
+		}, curve.String())
+	}
+}
+
+func TestProverDeadline(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := &commitmentCircuit{X: 1}
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &commitmentCircuit{})
+			assert.NoError(err)
+			srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+			assert.NoError(err)
+			pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			publicWitness, err := witness.Public()
+			assert.NoError(err)
+
+			var timings []time.Duration
+			proof, err := plonk.Prove(ccs, pk, witness,
+				backend.WithProverHashToFieldFunction(constantHash{}),
+				backend.WithProverDeadline(time.Now().Add(time.Hour)),
+				backend.WithProverStageTimings(func(stage string, elapsed time.Duration) {
+					timings = append(timings, elapsed)
+				}))
+			assert.NoError(err)
+			assert.NoError(plonk.Verify(proof, vk, publicWitness, backend.WithVerifierHashToFieldFunction(constantHash{})))
+			assert.Equal(9, len(timings))
+			for i := 1; i < len(timings); i++ {
+				assert.True(timings[i-1] <= timings[i], "timings are not increasing")
+			}
+
+			deadline := time.Now().Add(-time.Second)
+			_, err = plonk.Prove(ccs, pk, witness,
+				backend.WithProverHashToFieldFunction(constantHash{}),
+				backend.WithProverDeadline(deadline))
+			assert.True(errors.Is(err, context.DeadlineExceeded), "unexpected error: %v", err)
+			var deadlineErr *backend.DeadlineExceededError
+			assert.True(errors.As(err, &deadlineErr), "unexpected error: %v", err)
+			assert.True(deadlineErr.Deadline.Equal(deadline))
+			assert.True(len(deadlineErr.Completed) < 9, "all stages completed")
+		}, curve.String())
+	}
+}
+
+func TestProverAccelerator(t *testing.T) {
+	assert := test.NewAssert(t)
+	acc := new(cpuAccelerator)
+	backend.RegisterAccelerator("test", acc)
+	defer backend.RegisterAccelerator("test", nil)
+
+	_, err := backend.NewProverConfig(backend.WithProverAccelerator("unknown"))
+	assert.Error(err)
+
+	assignment := &commitmentCircuit{X: 1}
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &commitmentCircuit{})
+			assert.NoError(err)
+			srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+			assert.NoError(err)
+			pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			publicWitness, err := witness.Public()
+			assert.NoError(err)
+
+			// the accelerator only supports BN254, the other curves fall back
+			// to the CPU.
+			proof, err := plonk.Prove(ccs, pk, witness,
+				backend.WithProverHashToFieldFunction(constantHash{}),
+				backend.WithProverAccelerator("test"))
+			assert.NoError(err)
+			assert.NoError(plonk.Verify(proof, vk, publicWitness, backend.WithVerifierHashToFieldFunction(constantHash{})))
+		}, curve.String())
+	}
+	// all the multi-exponentiations of the BN254 proof are offloaded: the
+	// commitments to L, R, O, Z, the bsb22 commitment, H₁, H₂, H₃ and the
+	// linearized polynomial, and the two opening proofs.
+	assert.Equal(int64(11), acc.nbMultiExp.Load(), "multi-exponentiations not offloaded")
+	assert.True(acc.nbFFT.Load() > 0, "no FFT offloaded")
+}
+
+func TestProverAcceleratorContext(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &commitmentCircuit{})
+	assert.NoError(err)
+	srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+	assert.NoError(err)
+	pk, _, err := plonk.Setup(ccs, srs, srsLagrange)
+	assert.NoError(err)
+	witness, err := frontend.NewWitness(&commitmentCircuit{X: 1}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the accelerator gets the context of the prover, and its cancellation
+	// aborts the proof.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	acc := &cancellingAccelerator{cancel: cancel}
+	_, err = plonk.Prove(ccs, pk, witness, backend.WithProverContext(ctx), backend.WithProverAcceleratorEngine(acc))
+	assert.ErrorIs(err, context.Canceled)
+}
+
 func TestCustomChallengeHash(t *testing.T) {
 	assert := test.NewAssert(t)
 	assignment := &smallCircuit{X: 1}
@@ -194,6 +543,114 @@ func TestCustomKZGFoldingHash(t *testing.T) {
 	}
 }
 
+func TestIgnoreUnsatisfiedConstraints(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &smallCircuit{})
+			assert.NoError(err)
+			srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+			assert.NoError(err)
+			pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+			assert.NoError(err)
+
+			// the witness doesn't satisfy the constraints, the proof must not verify
+			witness, err := frontend.NewWitness(&smallCircuit{X: 2}, curve.ScalarField())
+			assert.NoError(err)
+			publicWitness, err := witness.Public()
+			assert.NoError(err)
+			proof, err := plonk.Prove(ccs, pk, witness, backend.WithProverIgnoreUnsatisfiedConstraints())
+			assert.NoError(err)
+			assert.Error(plonk.Verify(proof, vk, publicWitness))
+
+			// the wires which can't be solved are set to zero
+			ccs, err = frontend.Compile(curve.ScalarField(), scs.NewBuilder, &divCircuit{})
+			assert.NoError(err)
+			witness, err = frontend.NewWitness(&divCircuit{X: 2, Y: 0}, curve.ScalarField())
+			assert.NoError(err)
+			_, err = ccs.Solve(witness)
+			assert.Error(err)
+			_, err = ccs.Solve(witness, solver.WithIgnoreUnsatisfiedConstraints())
+			assert.NoError(err)
+		}, curve.String())
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &smallCircuit{})
+			assert.NoError(err)
+			srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+			assert.NoError(err)
+			pk, _, err := plonk.Setup(ccs, srs, srsLagrange)
+			assert.NoError(err)
+
+			// the witness doesn't satisfy the constraints
+			witness, err := frontend.NewWitness(&smallCircuit{X: 2}, curve.ScalarField())
+			assert.NoError(err)
+			_, err = plonk.Prove(ccs, pk, witness)
+			assert.True(errors.Is(err, backend.ErrUnsatisfiedConstraint), "unexpected error: %v", err)
+
+			// the memory budget is too small
+			_, err = plonk.Prove(ccs, pk, witness, backend.WithProverMemoryBudget(1))
+			assert.True(errors.Is(err, backend.ErrInsufficientMemoryBudget), "unexpected error: %v", err)
+
+			// the srs is too small for a larger circuit
+			largeCcs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &refCircuit{nbConstraints: 20})
+			assert.NoError(err)
+			_, _, err = plonk.Setup(largeCcs, srs, srsLagrange)
+			assert.True(errors.Is(err, backend.ErrSRSTooSmall), "unexpected error: %v", err)
+		}, curve.String())
+	}
+}
+
+// TestSmallDomains proves and verifies circuits whose domain is of size n, for
+// small n where the quotient domain is the smallest. A domain of size 1 is
+// refused by the setup.
+func TestSmallDomains(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range gnark.Curves() {
+		curve := curve
+		for _, n := range []int{1, 2, 4, 8} {
+			n := n
+			assert.Run(func(assert *test.Assert) {
+				if n == 1 {
+					ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &oneConstraintCircuit{})
+					assert.NoError(err)
+					assert.Equal(1, ccs.GetNbConstraints()+ccs.GetNbPublicVariables())
+					srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+					assert.NoError(err)
+					_, _, err = plonk.Setup(ccs, srs, srsLagrange)
+					assert.True(errors.Is(err, backend.ErrDomainTooSmall), "unexpected error: %v", err)
+					return
+				}
+				// the multiplications, the assertion and the public input
+				ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &refCircuit{nbConstraints: n - 2})
+				assert.NoError(err)
+				assert.Equal(n, ccs.GetNbConstraints()+ccs.GetNbPublicVariables())
+				srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+				assert.NoError(err)
+				pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+				assert.NoError(err)
+
+				var y big.Int
+				y.Exp(big.NewInt(3), new(big.Int).Lsh(big.NewInt(1), uint(n-2)), curve.ScalarField())
+				witness, err := frontend.NewWitness(&refCircuit{X: 3, Y: &y}, curve.ScalarField())
+				assert.NoError(err)
+				publicWitness, err := witness.Public()
+				assert.NoError(err)
+				proof, err := plonk.Prove(ccs, pk, witness)
+				assert.NoError(err)
+				assert.NoError(plonk.Verify(proof, vk, publicWitness))
+			}, curve.String(), fmt.Sprintf("n=%d", n))
+		}
+	}
+}
+
 func BenchmarkSetup(b *testing.B) {
 	for _, curve := range getCurves() {
 		b.Run(curve.String(), func(b *testing.B) {
@@ -320,6 +777,25 @@ func (c *smallCircuit) Define(api frontend.API) error {
 	return nil
 }
 
+type oneConstraintCircuit struct {
+	X frontend.Variable
+}
+
+func (c *oneConstraintCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.X, 1)
+	return nil
+}
+
+type divCircuit struct {
+	X, Y frontend.Variable
+}
+
+func (c *divCircuit) Define(api frontend.API) error {
+	res := api.DivUnchecked(c.X, c.Y)
+	api.AssertIsEqual(api.Add(res, 1), 1)
+	return nil
+}
+
 type constantHash struct{}
 
 func (h constantHash) Write(p []byte) (n int, err error) { return len(p), nil }
@@ -334,3 +810,89 @@ func getCurves() []ecc.ID {
 	}
 	return gnark.Curves()
 }
+
+// cpuAccelerator computes the offloaded operations with gnark-crypto, on BN254
+// only, and counts them.
+type cpuAccelerator struct {
+	nbMultiExp, nbFFT atomic.Int64
+}
+
+func (a *cpuAccelerator) MultiExp(ctx context.Context, curve ecc.ID, res, points, scalars any, _ backend.MultiExpConfig) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if curve != ecc.BN254 {
+		return backend.ErrAcceleratorUnsupported
+	}
+	a.nbMultiExp.Add(1)
+	var err error
+	switch res := res.(type) {
+	case *bn254.G1Jac:
+		_, err = res.MultiExp(points.([]bn254.G1Affine), scalars.([]fr_bn254.Element), ecc.MultiExpConfig{})
+	case *bn254.G2Jac:
+		_, err = res.MultiExp(points.([]bn254.G2Affine), scalars.([]fr_bn254.Element), ecc.MultiExpConfig{})
+	default:
+		return backend.ErrAcceleratorUnsupported
+	}
+	return err
+}
+
+// faultyAccelerator is a cpuAccelerator which adds the generator to the first
+// G1 multi-exponentiation, as a silent memory corruption would.
+type faultyAccelerator struct {
+	cpuAccelerator
+	corrupted atomic.Bool
+}
+
+func (a *faultyAccelerator) MultiExp(ctx context.Context, curve ecc.ID, res, points, scalars any, config backend.MultiExpConfig) error {
+	if err := a.cpuAccelerator.MultiExp(ctx, curve, res, points, scalars, config); err != nil {
+		return err
+	}
+	if res, ok := res.(*bn254.G1Jac); ok && a.corrupted.CompareAndSwap(false, true) {
+		g1, _, _, _ := bn254.Generators()
+		res.AddAssign(&g1)
+	}
+	return nil
+}
+
+func (a *cpuAccelerator) FFT(ctx context.Context, curve ecc.ID, v, domain any, config backend.FFTConfig) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if curve != ecc.BN254 {
+		return backend.ErrAcceleratorUnsupported
+	}
+	a.nbFFT.Add(1)
+	d := domain.(*fft.Domain)
+	decimation := fft.DIT
+	if config.DIF {
+		decimation = fft.DIF
+	}
+	var opts []fft.Option
+	if config.OnCoset {
+		opts = append(opts, fft.OnCoset())
+	}
+	if config.Inverse {
+		d.FFTInverse(v.([]fr_bn254.Element), decimation, opts...)
+	} else {
+		d.FFT(v.([]fr_bn254.Element), decimation, opts...)
+	}
+	return nil
+}
+
+// cancellingAccelerator is a cpuAccelerator which cancels the context of the
+// prover on its first call.
+type cancellingAccelerator struct {
+	cpuAccelerator
+	cancel context.CancelFunc
+}
+
+func (a *cancellingAccelerator) MultiExp(ctx context.Context, curve ecc.ID, res, points, scalars any, config backend.MultiExpConfig) error {
+	a.cancel()
+	return a.cpuAccelerator.MultiExp(ctx, curve, res, points, scalars, config)
+}
+
+func (a *cancellingAccelerator) FFT(ctx context.Context, curve ecc.ID, v, domain any, config backend.FFTConfig) error {
+	a.cancel()
+	return a.cpuAccelerator.FFT(ctx, curve, v, domain, config)
+}
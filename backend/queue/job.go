@@ -0,0 +1,130 @@
+//go:build !verifier_only
+
+// Package queue defines the interface between gnark and external proving
+// marketplaces and provides a reference in-process proving queue.
+//
+// A proving job carries the serialized constraint system, proving key and full
+// witness, and yields the serialized proof. Jobs are processed by decreasing
+// priority. Jobs whose deadline has passed before they are picked up are not
+// proven, and the provers abort the jobs whose deadline passes while they are
+// being proven.
+package queue
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+)
+
+// ProvingJob is a request to generate a proof. The serialized objects are the
+// ones produced by the WriteTo methods of the constraint system, the proving
+// key and the witness.
+type ProvingJob interface {
+	// ID uniquely identifies the job.
+	ID() string
+
+	// Backend returns the proof system to use.
+	Backend() backend.ID
+
+	// Curve returns the curve of the constraint system.
+	Curve() ecc.ID
+
+	// ConstraintSystem returns a reader to the serialized constraint system.
+	ConstraintSystem() io.Reader
+
+	// ProvingKey returns a reader to the serialized proving key.
+	ProvingKey() io.Reader
+
+	// Witness returns a reader to the serialized full witness.
+	Witness() io.Reader
+
+	// Deadline returns the time after which the proof is not needed anymore.
+	// The zero value means no deadline.
+	Deadline() time.Time
+
+	// Priority returns the priority of the job. Jobs with higher priority are
+	// processed first.
+	Priority() int
+}
+
+// Job is a ProvingJob backed by in-memory serialized objects.
+type Job struct {
+	JobID       string
+	BackendID   backend.ID
+	CurveID     ecc.ID
+	CS          []byte
+	PK          []byte
+	FullWitness []byte
+	JobDeadline time.Time
+	JobPriority int
+}
+
+func (j *Job) ID() string                  { return j.JobID }
+func (j *Job) Backend() backend.ID         { return j.BackendID }
+func (j *Job) Curve() ecc.ID               { return j.CurveID }
+func (j *Job) ConstraintSystem() io.Reader { return bytes.NewReader(j.CS) }
+func (j *Job) ProvingKey() io.Reader       { return bytes.NewReader(j.PK) }
+func (j *Job) Witness() io.Reader          { return bytes.NewReader(j.FullWitness) }
+func (j *Job) Deadline() time.Time         { return j.JobDeadline }
+func (j *Job) Priority() int               { return j.JobPriority }
+
+// Execute deserializes the inputs of the job, proves and returns the serialized
+// proof.
+func Execute(job ProvingJob, opts ...backend.ProverOption) ([]byte, error) {
+	curve := job.Curve()
+	w, err := witness.New(curve.ScalarField())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.ReadFrom(job.Witness()); err != nil {
+		return nil, fmt.Errorf("read witness: %w", err)
+	}
+
+	var proof io.WriterTo
+	switch job.Backend() {
+	case backend.GROTH16:
+		ccs := groth16.NewCS(curve)
+		pk := groth16.NewProvingKey(curve)
+		if err := readObjects(job, ccs, pk); err != nil {
+			return nil, err
+		}
+		if proof, err = groth16.Prove(ccs, pk, w, opts...); err != nil {
+			return nil, err
+		}
+	case backend.PLONK:
+		ccs := plonk.NewCS(curve)
+		pk := plonk.NewProvingKey(curve)
+		if err := readObjects(job, ccs, pk); err != nil {
+			return nil, err
+		}
+		if proof, err = plonk.Prove(ccs, pk, w, opts...); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported backend %s", job.Backend())
+	}
+
+	var buf bytes.Buffer
+	if _, err := proof.WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("write proof: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func readObjects(job ProvingJob, ccs constraint.ConstraintSystem, pk io.ReaderFrom) error {
+	if _, err := ccs.ReadFrom(job.ConstraintSystem()); err != nil {
+		return fmt.Errorf("read constraint system: %w", err)
+	}
+	if _, err := pk.ReadFrom(job.ProvingKey()); err != nil {
+		return fmt.Errorf("read proving key: %w", err)
+	}
+	return nil
+}
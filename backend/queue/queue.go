@@ -0,0 +1,175 @@
+//go:build !verifier_only
+
+package queue
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/logger"
+)
+
+var (
+	// ErrDeadlineExceeded is returned in the Result of a job whose deadline
+	// passed before it could be processed, or while it was being proven. In the
+	// latter case it wraps the [*backend.DeadlineExceededError] of the prover.
+	ErrDeadlineExceeded = errors.New("job deadline exceeded")
+	// ErrClosed is returned when submitting a job to a closed queue.
+	ErrClosed = errors.New("queue closed")
+)
+
+// Result is the outcome of a proving job.
+type Result struct {
+	JobID string
+	// Proof is the serialized proof, nil if Err is not nil.
+	Proof []byte
+	Err   error
+	// Duration is the time spent proving.
+	Duration time.Duration
+}
+
+// Queue is an in-process proving queue. Submitted jobs are processed by a fixed
+// number of workers, by decreasing priority, then by earliest deadline, then in
+// submission order.
+type Queue struct {
+	lock    sync.Mutex
+	cond    *sync.Cond
+	pending jobHeap
+	seq     uint64
+	closed  bool
+	wg      sync.WaitGroup
+	opts    []backend.ProverOption
+}
+
+// New returns a new queue and starts nbWorkers workers proving the submitted
+// jobs with the given prover options. As the provers already use all the
+// available CPUs, nbWorkers should typically be 1.
+func New(nbWorkers int, opts ...backend.ProverOption) (*Queue, error) {
+	if nbWorkers <= 0 {
+		return nil, fmt.Errorf("invalid number of workers %d", nbWorkers)
+	}
+	q := &Queue{opts: opts}
+	q.cond = sync.NewCond(&q.lock)
+	q.wg.Add(nbWorkers)
+	for i := 0; i < nbWorkers; i++ {
+		go q.work()
+	}
+	return q, nil
+}
+
+// Submit adds a job to the queue. The returned channel receives exactly one
+// Result once the job is processed.
+func (q *Queue) Submit(job ProvingJob) (<-chan Result, error) {
+	res := make(chan Result, 1)
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closed {
+		return nil, ErrClosed
+	}
+	heap.Push(&q.pending, &queuedJob{job: job, seq: q.seq, res: res})
+	q.seq++
+	q.cond.Signal()
+	return res, nil
+}
+
+// Len returns the number of jobs waiting to be processed.
+func (q *Queue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.pending.Len()
+}
+
+// Close stops accepting new jobs and waits until the pending jobs are
+// processed.
+func (q *Queue) Close() {
+	q.lock.Lock()
+	q.closed = true
+	q.cond.Broadcast()
+	q.lock.Unlock()
+	q.wg.Wait()
+}
+
+func (q *Queue) work() {
+	defer q.wg.Done()
+	log := logger.Logger()
+	for {
+		q.lock.Lock()
+		for q.pending.Len() == 0 && !q.closed {
+			q.cond.Wait()
+		}
+		if q.pending.Len() == 0 {
+			q.lock.Unlock()
+			return
+		}
+		qj := heap.Pop(&q.pending).(*queuedJob)
+		q.lock.Unlock()
+
+		job := qj.job
+		if d := job.Deadline(); !d.IsZero() && time.Now().After(d) {
+			qj.res <- Result{JobID: job.ID(), Err: ErrDeadlineExceeded}
+			continue
+		}
+		opts := q.opts
+		if d := job.Deadline(); !d.IsZero() {
+			// the prover stops between its stages once the deadline is exceeded
+			opts = append(opts[:len(opts):len(opts)], backend.WithProverDeadline(d))
+		}
+		start := time.Now()
+		proof, err := Execute(job, opts...)
+		took := time.Since(start)
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("%w: %w", ErrDeadlineExceeded, err)
+		}
+		if err != nil {
+			log.Err(err).Str("job", job.ID()).Msg("proving job failed")
+		} else {
+			log.Debug().Str("job", job.ID()).Dur("took", took).Msg("proving job done")
+		}
+		qj.res <- Result{JobID: job.ID(), Proof: proof, Err: err, Duration: took}
+	}
+}
+
+type queuedJob struct {
+	job ProvingJob
+	seq uint64
+	res chan Result
+}
+
+// jobHeap implements heap.Interface, the root being the next job to process.
+type jobHeap []*queuedJob
+
+func (h jobHeap) Len() int { return len(h) }
+
+func (h jobHeap) Less(i, j int) bool {
+	a, b := h[i].job, h[j].job
+	if a.Priority() != b.Priority() {
+		return a.Priority() > b.Priority()
+	}
+	da, db := a.Deadline(), b.Deadline()
+	if !da.Equal(db) {
+		// jobs without deadline come last
+		if da.IsZero() || db.IsZero() {
+			return db.IsZero()
+		}
+		return da.Before(db)
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h jobHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *jobHeap) Push(x any) { *h = append(*h, x.(*queuedJob)) }
+
+func (h *jobHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return x
+}
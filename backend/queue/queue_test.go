@@ -0,0 +1,175 @@
+//go:build !verifier_only
+
+package queue
+
+import (
+	"bytes"
+	"container/heap"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test/unsafekzg"
+	"github.com/stretchr/testify/require"
+)
+
+type cubicCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *cubicCircuit) Define(api frontend.API) error {
+	x3 := api.Mul(c.X, c.X, c.X)
+	api.AssertIsEqual(c.Y, api.Add(x3, c.X, 5))
+	return nil
+}
+
+func serialize(assert *require.Assertions, o io.WriterTo) []byte {
+	var buf bytes.Buffer
+	_, err := o.WriteTo(&buf)
+	assert.NoError(err)
+	return buf.Bytes()
+}
+
+func TestQueue(t *testing.T) {
+	assert := require.New(t)
+
+	w, err := frontend.NewWitness(&cubicCircuit{X: 3, Y: 35}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	pw, err := w.Public()
+	assert.NoError(err)
+
+	// groth16 job
+	r1csCCS, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &cubicCircuit{})
+	assert.NoError(err)
+	g16PK, g16VK, err := groth16.Setup(r1csCCS)
+	assert.NoError(err)
+	g16Job := &Job{
+		JobID:       "groth16",
+		BackendID:   backend.GROTH16,
+		CurveID:     ecc.BN254,
+		CS:          serialize(assert, r1csCCS),
+		PK:          serialize(assert, g16PK),
+		FullWitness: serialize(assert, w),
+	}
+
+	// plonk job
+	scsCCS, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &cubicCircuit{})
+	assert.NoError(err)
+	srs, srsLagrange, err := unsafekzg.NewSRS(scsCCS)
+	assert.NoError(err)
+	plonkPK, plonkVK, err := plonk.Setup(scsCCS, srs, srsLagrange)
+	assert.NoError(err)
+	plonkJob := &Job{
+		JobID:       "plonk",
+		BackendID:   backend.PLONK,
+		CurveID:     ecc.BN254,
+		CS:          serialize(assert, scsCCS),
+		PK:          serialize(assert, plonkPK),
+		FullWitness: serialize(assert, w),
+		JobPriority: 1,
+	}
+
+	expired := *g16Job
+	expired.JobID = "expired"
+	expired.JobDeadline = time.Now().Add(-time.Second)
+
+	q, err := New(1)
+	assert.NoError(err)
+	chG16, err := q.Submit(g16Job)
+	assert.NoError(err)
+	chPlonk, err := q.Submit(plonkJob)
+	assert.NoError(err)
+	chExpired, err := q.Submit(&expired)
+	assert.NoError(err)
+	q.Close()
+
+	_, err = q.Submit(g16Job)
+	assert.True(errors.Is(err, ErrClosed))
+
+	res := <-chG16
+	assert.NoError(res.Err)
+	g16Proof := groth16.NewProof(ecc.BN254)
+	_, err = g16Proof.ReadFrom(bytes.NewReader(res.Proof))
+	assert.NoError(err)
+	assert.NoError(groth16.Verify(g16Proof, g16VK, pw))
+
+	res = <-chPlonk
+	assert.NoError(res.Err)
+	plonkProof := plonk.NewProof(ecc.BN254)
+	_, err = plonkProof.ReadFrom(bytes.NewReader(res.Proof))
+	assert.NoError(err)
+	assert.NoError(plonk.Verify(plonkProof, plonkVK, pw))
+
+	res = <-chExpired
+	assert.True(errors.Is(res.Err, ErrDeadlineExceeded))
+}
+
+func TestDeadlineDuringProof(t *testing.T) {
+	assert := require.New(t)
+
+	w, err := frontend.NewWitness(&cubicCircuit{X: 3, Y: 35}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &cubicCircuit{})
+	assert.NoError(err)
+	pk, _, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	job := &Job{
+		JobID:       "slow",
+		BackendID:   backend.GROTH16,
+		CurveID:     ecc.BN254,
+		CS:          serialize(assert, ccs),
+		PK:          serialize(assert, pk),
+		FullWitness: serialize(assert, w),
+		JobDeadline: time.Now().Add(time.Second),
+	}
+
+	// the job is picked up before its deadline, which passes while solving
+	q, err := New(1, backend.WithProverStageTimings(func(stage string, _ time.Duration) {
+		if stage == "solve" {
+			time.Sleep(time.Until(job.JobDeadline) + 10*time.Millisecond)
+		}
+	}))
+	assert.NoError(err)
+	ch, err := q.Submit(job)
+	assert.NoError(err)
+	q.Close()
+
+	res := <-ch
+	assert.True(errors.Is(res.Err, ErrDeadlineExceeded), "unexpected error: %v", res.Err)
+	var dErr *backend.DeadlineExceededError
+	assert.True(errors.As(res.Err, &dErr))
+	assert.Len(dErr.Completed, 1)
+	assert.Equal("solve", dErr.Completed[0].Stage)
+	assert.Nil(res.Proof)
+}
+
+func TestJobOrdering(t *testing.T) {
+	assert := require.New(t)
+	now := time.Now()
+	jobs := []*Job{
+		{JobID: "low"},
+		{JobID: "high-late", JobPriority: 2, JobDeadline: now.Add(2 * time.Hour)},
+		{JobID: "high-none", JobPriority: 2},
+		{JobID: "high-early", JobPriority: 2, JobDeadline: now.Add(time.Hour)},
+		{JobID: "low-second"},
+		{JobID: "mid", JobPriority: 1},
+	}
+	var h jobHeap
+	for i, j := range jobs {
+		heap.Push(&h, &queuedJob{job: j, seq: uint64(i)})
+	}
+	var order []string
+	for h.Len() > 0 {
+		order = append(order, heap.Pop(&h).(*queuedJob).job.ID())
+	}
+	assert.Equal([]string{"high-early", "high-late", "high-none", "mid", "low", "low-second"}, order)
+}
@@ -0,0 +1,75 @@
+//go:build !verifier_only
+
+package backend
+
+import (
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/rs/zerolog"
+)
+
+// solverConfig holds the solver options of a [ProverConfig].
+type solverConfig struct {
+	SolverOpts []solver.Option
+
+	// solver options of WithHints and WithSolverLogger, appended to SolverOpts
+	// by NewProverConfig
+	callSolverOpts []solver.Option
+}
+
+// applySolverOptions appends the solver options of the prover options to the
+// ones of [WithSolverOptions].
+func (opt *ProverConfig) applySolverOptions() {
+	opt.SolverOpts = append(opt.SolverOpts[:len(opt.SolverOpts):len(opt.SolverOpts)], opt.callSolverOpts...)
+	opt.callSolverOpts = nil
+	if opt.NbTasks > 0 {
+		opt.SolverOpts = append(opt.SolverOpts, solver.WithNbTasks(opt.NbTasks))
+	}
+	if opt.IgnoreUnsatisfiedConstraints {
+		opt.SolverOpts = append(opt.SolverOpts, solver.WithIgnoreUnsatisfiedConstraints())
+	}
+}
+
+// WithSolverOptions specifies the constraint system solver options, replacing
+// the ones of a previous WithSolverOptions. The solver options set by
+// [WithHints], [WithSolverLogger] and [WithNbTasks] are kept, and applied after
+// them.
+func WithSolverOptions(solverOpts ...solver.Option) ProverOption {
+	return func(opt *ProverConfig) error {
+		opt.SolverOpts = solverOpts
+		return nil
+	}
+}
+
+// withCallSolverOptions appends solver options applied after the ones of
+// [WithSolverOptions].
+func withCallSolverOptions(solverOpts ...solver.Option) ProverOption {
+	return func(opt *ProverConfig) error {
+		opt.callSolverOpts = append(opt.callSolverOpts, solverOpts...)
+		return nil
+	}
+}
+
+// WithHints specifies hint functions used by the solver in addition to the
+// ones registered with [solver.RegisterHint]. Unlike the registry, the hints
+// are only visible to the call they are given to. See [solver.WithHints].
+func WithHints(hintFunctions ...solver.Hint) ProverOption {
+	return withCallSolverOptions(solver.WithHints(hintFunctions...))
+}
+
+// WithSolverLogger sets the logger used by the solver, for the outputs of
+// api.Println, instead of the global gnark logger. See [solver.WithLogger].
+func WithSolverLogger(l zerolog.Logger) ProverOption {
+	return withCallSolverOptions(solver.WithLogger(l))
+}
+
+// WithProverIgnoreUnsatisfiedConstraints makes the prover solve the constraint
+// system even if some constraints are not satisfied, and return a proof which
+// doesn't verify instead of an error. See
+// [solver.WithIgnoreUnsatisfiedConstraints]. This is only useful to test that
+// verifiers reject such proofs.
+func WithProverIgnoreUnsatisfiedConstraints() ProverOption {
+	return func(pc *ProverConfig) error {
+		pc.IgnoreUnsatisfiedConstraints = true
+		return nil
+	}
+}
@@ -0,0 +1,9 @@
+//go:build verifier_only
+
+package backend
+
+// solverConfig is empty in verifier_only builds, which don't link the
+// constraint system solver.
+type solverConfig struct{}
+
+func (opt *ProverConfig) applySolverOptions() {}
@@ -0,0 +1,156 @@
+// Package versioning helps upgrading a circuit whose verifier is already
+// deployed, e.g. on-chain.
+//
+// A verifier only depends on the circuit through its verifying key and the
+// order of the public inputs. This package records the public input layout of
+// the versions of a circuit, checks that a new version can be verified by a
+// verifier deployed for an older one, and maps public witnesses between
+// versions.
+package versioning
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+)
+
+var (
+	// ErrIncompatibleLayout is returned (wrapped) when a public input layout
+	// doesn't match the one of a deployed verifier.
+	ErrIncompatibleLayout = errors.New("incompatible public input layout")
+
+	// ErrUnknownVersion is returned (wrapped) by the Registry for versions not
+	// registered.
+	ErrUnknownVersion = errors.New("unknown circuit version")
+)
+
+// PublicLayout is the list of the names of the public inputs of a circuit, in
+// the order of the public witness.
+type PublicLayout []string
+
+// NewPublicLayout returns the public input layout of the compiled circuit. It
+// is the one the verifier sees: for a circuit compiled with
+// frontend.WithPublicInputsHash, the layout only holds the digest
+// (frontend.PublicInputsDigestName), as the declared public inputs are secret
+// inputs of the compiled circuit.
+func NewPublicLayout(ccs constraint.ConstraintSystem) PublicLayout {
+	return PublicLayout(ccs.PublicInputNames())
+}
+
+// CheckCompatible returns an error wrapping ErrIncompatibleLayout if proofs for
+// the layout l can't be verified against a verifier deployed for the layout
+// deployed, i.e. if the layouts don't have the same inputs in the same order.
+func (l PublicLayout) CheckCompatible(deployed PublicLayout) error {
+	if len(l) != len(deployed) {
+		return fmt.Errorf("%w: %d public inputs, deployed verifier expects %d", ErrIncompatibleLayout, len(l), len(deployed))
+	}
+	for i := range l {
+		if l[i] != deployed[i] {
+			return fmt.Errorf("%w: public input %d is %q, deployed verifier expects %q", ErrIncompatibleLayout, i, l[i], deployed[i])
+		}
+	}
+	return nil
+}
+
+// CheckVerifyingKey returns an error wrapping ErrIncompatibleLayout if the
+// verifying key doesn't expect as many public inputs as the layout.
+func (l PublicLayout) CheckVerifyingKey(vk interface{ NbPublicWitness() int }) error {
+	if n := vk.NbPublicWitness(); n != len(l) {
+		return fmt.Errorf("%w: %d public inputs, verifying key expects %d", ErrIncompatibleLayout, len(l), n)
+	}
+	return nil
+}
+
+// Migrate returns the public witness for the layout l with the values of the
+// public witness w of the layout from. The values are matched by the names of
+// the inputs; inputs of from which are not in l are dropped, and it is an
+// error if an input of l is not in from.
+func (l PublicLayout) Migrate(w witness.Witness, from PublicLayout) (witness.Witness, error) {
+	public, err := w.Public()
+	if err != nil {
+		return nil, err
+	}
+	values := reflect.ValueOf(public.Vector())
+	if values.Len() != len(from) {
+		return nil, fmt.Errorf("public witness has %d values, layout has %d inputs", values.Len(), len(from))
+	}
+	index := make(map[string]int, len(from))
+	for i, name := range from {
+		index[name] = i
+	}
+
+	mapped := make([]any, len(l))
+	for i, name := range l {
+		j, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: public input %q has no value in the previous layout", ErrIncompatibleLayout, name)
+		}
+		mapped[i] = values.Index(j).Interface()
+	}
+
+	chValues := make(chan any)
+	go func() {
+		defer close(chValues)
+		for _, v := range mapped {
+			chValues <- v
+		}
+	}()
+	if err := public.Fill(len(l), 0, chValues); err != nil {
+		return nil, err
+	}
+	return public, nil
+}
+
+// Registry records the public input layouts of the versions of a circuit.
+type Registry struct {
+	layouts  map[string]PublicLayout
+	versions []string
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{layouts: make(map[string]PublicLayout)}
+}
+
+// Register records the public input layout of the compiled circuit for the
+// version.
+func (r *Registry) Register(version string, ccs constraint.ConstraintSystem) error {
+	if _, ok := r.layouts[version]; ok {
+		return fmt.Errorf("version %q already registered", version)
+	}
+	r.layouts[version] = NewPublicLayout(ccs)
+	r.versions = append(r.versions, version)
+	return nil
+}
+
+// Versions returns the registered versions, in order of registration.
+func (r *Registry) Versions() []string {
+	return append([]string(nil), r.versions...)
+}
+
+// Layout returns the public input layout of the version.
+func (r *Registry) Layout(version string) (PublicLayout, error) {
+	layout, ok := r.layouts[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownVersion, version)
+	}
+	return layout, nil
+}
+
+// CheckUpgrade returns an error wrapping ErrIncompatibleLayout if proofs for
+// the version candidate can't be verified by the verifier deployed for the
+// version deployed, with a new verifying key but the same public inputs.
+func (r *Registry) CheckUpgrade(deployed, candidate string) error {
+	from, err := r.Layout(deployed)
+	if err != nil {
+		return err
+	}
+	to, err := r.Layout(candidate)
+	if err != nil {
+		return err
+	}
+	return to.CheckCompatible(from)
+}
@@ -0,0 +1,128 @@
+//go:build !verifier_only
+
+package versioning_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/versioning"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type circuitV1 struct {
+	Root  frontend.Variable `gnark:",public"`
+	Block frontend.Variable `gnark:",public"`
+	X     frontend.Variable
+}
+
+func (c *circuitV1) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.Block), c.Root)
+	return nil
+}
+
+// circuitV2 adds a secret input, the public inputs are unchanged
+type circuitV2 struct {
+	Root  frontend.Variable `gnark:",public"`
+	Block frontend.Variable `gnark:",public"`
+	X, Y  frontend.Variable
+}
+
+func (c *circuitV2) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.Y, c.Block), c.Root)
+	return nil
+}
+
+// circuitV3 swaps the public inputs
+type circuitV3 struct {
+	Block frontend.Variable `gnark:",public"`
+	Root  frontend.Variable `gnark:",public"`
+	X     frontend.Variable
+}
+
+func (c *circuitV3) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.Block), c.Root)
+	return nil
+}
+
+func compile(t *testing.T, circuit frontend.Circuit, opts ...frontend.CompileOption) constraint.ConstraintSystem {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ccs
+}
+
+func TestRegistry(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	registry := versioning.NewRegistry()
+	assert.NoError(registry.Register("v1", compile(t, &circuitV1{})))
+	assert.NoError(registry.Register("v2", compile(t, &circuitV2{})))
+	assert.NoError(registry.Register("v3", compile(t, &circuitV3{})))
+	assert.Error(registry.Register("v1", compile(t, &circuitV1{})))
+	assert.Equal([]string{"v1", "v2", "v3"}, registry.Versions())
+
+	assert.NoError(registry.CheckUpgrade("v1", "v2"))
+	err := registry.CheckUpgrade("v1", "v3")
+	assert.True(errors.Is(err, versioning.ErrIncompatibleLayout), "unexpected error: %v", err)
+	err = registry.CheckUpgrade("v1", "v4")
+	assert.True(errors.Is(err, versioning.ErrUnknownVersion), "unexpected error: %v", err)
+
+	// the layout matches the verifying key
+	_, vk, err := groth16.Setup(compile(t, &circuitV2{}))
+	assert.NoError(err)
+	layout, err := registry.Layout("v2")
+	assert.NoError(err)
+	assert.NoError(layout.CheckVerifyingKey(vk))
+	assert.Error(versioning.PublicLayout{"Root"}.CheckVerifyingKey(vk))
+}
+
+func TestMigrate(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	v1 := versioning.NewPublicLayout(compile(t, &circuitV1{}))
+	v3 := versioning.NewPublicLayout(compile(t, &circuitV3{}))
+
+	w, err := frontend.NewWitness(&circuitV1{Root: 6, Block: 3, X: 2}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	migrated, err := v3.Migrate(w, v1)
+	assert.NoError(err)
+
+	expected, err := frontend.NewWitness(&circuitV3{Root: 6, Block: 3}, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	assert.NoError(err)
+	assert.Equal(expected.Vector().(fr.Vector), migrated.Vector().(fr.Vector))
+
+	_, err = versioning.PublicLayout{"Root", "Timestamp"}.Migrate(w, v1)
+	assert.True(errors.Is(err, versioning.ErrIncompatibleLayout), "unexpected error: %v", err)
+}
+
+func TestHashedPublicInputs(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// the verifier of a circuit compiled with hashed public inputs only sees
+	// the digest
+	ccs := compile(t, &circuitV1{}, frontend.WithPublicInputsHash(mimc.PublicInputsHasher{}))
+	layout := versioning.NewPublicLayout(ccs)
+	assert.Equal(versioning.PublicLayout{frontend.PublicInputsDigestName}, layout)
+
+	_, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	assert.NoError(layout.CheckVerifyingKey(vk))
+	assert.Error(versioning.PublicLayout{"Root", "Block"}.CheckVerifyingKey(vk))
+
+	// swapping the hashed inputs doesn't change the inputs of the verifier
+	registry := versioning.NewRegistry()
+	assert.NoError(registry.Register("v1", ccs))
+	assert.NoError(registry.Register("v3", compile(t, &circuitV3{}, frontend.WithPublicInputsHash(mimc.PublicInputsHasher{}))))
+	assert.NoError(registry.CheckUpgrade("v1", "v3"))
+	assert.NoError(registry.Register("v1-plain", compile(t, &circuitV1{})))
+	assert.Error(registry.CheckUpgrade("v1-plain", "v1"))
+}
@@ -0,0 +1,147 @@
+package witness
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// ErrValueOutOfRange is returned (wrapped) when a value doesn't fit in the
+// field it is converted to.
+var ErrValueOutOfRange = errors.New("value out of range of the target field")
+
+// ConvertValue returns v, an element of the field of modulus from, as an
+// element of the field of modulus to. The value is kept as is: it returns an
+// error wrapping [ErrValueOutOfRange] if v is not reduced modulo from, or if it
+// doesn't fit in the target field, in which case [SplitValue] maps it to limbs
+// instead.
+func ConvertValue(v, from, to *big.Int) (*big.Int, error) {
+	if v.Sign() < 0 || v.Cmp(from) >= 0 {
+		return nil, fmt.Errorf("%w: %s is not reduced modulo %s", ErrValueOutOfRange, v, from)
+	}
+	if v.Cmp(to) >= 0 {
+		return nil, fmt.Errorf("%w: %s doesn't fit modulo %s", ErrValueOutOfRange, v, to)
+	}
+	return new(big.Int).Set(v), nil
+}
+
+// LimbSize returns the largest number of bits such that any limb of this size
+// fits in the field of modulus to.
+func LimbSize(to *big.Int) int {
+	return to.BitLen() - 1
+}
+
+// NbLimbs returns the number of limbs of nbBits bits needed to hold any
+// element of the field of modulus from.
+func NbLimbs(from *big.Int, nbBits int) int {
+	return (from.BitLen() + nbBits - 1) / nbBits
+}
+
+// SplitValue decomposes v, an element of the field of modulus from, into
+// [NbLimbs] limbs of nbBits bits in the field of modulus to, least significant
+// limb first. This is the layout of the limbs of emulated.Element, so that a
+// value of a larger field is given to a circuit over a smaller one with nbBits
+// set to the BitsPerLimb of the emulation parameters. If nbBits is zero, then
+// [LimbSize] of to is used.
+//
+// It returns an error wrapping [ErrValueOutOfRange] if v is not reduced modulo
+// from, or if limbs of nbBits bits don't fit in the target field.
+func SplitValue(v, from, to *big.Int, nbBits int) ([]*big.Int, error) {
+	if nbBits == 0 {
+		nbBits = LimbSize(to)
+	}
+	if nbBits < 0 || nbBits > LimbSize(to) {
+		return nil, fmt.Errorf("%w: limbs of %d bits don't fit modulo %s", ErrValueOutOfRange, nbBits, to)
+	}
+	if v.Sign() < 0 || v.Cmp(from) >= 0 {
+		return nil, fmt.Errorf("%w: %s is not reduced modulo %s", ErrValueOutOfRange, v, from)
+	}
+	limbs := make([]*big.Int, NbLimbs(from, nbBits))
+	mask := new(big.Int).Lsh(big.NewInt(1), uint(nbBits))
+	mask.Sub(mask, big.NewInt(1))
+	r := new(big.Int).Set(v)
+	for i := range limbs {
+		limbs[i] = new(big.Int).And(r, mask)
+		r.Rsh(r, uint(nbBits))
+	}
+	return limbs, nil
+}
+
+// RecomposeValue returns the value of the limbs of nbBits bits, least
+// significant limb first. It is the inverse of [SplitValue].
+func RecomposeValue(limbs []*big.Int, nbBits int) *big.Int {
+	res := new(big.Int)
+	for i := len(limbs) - 1; i >= 0; i-- {
+		res.Lsh(res, uint(nbBits))
+		res.Add(res, limbs[i])
+	}
+	return res
+}
+
+// Convert returns a copy of the witness w over the field of modulus to, as
+// needed when the public inputs of a proof are given to a verifier circuit
+// over another curve. The values are kept as is, see [ConvertValue]; use
+// [ConvertToLimbs] if they may not fit in the target field.
+func Convert(w Witness, to *big.Int) (Witness, error) {
+	return convert(w, to, func(v, from *big.Int) ([]*big.Int, error) {
+		c, err := ConvertValue(v, from, to)
+		if err != nil {
+			return nil, err
+		}
+		return []*big.Int{c}, nil
+	})
+}
+
+// ConvertToLimbs returns a copy of the witness w over the field of modulus to,
+// where each value is replaced by its limbs of nbBits bits, see [SplitValue].
+// The limbs of public values are public.
+func ConvertToLimbs(w Witness, to *big.Int, nbBits int) (Witness, error) {
+	return convert(w, to, func(v, from *big.Int) ([]*big.Int, error) {
+		return SplitValue(v, from, to, nbBits)
+	})
+}
+
+func convert(w Witness, to *big.Int, f func(v, from *big.Int) ([]*big.Int, error)) (Witness, error) {
+	_w, ok := w.(*witness)
+	if !ok {
+		return nil, ErrInvalidWitness
+	}
+	from := field(_w.vector)
+	res, err := New(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var values [][]*big.Int
+	for v := range _w.iterate() {
+		value := utils.FromInterface(v)
+		values = append(values, []*big.Int{&value})
+	}
+	nbPublic, nbSecret := 0, 0
+	for i := range values {
+		if values[i], err = f(values[i][0], from); err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		if i < int(_w.nbPublic) {
+			nbPublic += len(values[i])
+		} else {
+			nbSecret += len(values[i])
+		}
+	}
+
+	chValues := make(chan any)
+	go func() {
+		defer close(chValues)
+		for i := range values {
+			for _, v := range values[i] {
+				chValues <- v
+			}
+		}
+	}()
+	if err := res.Fill(nbPublic, nbSecret, chValues); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,83 @@
+package witness_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertValue(t *testing.T) {
+	assert := require.New(t)
+	bn254, bw6761 := ecc.BN254.ScalarField(), ecc.BW6_761.ScalarField()
+
+	v := new(big.Int).Sub(bn254, big.NewInt(1))
+	c, err := witness.ConvertValue(v, bn254, bw6761)
+	assert.NoError(err)
+	assert.Equal(0, c.Cmp(v))
+
+	// from the larger field to the smaller one
+	v = new(big.Int).Sub(bw6761, big.NewInt(1))
+	_, err = witness.ConvertValue(v, bw6761, bn254)
+	assert.True(errors.Is(err, witness.ErrValueOutOfRange), "unexpected error: %v", err)
+	_, err = witness.ConvertValue(bn254, bn254, bw6761)
+	assert.True(errors.Is(err, witness.ErrValueOutOfRange), "unexpected error: %v", err)
+
+	limbs, err := witness.SplitValue(v, bw6761, bn254, 0)
+	assert.NoError(err)
+	assert.Len(limbs, witness.NbLimbs(bw6761, witness.LimbSize(bn254)))
+	for _, l := range limbs {
+		assert.True(l.Cmp(bn254) < 0, "limb doesn't fit")
+	}
+	assert.Equal(0, witness.RecomposeValue(limbs, witness.LimbSize(bn254)).Cmp(v))
+
+	limbs, err = witness.SplitValue(v, bw6761, bn254, 64)
+	assert.NoError(err)
+	assert.Len(limbs, 6)
+	assert.Equal(0, witness.RecomposeValue(limbs, 64).Cmp(v))
+
+	_, err = witness.SplitValue(v, bw6761, bn254, 254)
+	assert.True(errors.Is(err, witness.ErrValueOutOfRange), "unexpected error: %v", err)
+}
+
+func TestConvert(t *testing.T) {
+	assert := require.New(t)
+	bls12377, bw6761 := ecc.BLS12_377.ScalarField(), ecc.BW6_761.ScalarField()
+
+	x := new(big.Int).Sub(bls12377, big.NewInt(1))
+	w, err := frontend.NewWitness(&circuit{X: x, Y: 2, E: 3}, bls12377)
+	assert.NoError(err)
+
+	// BLS12-377 scalars fit in the BW6-761 scalar field
+	c, err := witness.Convert(w, bw6761)
+	assert.NoError(err)
+	v := c.Vector().(fr_bw6761.Vector)
+	assert.Len(v, 3)
+	assert.Equal(0, v[0].BigInt(new(big.Int)).Cmp(x))
+	assert.Equal(uint64(3), v[2].Uint64())
+	public, err := c.Public()
+	assert.NoError(err)
+	assert.Len(public.Vector().(fr_bw6761.Vector), 2)
+
+	// and back in limbs
+	c, err = witness.ConvertToLimbs(c, bls12377, 64)
+	assert.NoError(err)
+	l := c.Vector().(fr_bls12377.Vector)
+	assert.Len(l, 3*witness.NbLimbs(bw6761, 64))
+	public, err = c.Public()
+	assert.NoError(err)
+	assert.Len(public.Vector().(fr_bls12377.Vector), 2*witness.NbLimbs(bw6761, 64))
+
+	_, err = witness.Convert(c, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	w, err = frontend.NewWitness(&circuit{X: 1, Y: 2, E: new(big.Int).Sub(bw6761, big.NewInt(1))}, bw6761)
+	assert.NoError(err)
+	_, err = witness.Convert(w, bls12377)
+	assert.True(errors.Is(err, witness.ErrValueOutOfRange), "unexpected error: %v", err)
+}
@@ -0,0 +1,177 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package witness
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned by a Store when no object is stored under the
+// requested key.
+var ErrNotFound = errors.New("witness not found in store")
+
+// Store abstracts the storage of serialized witnesses and solved assignments
+// (for example the solutions and extended witnesses returned by
+// constraint.ConstraintSystem.Solve and SolveExtended).
+//
+// It allows distributed proving pipelines to generate witnesses on one fleet
+// and to prove on another one. Objects are stored using their binary encoding
+// (io.WriterTo) and retrieved using io.ReaderFrom, so a remote object storage
+// only needs to implement this interface to be used.
+//
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Put stores the binary encoding of v under key, replacing any existing
+	// object.
+	Put(key string, v io.WriterTo) error
+
+	// Get decodes the object stored under key into v. It returns an error
+	// wrapping ErrNotFound if no object is stored under key.
+	Get(key string, v io.ReaderFrom) error
+
+	// Delete removes the object stored under key. It is not an error to
+	// delete a missing key.
+	Delete(key string) error
+}
+
+// NewMemoryStore returns a Store keeping the encoded objects in memory. It is
+// suitable for sharing witnesses between goroutines of a single process.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		db: make(map[string][]byte),
+	}
+}
+
+type memoryStore struct {
+	lock sync.RWMutex
+	db   map[string][]byte
+}
+
+func (s *memoryStore) Put(key string, v io.WriterTo) error {
+	var buf bytes.Buffer
+	if _, err := v.WriteTo(&buf); err != nil {
+		return fmt.Errorf("encode %q: %w", key, err)
+	}
+	s.lock.Lock()
+	s.db[key] = buf.Bytes()
+	s.lock.Unlock()
+	return nil
+}
+
+func (s *memoryStore) Get(key string, v io.ReaderFrom) error {
+	s.lock.RLock()
+	data, ok := s.db[key]
+	s.lock.RUnlock()
+	if !ok {
+		return fmt.Errorf("%q: %w", key, ErrNotFound)
+	}
+	if _, err := v.ReadFrom(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("decode %q: %w", key, err)
+	}
+	return nil
+}
+
+func (s *memoryStore) Delete(key string) error {
+	s.lock.Lock()
+	delete(s.db, key)
+	s.lock.Unlock()
+	return nil
+}
+
+// NewFileStore returns a Store keeping each object in its own file in
+// directory dir. The directory is created if it does not exist. Keys must be
+// valid file names and may not contain path separators.
+//
+// Writes go to a temporary file which is renamed once complete, so that
+// concurrent readers (possibly on other machines sharing the file system)
+// never observe partially written objects.
+func NewFileStore(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+type fileStore struct {
+	dir string
+}
+
+func (s *fileStore) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+	return filepath.Join(s.dir, key), nil
+}
+
+func (s *fileStore) Put(key string, v io.WriterTo) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(s.dir, "."+key+".tmp*")
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	_, err = v.WriteTo(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("encode %q: %w", key, err)
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (s *fileStore) Get(key string, v io.ReaderFrom) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%q: %w", key, ErrNotFound)
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := v.ReadFrom(bufio.NewReader(f)); err != nil {
+		return fmt.Errorf("decode %q: %w", key, err)
+	}
+	return nil
+}
+
+func (s *fileStore) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
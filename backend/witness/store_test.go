@@ -0,0 +1,44 @@
+package witness_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	fileStore, err := witness.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	for name, store := range map[string]witness.Store{
+		"memory": witness.NewMemoryStore(),
+		"file":   fileStore,
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+
+			w, err := frontend.NewWitness(&circuit{X: 42, Y: 8000, E: 1}, ecc.BN254.ScalarField())
+			assert.NoError(err)
+
+			assert.NoError(store.Put("w", w))
+
+			reconstructed, err := witness.New(ecc.BN254.ScalarField())
+			assert.NoError(err)
+			assert.NoError(store.Get("w", reconstructed))
+			assert.Equal(w.Vector(), reconstructed.Vector())
+
+			assert.NoError(store.Delete("w"))
+			err = store.Get("w", reconstructed)
+			assert.True(errors.Is(err, witness.ErrNotFound))
+			assert.NoError(store.Delete("w"))
+		})
+	}
+
+	w, err := frontend.NewWitness(&circuit{X: 42, Y: 8000, E: 1}, ecc.BN254.ScalarField())
+	require.NoError(t, err)
+	require.Error(t, fileStore.Put("../w", w), "file store must reject keys with path separators")
+}
@@ -247,3 +247,26 @@ func resize(v any, n int) any {
 		panic("invalid input")
 	}
 }
+
+func field(v any) *big.Int {
+	switch v.(type) {
+	case fr_bn254.Vector:
+		return ecc.BN254.ScalarField()
+	case fr_bls12377.Vector:
+		return ecc.BLS12_377.ScalarField()
+	case fr_bls12381.Vector:
+		return ecc.BLS12_381.ScalarField()
+	case fr_bw6761.Vector:
+		return ecc.BW6_761.ScalarField()
+	case fr_bls24317.Vector:
+		return ecc.BLS24_317.ScalarField()
+	case fr_bls24315.Vector:
+		return ecc.BLS24_315.ScalarField()
+	case fr_bw6633.Vector:
+		return ecc.BW6_633.ScalarField()
+	case tinyfield.Vector:
+		return tinyfield.Modulus()
+	default:
+		panic("invalid input")
+	}
+}
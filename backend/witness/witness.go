@@ -34,6 +34,10 @@
 //   - `[uint32(1)|uint32(2)|uint32(3)|bytes(Y)|bytes(X)|bytes(Z)]`
 //   - Hex representation with values `Y = 35`, `X = 3`, `Z = 2`
 //     `000000010000000200000003000000000000000000000000000000000000000000000000000000000000002300000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000002`
+//
+// The ordering only depends on the circuit structure, not on the arithmetization. A witness
+// built once from an assignment can be passed to both the Groth16 (R1CS) and the PLONK
+// (SparseR1CS) provers of the same circuit, and its public part to both verifiers.
 package witness
 
 import (
@@ -78,6 +82,9 @@ type Witness interface {
 	// Public returns the Public an object containing the public part of the Witness only.
 	Public() (Witness, error)
 
+	// Secret returns an object containing the secret part of the Witness only.
+	Secret() (Witness, error)
+
 	// Vector returns the underlying fr.Vector slice
 	Vector() any
 
@@ -159,6 +166,20 @@ func (w *witness) Public() (Witness, error) {
 	}, nil
 }
 
+func (w *witness) Secret() (Witness, error) {
+	values := w.iterate()
+	for i := uint32(0); i < w.nbPublic; i++ {
+		<-values
+	}
+	res := &witness{
+		vector: resize(w.vector, 0),
+	}
+	if err := res.Fill(0, int(w.nbSecret), values); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (w *witness) WriteTo(wr io.Writer) (n int64, err error) {
 	// write number of public, number of secret
 	if err := binary.Write(wr, binary.BigEndian, w.nbPublic); err != nil {
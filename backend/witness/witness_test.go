@@ -3,7 +3,9 @@ package witness_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -110,6 +112,32 @@ func TestPublic(t *testing.T) {
 	assert.Equal("8000", wt[1].String())
 }
 
+func TestSecret(t *testing.T) {
+	assert := require.New(t)
+
+	var assignment circuit
+	assignment.X = new(fr.Element).SetInt64(42)
+	assignment.Y = new(fr.Element).SetInt64(8000)
+	assignment.E = new(fr.Element).SetInt64(1)
+
+	w, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	secretW, err := w.Secret()
+	assert.NoError(err)
+
+	wt := secretW.Vector().(fr.Vector)
+
+	assert.Equal(1, len(wt))
+	assert.Equal("1", wt[0].String())
+
+	publicW, err := w.Public()
+	assert.NoError(err)
+	secretW, err = publicW.Secret()
+	assert.NoError(err)
+	assert.Equal(0, len(secretW.Vector().(fr.Vector)))
+}
+
 func roundTripMarshal(assert *require.Assertions, assignment circuit, publicOnly bool) {
 	var opts []frontend.WitnessOption
 	if publicOnly {
@@ -181,3 +209,33 @@ func TestVariableInitHook(t *testing.T) {
 	assert.True(ok)
 	assert.Len(fw, 10, "invalid length")
 }
+
+func TestPublicInputRange(t *testing.T) {
+	assert := require.New(t)
+	field := ecc.BN254.ScalarField()
+
+	// e.g. a keccak digest, which is larger than the modulus
+	digest := new(big.Int).Lsh(big.NewInt(1), 255)
+	assignment := &circuit{X: digest, Y: 1, E: 1}
+
+	_, err := frontend.NewWitness(assignment, field)
+	var rangeErr *frontend.PublicInputRangeError
+	assert.True(errors.As(err, &rangeErr), "unexpected error: %v", err)
+	assert.Equal("X", rangeErr.Name)
+	assert.Equal(0, rangeErr.Value.Cmp(digest))
+
+	// the modulus itself isn't canonical either
+	_, err = frontend.NewWitness(&circuit{X: 1, Y: new(big.Int).Set(field), E: 1}, field, frontend.PublicOnly())
+	assert.True(errors.As(err, &rangeErr), "unexpected error: %v", err)
+	assert.Equal("Y", rangeErr.Name)
+
+	// secret inputs are not checked
+	_, err = frontend.NewWitness(&circuit{X: 1, Y: 1, E: digest}, field)
+	assert.NoError(err)
+
+	w, err := frontend.NewWitness(assignment, field, frontend.ReducePublicInputs())
+	assert.NoError(err)
+	var reduced fr.Element
+	reduced.SetBigInt(digest)
+	assert.Equal(reduced, w.Vector().(fr.Vector)[0])
+}
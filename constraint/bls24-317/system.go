@@ -17,6 +17,9 @@
 package cs
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
 	"github.com/fxamacker/cbor/v2"
 	"io"
 	"time"
@@ -24,6 +27,7 @@ import (
 	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/constraint"
 	csolver "github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/debug"
 	"github.com/consensys/gnark/internal/backend/ioutils"
 	"github.com/consensys/gnark/logger"
 	"reflect"
@@ -66,10 +70,56 @@ func newSystem(capacity int, t constraint.SystemType) *system {
 // If it's a R1CS returns R1CSSolution
 // If it's a SparseR1CS returns SparseR1CSSolution
 func (cs *system) Solve(witness witness.Witness, opts ...csolver.Option) (any, error) {
+	solver, err := cs.solve(witness, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// format the solution
+	// TODO @gbotrel revisit post-refactor
+	if cs.Type == constraint.SystemR1CS {
+		var res R1CSSolution
+		res.W = solver.values
+		res.A = solver.a
+		res.B = solver.b
+		res.C = solver.c
+		return &res, nil
+	} else {
+		// sparse R1CS
+		var res SparseR1CSSolution
+		// query l, r, o in Lagrange basis, not blinded
+		res.L, res.R, res.O = evaluateLROSmallDomain(cs, solver.values)
+
+		return &res, nil
+	}
+
+}
+
+// SolveExtended solves the constraint system with provided witness and returns
+// the assignment of all the wires of the system as an *ExtendedWitness.
+func (cs *system) SolveExtended(witness witness.Witness, opts ...csolver.Option) (constraint.ExtendedWitness, error) {
+	solver, err := cs.solve(witness, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ExtendedWitness{
+		NbPublic:   len(cs.Public),
+		NbSecret:   len(cs.Secret),
+		NbInternal: cs.NbInternalVariables,
+		W:          solver.values,
+	}, nil
+}
+
+// solve runs the solver on the provided witness and returns it once all the
+// wires are computed.
+func (cs *system) solve(witness witness.Witness, opts ...csolver.Option) (*solver, error) {
 	log := logger.Logger().With().Int("nbConstraints", cs.GetNbConstraints()).Logger()
 	start := time.Now()
 
-	v := witness.Vector().(fr.Vector)
+	v, ok := witness.Vector().(fr.Vector)
+	if !ok {
+		return nil, csolver.ErrCurveMismatch
+	}
 
 	// init the solver
 	solver, err := newSolver(cs, v, opts...)
@@ -97,24 +147,7 @@ func (cs *system) Solve(witness witness.Witness, opts ...csolver.Option) (any, e
 
 	log.Debug().Dur("took", time.Since(start)).Msg("constraint system solver done")
 
-	// format the solution
-	// TODO @gbotrel revisit post-refactor
-	if cs.Type == constraint.SystemR1CS {
-		var res R1CSSolution
-		res.W = solver.values
-		res.A = solver.a
-		res.B = solver.b
-		res.C = solver.c
-		return &res, nil
-	} else {
-		// sparse R1CS
-		var res SparseR1CSSolution
-		// query l, r, o in Lagrange basis, not blinded
-		res.L, res.R, res.O = evaluateLROSmallDomain(cs, solver.values)
-
-		return &res, nil
-	}
-
+	return solver, nil
 }
 
 // IsSolved
@@ -198,6 +231,26 @@ func (cs *system) ReadFrom(r io.Reader) (int64, error) {
 	return int64(decoder.NumBytesRead()), nil
 }
 
+// Hash returns a canonical hash of the constraint system. The hash covers the
+// instructions, blueprints, coefficients, inputs and commitment information, but
+// not the debug information, logs or the gnark version, so that it identifies the
+// circuit and can be used to check that a proving or verifying key on disk
+// corresponds to it.
+func (cs *system) Hash() ([]byte, error) {
+	shape := *cs
+	shape.GnarkVersion = ""
+	shape.Logs = nil
+	shape.DebugInfo = nil
+	shape.SymbolTable = debug.SymbolTable{}
+	shape.MDebug = nil
+
+	h := sha256.New()
+	if _, err := shape.WriteTo(h); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
 func (cs *system) GetCoefficient(i int) (r constraint.Element) {
 	copy(r[:], cs.Coefficients[i][:])
 	return
@@ -351,6 +404,84 @@ func (t *SparseR1CSSolution) ReadFrom(r io.Reader) (int64, error) {
 	return n, err
 }
 
+// ExtendedWitness is the assignment of all the wires of a solved constraint
+// system, ordered as [ public | secret | internal ]. It allows external provers to
+// take over after witness generation.
+//
+// The binary encoding (see WriteTo) is, with all integers big endian:
+//
+//	nbPublic   uint32
+//	nbSecret   uint32
+//	nbInternal uint32
+//	len(W)     uint32
+//	W          len(W) field elements, each encoded on fr.Bytes bytes in regular (non-Montgomery) big endian form
+type ExtendedWitness struct {
+	NbPublic, NbSecret, NbInternal int
+	W                              fr.Vector
+}
+
+// Public returns the assignment of the public wires.
+func (t *ExtendedWitness) Public() fr.Vector {
+	return t.W[:t.NbPublic]
+}
+
+// Secret returns the assignment of the secret wires.
+func (t *ExtendedWitness) Secret() fr.Vector {
+	return t.W[t.NbPublic : t.NbPublic+t.NbSecret]
+}
+
+// Internal returns the assignment of the internal wires.
+func (t *ExtendedWitness) Internal() fr.Vector {
+	return t.W[t.NbPublic+t.NbSecret:]
+}
+
+// Vector returns the underlying fr.Vector.
+func (t *ExtendedWitness) Vector() any {
+	return t.W
+}
+
+// WriteTo encodes the extended witness into provided io.Writer (see ExtendedWitness for the format).
+func (t *ExtendedWitness) WriteTo(w io.Writer) (int64, error) {
+	if t.NbPublic+t.NbSecret+t.NbInternal != len(t.W) {
+		return 0, errors.New("inconsistent extended witness size")
+	}
+	var buf [12]byte
+	binary.BigEndian.PutUint32(buf[0:4], uint32(t.NbPublic))
+	binary.BigEndian.PutUint32(buf[4:8], uint32(t.NbSecret))
+	binary.BigEndian.PutUint32(buf[8:12], uint32(t.NbInternal))
+	m, err := w.Write(buf[:])
+	n := int64(m)
+	if err != nil {
+		return n, err
+	}
+	a, err := t.W.WriteTo(w)
+	n += a
+	return n, err
+}
+
+// ReadFrom decodes an extended witness from provided io.Reader and checks its
+// wire counts are consistent with the number of field elements read.
+func (t *ExtendedWitness) ReadFrom(r io.Reader) (int64, error) {
+	var buf [12]byte
+	m, err := io.ReadFull(r, buf[:])
+	n := int64(m)
+	if err != nil {
+		return n, err
+	}
+	t.NbPublic = int(binary.BigEndian.Uint32(buf[0:4]))
+	t.NbSecret = int(binary.BigEndian.Uint32(buf[4:8]))
+	t.NbInternal = int(binary.BigEndian.Uint32(buf[8:12]))
+	a, err := t.W.ReadFrom(r)
+	n += a
+	if err != nil {
+		return n, err
+	}
+	if t.NbPublic+t.NbSecret+t.NbInternal != len(t.W) {
+		return n, errors.New("inconsistent extended witness size")
+	}
+	return n, nil
+}
+
 func getTagSet() cbor.TagSet {
 	// temporary for refactor
 	ts := cbor.NewTagSet()
@@ -127,6 +127,23 @@ func TestSerialization(t *testing.T) {
 				if !reflect.DeepEqual(r, r2) {
 					t.Fatal("compilation of R1CS is not deterministic (reconstruction)")
 				}
+
+				// ensure the hash is stable across compilation and serialization
+				h1, err := r1cs1.Hash()
+				if err != nil {
+					t.Fatal(err)
+				}
+				h2, err := r1cs2.Hash()
+				if err != nil {
+					t.Fatal(err)
+				}
+				h3, err := r.Hash()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(h1, h2) || !bytes.Equal(h1, h3) {
+					t.Fatal("hash of R1CS is not deterministic")
+				}
 			}
 		})
 
@@ -148,6 +165,69 @@ func (circuit *circuit) Define(api frontend.API) error {
 	return nil
 }
 
+func TestExtendedWitnessSerialization(t *testing.T) {
+	testExtendedWitnessSerialization(t, r1cs.NewBuilder)
+}
+
+// testExtendedWitnessSerialization solves circuit with the system produced by newBuilder
+// and checks the layout and the binary round trip of the extended witness.
+func testExtendedWitnessSerialization(t *testing.T, newBuilder frontend.NewBuilder) {
+	if testing.Short() {
+		t.Skip("skipping extended witness serialization in short mode")
+	}
+	var x, c42 fr.Element
+	x.SetOne()
+	c42.SetUint64(42)
+	for i := 0; i < n; i++ {
+		var xx fr.Element
+		xx.Mul(&x, &x)
+		x.Add(&xx, &x).Add(&x, &c42)
+	}
+	var w, c circuit
+	w.X = 1
+	w.Y = x
+	witness, err := frontend.NewWitness(&w, fr.Modulus())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ccs, err := frontend.Compile(fr.Modulus(), newBuilder, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ext, err := ccs.SolveExtended(witness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ext := _ext.(*cs.ExtendedWitness)
+	if !reflect.DeepEqual(ext.Vector(), ext.W) {
+		t.Fatal("extended witness vector mismatch")
+	}
+	nbInternal, nbSecret, nbPublic := ccs.GetNbVariables()
+	if len(ext.Public()) != nbPublic || len(ext.Secret()) != nbSecret || len(ext.Internal()) != nbInternal {
+		t.Fatal("unexpected extended witness layout")
+	}
+	if !ext.Public()[nbPublic-1].Equal(&x) || !ext.Secret()[0].IsOne() {
+		t.Fatal("extended witness does not start with the witness values")
+	}
+
+	var buffer bytes.Buffer
+	written, err := ext.WriteTo(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reconstructed cs.ExtendedWitness
+	read, err := reconstructed.ReadFrom(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != read {
+		t.Fatal("didn't read same number of bytes we wrote")
+	}
+	if !reflect.DeepEqual(ext, &reconstructed) {
+		t.Fatal("extended witness round trip mismatch")
+	}
+}
+
 func BenchmarkSolve(b *testing.B) {
 
 	var w circuit
@@ -94,6 +94,11 @@ type System struct {
 	// input wires names
 	Public, Secret []string
 
+	// InputPaths are the paths of the input wires in the circuit struct, such
+	// as Transfers[1].Signature.S, indexed by wire. A path is empty when the
+	// input isn't defined by a field of the circuit struct.
+	InputPaths []string
+
 	// logs (added with system.Println, resolved when solver sets a value to a wire)
 	Logs []LogEntry
 
@@ -247,6 +252,15 @@ func (system *System) AddSecretVariable(name string) (idx int) {
 	return idx
 }
 
+// SetInputPath sets the path of the input wire vID in the circuit struct, such
+// as Transfers[1].Signature.S.
+func (system *System) SetInputPath(vID int, path string) {
+	if vID >= len(system.InputPaths) {
+		system.InputPaths = append(system.InputPaths, make([]string, vID+1-len(system.InputPaths))...)
+	}
+	system.InputPaths[vID] = path
+}
+
 func (system *System) AddSolverHint(f solver.Hint, id solver.HintID, input []LinearExpression, nbOutput int) (internalVariables []int, err error) {
 	if nbOutput <= 0 {
 		return nil, fmt.Errorf("hint function must return at least one output")
@@ -431,6 +445,64 @@ func (cs *System) CheckUnconstrainedWires() error {
 	return nil
 }
 
+// PublicInputNames returns the names of the public inputs, in the order of the
+// public witness. The constant ONE wire of R1CS systems isn't an input.
+func (cs *System) PublicInputNames() []string {
+	names := cs.Public
+	if cs.Type == SystemR1CS && len(names) > 0 {
+		names = names[1:]
+	}
+	return append([]string(nil), names...)
+}
+
+// UnusedSecretInputs returns the names of the secret inputs which are not
+// referenced with a non-zero coefficient in any constraint. The secret inputs
+// which are only used as inputs to hints are also reported as unused.
+func (cs *System) UnusedSecretInputs() []string {
+	nbPublic := cs.GetNbPublicVariables()
+	used := make([]bool, cs.GetNbSecretVariables())
+	mark := func(wireID uint32) {
+		if i := int(wireID) - nbPublic; i >= 0 && i < len(used) {
+			used[i] = true
+		}
+	}
+
+	switch cs.Type {
+	case SystemR1CS:
+		it := cs.GetR1CIterator()
+		for c := it.Next(); c != nil; c = it.Next() {
+			for _, l := range [3]LinearExpression{c.L, c.R, c.O} {
+				for _, t := range l {
+					if t.CoeffID() != CoeffIdZero {
+						mark(t.VID)
+					}
+				}
+			}
+		}
+	case SystemSparseR1CS:
+		it := cs.GetSparseR1CIterator()
+		for c := it.Next(); c != nil; c = it.Next() {
+			if c.QL != CoeffIdZero || c.QM != CoeffIdZero {
+				mark(c.XA)
+			}
+			if c.QR != CoeffIdZero || c.QM != CoeffIdZero {
+				mark(c.XB)
+			}
+			if c.QO != CoeffIdZero {
+				mark(c.XC)
+			}
+		}
+	}
+
+	var unused []string
+	for i := range used {
+		if !used[i] {
+			unused = append(unused, cs.Secret[i])
+		}
+	}
+	return unused
+}
+
 func (cs *System) GetR1CIterator() R1CIterator {
 	return R1CIterator{cs: cs}
 }
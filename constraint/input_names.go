@@ -0,0 +1,130 @@
+package constraint
+
+import (
+	"sort"
+	"sync"
+)
+
+// InputNames returns the names of the public and secret inputs the wires are
+// or depend on, the internal wires being traced back through the instructions
+// solving them. The names are the paths of the inputs in the circuit struct,
+// such as Transfers[1].Signature.S, or the names of the inputs if the system
+// doesn't record their paths.
+func (system *System) InputNames(wires ...uint32) []string {
+	return system.NewInputTracer().InputNames(wires...)
+}
+
+// InputTracer returns the names of the inputs wires depend on, as
+// [System.InputNames]. The wires referenced by the instructions are replayed
+// on the first call only, so that tracing many wires doesn't replay the whole
+// system each time.
+type InputTracer struct {
+	system  *System
+	once    sync.Once
+	parents [][]uint32
+}
+
+// NewInputTracer returns an InputTracer of the system. It is safe for
+// concurrent use.
+func (system *System) NewInputTracer() *InputTracer {
+	return &InputTracer{system: system}
+}
+
+// InputNames returns the names of the public and secret inputs the wires are
+// or depend on, see [System.InputNames].
+func (t *InputTracer) InputNames(wires ...uint32) []string {
+	system := t.system
+	offset := system.internalWireOffset()
+	var parents [][]uint32
+	if len(wires) > 0 {
+		t.once.Do(func() {
+			t.parents = system.wireParents()
+		})
+		parents = t.parents
+	}
+
+	var inputs []int
+	seen := make(map[uint32]struct{})
+	for len(wires) > 0 {
+		w := wires[len(wires)-1]
+		wires = wires[:len(wires)-1]
+		if _, ok := seen[w]; ok {
+			continue
+		}
+		seen[w] = struct{}{}
+		if w < offset {
+			if system.Type == SystemR1CS && w == 0 {
+				continue // ONE_WIRE
+			}
+			inputs = append(inputs, int(w))
+		} else if int(w-offset) < len(parents) {
+			wires = append(wires, parents[w-offset]...)
+		}
+	}
+
+	sort.Ints(inputs)
+	names := make([]string, len(inputs))
+	for i, vID := range inputs {
+		names[i] = system.inputName(vID)
+	}
+	return names
+}
+
+// wireParents returns, for each internal wire, the wires referenced by the
+// instruction solving it. It replays the instructions of the system through
+// the blueprints' UpdateInstructionTree, as when building the levels.
+func (system *System) wireParents() [][]uint32 {
+	r := parentsRecorder{
+		offset:  system.internalWireOffset(),
+		levels:  make([]Level, system.NbInternalVariables),
+		parents: make([][]uint32, system.NbInternalVariables),
+	}
+	for i := range r.levels {
+		r.levels[i] = LevelUnset
+	}
+	for i, pi := range system.Instructions {
+		r.referenced = nil
+		system.Blueprints[pi.BlueprintID].UpdateInstructionTree(system.GetInstruction(i), &r)
+	}
+	return r.parents
+}
+
+// parentsRecorder is an InstructionTree recording the wires an instruction
+// references when inserting its output wires.
+type parentsRecorder struct {
+	offset     uint32
+	levels     []Level
+	parents    [][]uint32
+	referenced []uint32
+}
+
+func (r *parentsRecorder) InsertWire(wire uint32, level Level) {
+	r.levels[wire-r.offset] = level
+	r.parents[wire-r.offset] = r.referenced
+}
+
+func (r *parentsRecorder) HasWire(wire uint32) bool {
+	if wire < r.offset {
+		r.referenced = append(r.referenced, wire)
+		return false
+	}
+	if wire-r.offset >= uint32(len(r.levels)) {
+		// constant
+		return false
+	}
+	r.referenced = append(r.referenced, wire)
+	return true
+}
+
+func (r *parentsRecorder) GetWireLevel(wire uint32) Level {
+	return r.levels[wire-r.offset]
+}
+
+// inputName returns the path of the input wire vID in the circuit struct, or
+// its name if the path isn't known.
+func (system *System) inputName(vID int) string {
+	if vID < len(system.InputPaths) && system.InputPaths[vID] != "" {
+		return system.InputPaths[vID]
+	}
+	return system.VariableToString(vID)
+}
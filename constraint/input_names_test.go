@@ -0,0 +1,43 @@
+package constraint_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+type inputNamesCircuit struct {
+	Key   frontend.Variable `gnark:"pub_key,public"`
+	Sig_R frontend.Variable
+	A_1   [2]frontend.Variable
+	Pairs [2]struct {
+		X_0 frontend.Variable `gnark:"x_0"`
+	}
+}
+
+func (c *inputNamesCircuit) Define(api frontend.API) error {
+	s := api.Add(c.Key, c.Sig_R, c.A_1[0], c.A_1[1], c.Pairs[0].X_0, c.Pairs[1].X_0)
+	api.AssertIsEqual(s, 0)
+	return nil
+}
+
+func TestInputNames(t *testing.T) {
+	assert := require.New(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &inputNamesCircuit{})
+	assert.NoError(err)
+	system := ccs.(*cs.R1CS)
+
+	// the underscores and the digits of the names are kept, wire 0 is the ONE
+	// wire
+	assert.Equal([]string{"pub_key", "Sig_R", "A_1[0]", "A_1[1]", "Pairs[0].x_0", "Pairs[1].x_0"},
+		system.InputNames(0, 1, 2, 3, 4, 5, 6))
+
+	// the schema names are reported if the system doesn't record the paths
+	system.InputPaths = nil
+	assert.Equal([]string{"pub_key", "Sig_R", "A_1_0", "Pairs_1_x_0"}, system.InputNames(1, 2, 3, 6))
+}
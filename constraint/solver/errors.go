@@ -0,0 +1,20 @@
+package solver
+
+import "github.com/consensys/gnark/internal/errs"
+
+var (
+	// ErrUnsatisfiedConstraint is the error wrapped by the errors returned by
+	// the solver when the witness does not satisfy a constraint of the system.
+	// Use [errors.Is] to check for it.
+	ErrUnsatisfiedConstraint = errs.ErrUnsatisfiedConstraint
+
+	// ErrInvalidWitnessSize is the error wrapped by the errors returned by the
+	// solver when the number of values in the witness doesn't match the number
+	// of inputs of the system.
+	ErrInvalidWitnessSize = errs.ErrInvalidWitnessSize
+
+	// ErrCurveMismatch is the error wrapped by the errors returned when objects
+	// defined over different curves (e.g. a witness and a constraint system)
+	// are used together.
+	ErrCurveMismatch = errs.ErrCurveMismatch
+)
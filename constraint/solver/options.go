@@ -18,6 +18,8 @@ type Config struct {
 	HintFunctions map[HintID]Hint // defaults to all built-in hint functions
 	Logger        zerolog.Logger  // defaults to gnark.Logger
 	NbTasks       int             // defaults to runtime.NumCPU()
+
+	IgnoreUnsatisfiedConstraints bool // defaults to false
 }
 
 // WithHints is a solver option that specifies additional hint functions to be used
@@ -79,6 +81,18 @@ func WithNbTasks(nbTasks int) Option {
 	}
 }
 
+// WithIgnoreUnsatisfiedConstraints makes the solver go on when a constraint is
+// not satisfied, instead of returning an error. The wires which can't be
+// computed because of an unsatisfied constraint are set to zero. The solution
+// then doesn't satisfy the constraint system, and a proof computed from it is
+// invalid: this is only useful to test the soundness of verifiers.
+func WithIgnoreUnsatisfiedConstraints() Option {
+	return func(opt *Config) error {
+		opt.IgnoreUnsatisfiedConstraints = true
+		return nil
+	}
+}
+
 // NewConfig returns a default SolverConfig with given prover options opts applied.
 func NewConfig(opts ...Option) (Config, error) {
 	log := logger.Logger()
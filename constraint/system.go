@@ -25,6 +25,11 @@ type ConstraintSystem interface {
 	// Returns a typed solution (R1CSSolution or SparseR1CSSolution) and nil otherwise.
 	Solve(witness witness.Witness, opts ...solver.Option) (any, error)
 
+	// SolveExtended attempts to solve the constraint system using provided witness.
+	// Returns an error if the witness does not allow all the constraints to be satisfied.
+	// Returns the assignment of all the wires (public, secret and internal) and nil otherwise.
+	SolveExtended(witness witness.Witness, opts ...solver.Option) (ExtendedWitness, error)
+
 	// GetNbVariables return number of internal, secret and public Variables
 	// Deprecated: use GetNbSecretVariables() instead
 	GetNbVariables() (internal, secret, public int)
@@ -42,6 +47,8 @@ type ConstraintSystem interface {
 
 	AddPublicVariable(name string) int
 	AddSecretVariable(name string) int
+	// SetInputPath sets the path of an input wire in the circuit struct
+	SetInputPath(vID int, path string)
 	AddInternalVariable() int
 
 	// AddSolverHint adds a hint to the solver such that the output variables will be computed
@@ -75,9 +82,32 @@ type ConstraintSystem interface {
 	// This is experimental.
 	CheckUnconstrainedWires() error
 
+	// UnusedSecretInputs returns the names of the secret inputs which are not
+	// referenced in any constraint.
+	UnusedSecretInputs() []string
+
+	// PublicInputNames returns the names of the public inputs, in the order of
+	// the public witness.
+	PublicInputNames() []string
+
 	GetInstruction(int) Instruction
 
 	GetCoefficient(i int) Element
+
+	// Hash returns a canonical hash of the constraint system, independent of
+	// the debug information and gnark version. It can be used to check that a
+	// proving or verifying key corresponds to the compiled circuit.
+	Hash() ([]byte, error)
+}
+
+// ExtendedWitness is the assignment of all the wires of a solved constraint system.
+// The concrete type is the curve specific *ExtendedWitness (cs/...).
+type ExtendedWitness interface {
+	io.WriterTo
+	io.ReaderFrom
+
+	// Vector returns the underlying fr.Vector, ordered as [ public | secret | internal ].
+	Vector() any
 }
 
 type CustomizableSystem interface {
@@ -50,6 +50,13 @@ type solver struct {
 	logger  zerolog.Logger
 	nbTasks int
 
+	// if set, unsatisfied constraints are counted instead of stopping the solver
+	ignoreUnsatisfied bool
+	nbUnsatisfied     uint64
+
+	// traces the wires of the unsatisfied constraints back to the inputs
+	inputs *constraint.InputTracer
+
 	a, b, c fr.Vector // R1CS solver will compute the a,b,c matrices
 
 	q *big.Int
@@ -72,7 +79,7 @@ func newSolver(cs *system, witness fr.Vector, opts ...csolver.Option) (*solver,
 	expectedWitnessSize := len(cs.Public) - witnessOffset + len(cs.Secret)
 
 	if len(witness) != expectedWitnessSize {
-		return nil, fmt.Errorf("invalid witness size, got %d, expected %d", len(witness), expectedWitnessSize)
+		return nil, fmt.Errorf("%w, got %d, expected %d", csolver.ErrInvalidWitnessSize, len(witness), expectedWitnessSize)
 	}
 
 	// check all hints are there
@@ -91,13 +98,15 @@ func newSolver(cs *system, witness fr.Vector, opts ...csolver.Option) (*solver,
 	}
 
 	s := solver{
-		system:          cs,
-		values:          make([]fr.Element, nbWires),
-		solved:          make([]bool, nbWires),
-		mHintsFunctions: hintFunctions,
-		logger:          opt.Logger,
-		nbTasks:         opt.NbTasks,
-		q:               cs.Field(),
+		system:            cs,
+		values:            make([]fr.Element, nbWires),
+		solved:            make([]bool, nbWires),
+		mHintsFunctions:   hintFunctions,
+		logger:            opt.Logger,
+		nbTasks:           opt.NbTasks,
+		ignoreUnsatisfied: opt.IgnoreUnsatisfiedConstraints,
+		inputs:            cs.NewInputTracer(),
+		q:                 cs.Field(),
 	}
 
 	// set the witness indexes as solved
@@ -399,6 +408,20 @@ func (solver *solver) processInstruction(pi constraint.PackedInstruction, scratc
 	// blueprint declared "I know how to solve this."
 	if bc, ok := blueprint.(constraint.BlueprintSolvable); ok {
 		if err := bc.Solve(solver, inst); err != nil {
+			if bs, ok := blueprint.(constraint.BlueprintSparseR1C); ok {
+				var c constraint.SparseR1C
+				bs.DecompressSparseR1C(&c, inst)
+				if err := solver.unsatisfied(cID, func() error { return err }, sparseR1CTerms(&c)); err != nil {
+					return err
+				}
+				// the wire left unsolved by the constraint is set to zero
+				for _, vID := range [3]uint32{c.XA, c.XB, c.XC} {
+					if !solver.solved[vID] {
+						solver.set(int(vID), fr.Element{})
+					}
+				}
+				return nil
+			}
 			return solver.wrapErrWithDebugInfo(cID, err)
 		}
 		return nil
@@ -521,9 +544,25 @@ func (solver *solver) run() error {
 		return errors.New("solver didn't assign a value to all wires")
 	}
 
+	if solver.nbUnsatisfied != 0 {
+		solver.logger.Warn().Uint64("nbUnsatisfied", solver.nbUnsatisfied).Msg("ignored unsatisfied constraints, the solution is invalid")
+	}
+
 	return nil
 }
 
+// unsatisfied returns the error of the unsatisfied constraint cID, or counts
+// it and returns nil if the solver ignores unsatisfied constraints. The error
+// is built by err, and wrapped with the debug info and the inputs of the
+// terms, only when it is returned.
+func (solver *solver) unsatisfied(cID uint32, err func() error, terms ...constraint.LinearExpression) error {
+	if solver.ignoreUnsatisfied {
+		atomic.AddUint64(&solver.nbUnsatisfied, 1)
+		return nil
+	}
+	return solver.wrapErrWithDebugInfo(cID, err(), terms...)
+}
+
 // solveR1C compute unsolved wires in the constraint, if any and set the solver accordingly
 //
 // returns an error if the solver called a hint function that errored
@@ -561,13 +600,17 @@ func (solver *solver) solveR1C(cID uint32, r *constraint.R1C) error {
 	processLExp(r.R, b, 2)
 	processLExp(r.O, c, 3)
 
+	notEqual := func() error {
+		return fmt.Errorf("%s ⋅ %s != %s", a.String(), b.String(), c.String())
+	}
+
 	if loc == 0 {
 		// there is nothing to solve, may happen if we have an assertion
 		// (ie a constraints that doesn't yield any output)
 		// or if we solved the unsolved wires with hint functions
 		var check fr.Element
 		if !check.Mul(a, b).Equal(c) {
-			return solver.wrapErrWithDebugInfo(cID, fmt.Errorf("%s ⋅ %s != %s", a.String(), b.String(), c.String()))
+			return solver.unsatisfied(cID, notEqual, r.L, r.R, r.O)
 		}
 		return nil
 	}
@@ -588,7 +631,10 @@ func (solver *solver) solveR1C(cID uint32, r *constraint.R1C) error {
 			// we didn't actually ensure that a * b == c
 			var check fr.Element
 			if !check.Mul(a, b).Equal(c) {
-				return solver.wrapErrWithDebugInfo(cID, fmt.Errorf("%s ⋅ %s != %s", a.String(), b.String(), c.String()))
+				// if ignored, the wire is set to zero
+				if err := solver.unsatisfied(cID, notEqual, r.L, r.R, r.O); err != nil {
+					return err
+				}
 			}
 		}
 	case 2:
@@ -599,7 +645,10 @@ func (solver *solver) solveR1C(cID uint32, r *constraint.R1C) error {
 		} else {
 			var check fr.Element
 			if !check.Mul(a, b).Equal(c) {
-				return solver.wrapErrWithDebugInfo(cID, fmt.Errorf("%s ⋅ %s != %s", a.String(), b.String(), c.String()))
+				// if ignored, the wire is set to zero
+				if err := solver.unsatisfied(cID, notEqual, r.L, r.R, r.O); err != nil {
+					return err
+				}
 			}
 		}
 	case 3:
@@ -621,24 +670,71 @@ func (solver *solver) solveR1C(cID uint32, r *constraint.R1C) error {
 // UnsatisfiedConstraintError wraps an error with useful metadata on the unsatisfied constraint
 type UnsatisfiedConstraintError struct {
 	Err       error
-	CID       int     // constraint ID
-	DebugInfo *string // optional debug info
+	CID       int      // constraint ID
+	DebugInfo *string  // optional debug info
+	Inputs    []string // names of the public and secret inputs the constraint depends on
 }
 
 func (r *UnsatisfiedConstraintError) Error() string {
+	msg := r.Err.Error()
 	if r.DebugInfo != nil {
-		return fmt.Sprintf("constraint #%d is not satisfied: %s", r.CID, *r.DebugInfo)
+		msg = *r.DebugInfo
+	}
+	if len(r.Inputs) > 0 {
+		return fmt.Sprintf("constraint #%d is not satisfied: %s (inputs: %s)", r.CID, msg, strings.Join(r.Inputs, ", "))
 	}
-	return fmt.Sprintf("constraint #%d is not satisfied: %s", r.CID, r.Err.Error())
+	return fmt.Sprintf("constraint #%d is not satisfied: %s", r.CID, msg)
 }
 
-func (solver *solver) wrapErrWithDebugInfo(cID uint32, err error) *UnsatisfiedConstraintError {
+// Is returns true if target is [csolver.ErrUnsatisfiedConstraint].
+func (r *UnsatisfiedConstraintError) Is(target error) bool {
+	return target == csolver.ErrUnsatisfiedConstraint
+}
+
+// Unwrap returns the underlying error, so that [errors.Is] and [errors.As] can
+// reach the cause of the unsatisfied constraint.
+func (r *UnsatisfiedConstraintError) Unwrap() error {
+	return r.Err
+}
+
+func (solver *solver) wrapErrWithDebugInfo(cID uint32, err error, terms ...constraint.LinearExpression) *UnsatisfiedConstraintError {
 	var debugInfo *string
 	if dID, ok := solver.MDebug[int(cID)]; ok {
 		debugInfo = new(string)
 		*debugInfo = solver.logValue(solver.DebugInfo[dID])
 	}
-	return &UnsatisfiedConstraintError{CID: int(cID), Err: err, DebugInfo: debugInfo}
+	return &UnsatisfiedConstraintError{CID: int(cID), Err: err, DebugInfo: debugInfo, Inputs: solver.inputNames(terms...)}
+}
+
+// inputNames returns the names of the public and secret inputs involved in the
+// terms, directly or through the internal wires depending on them, as Go paths
+// of the circuit struct fields.
+func (solver *solver) inputNames(terms ...constraint.LinearExpression) []string {
+	var wires []uint32
+	for _, l := range terms {
+		for _, t := range l {
+			if t.IsConstant() || t.CoeffID() == constraint.CoeffIdZero {
+				continue
+			}
+			wires = append(wires, t.VID)
+		}
+	}
+	return solver.inputs.InputNames(wires...)
+}
+
+// sparseR1CTerms returns the terms of the wires referenced in the constraint c.
+func sparseR1CTerms(c *constraint.SparseR1C) constraint.LinearExpression {
+	l := make(constraint.LinearExpression, 0, 3)
+	if c.QL != constraint.CoeffIdZero || c.QM != constraint.CoeffIdZero {
+		l = append(l, constraint.Term{CID: constraint.CoeffIdOne, VID: c.XA})
+	}
+	if c.QR != constraint.CoeffIdZero || c.QM != constraint.CoeffIdZero {
+		l = append(l, constraint.Term{CID: constraint.CoeffIdOne, VID: c.XB})
+	}
+	if c.QO != constraint.CoeffIdZero {
+		l = append(l, constraint.Term{CID: constraint.CoeffIdOne, VID: c.XC})
+	}
+	return l
 }
 
 // temporary variables to avoid memallocs in hotloop
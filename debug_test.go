@@ -153,6 +153,53 @@ func TestTraceNotEqual(t *testing.T) {
 	}
 }
 
+// -------------------------------------------------------------------------------------------------
+// Input names
+type inputNamesSignature struct {
+	S frontend.Variable
+}
+
+type inputNamesTransfer struct {
+	Signature inputNamesSignature `gnark:"signature"`
+	Amount    frontend.Variable
+}
+
+type inputNamesTrace struct {
+	Transfers [2]inputNamesTransfer `gnark:"transfer"`
+	Expected  frontend.Variable     `gnark:",public"`
+}
+
+func (circuit *inputNamesTrace) Define(api frontend.API) error {
+	// the internal wire s depends on the inputs of the second transfer only
+	s := api.Mul(circuit.Transfers[1].Signature.S, circuit.Transfers[1].Amount)
+	s = api.Add(s, 1)
+	api.AssertIsEqual(s, circuit.Expected)
+	return nil
+}
+
+func TestTraceInputNames(t *testing.T) {
+	assert := require.New(t)
+
+	var circuit, witness inputNamesTrace
+	witness.Transfers[0].Signature.S = 1
+	witness.Transfers[0].Amount = 1
+	witness.Transfers[1].Signature.S = 2
+	witness.Transfers[1].Amount = 3
+	witness.Expected = 3
+
+	{
+		_, err := getGroth16Trace(&circuit, &witness)
+		assert.Error(err)
+		assert.Contains(err.Error(), "(inputs: Expected, transfer[1].signature.S, transfer[1].Amount)")
+	}
+
+	{
+		_, err := getPlonkTrace(&circuit, &witness)
+		assert.Error(err)
+		assert.Contains(err.Error(), "(inputs: Expected, transfer[1].signature.S, transfer[1].Amount)")
+	}
+}
+
 func getPlonkTrace(circuit, w frontend.Circuit) (string, error) {
 	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, circuit)
 	if err != nil {
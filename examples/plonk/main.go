@@ -1,3 +1,5 @@
+//go:build !verifier_only
+
 // Copyright 2020 ConsenSys AG
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -1,3 +1,5 @@
+//go:build !verifier_only
+
 package main
 
 import (
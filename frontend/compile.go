@@ -54,7 +54,7 @@ func Compile(field *big.Int, newBuilder NewBuilder, circuit Circuit, opts ...Com
 
 	// parse the circuit builds a schema of the circuit
 	// and call circuit.Define() method to initialize a list of constraints in the compiler
-	if err = parseCircuit(builder, circuit); err != nil {
+	if err = parseCircuit(builder, circuit, opt); err != nil {
 		log.Err(err).Msg("parsing circuit")
 		return nil, fmt.Errorf("parse circuit: %w", err)
 
@@ -64,7 +64,7 @@ func Compile(field *big.Int, newBuilder NewBuilder, circuit Circuit, opts ...Com
 	return builder.Compile()
 }
 
-func parseCircuit(builder Builder, circuit Circuit) (err error) {
+func parseCircuit(builder Builder, circuit Circuit, opt CompileConfig) (err error) {
 	// ensure circuit.Define has pointer receiver
 	if reflect.ValueOf(circuit).Kind() != reflect.Ptr {
 		return errors.New("frontend.Circuit methods must be defined on pointer receiver")
@@ -78,6 +78,18 @@ func parseCircuit(builder Builder, circuit Circuit) (err error) {
 	log := logger.Logger()
 	log.Info().Int("nbSecret", s.Secret).Int("nbPublic", s.Public).Msg("parsed circuit inputs")
 
+	// when hashing the public inputs, the digest is the only public variable
+	// and the declared public inputs are allocated as secret variables
+	// preceding the declared secret inputs.
+	var digest Variable
+	var hashedInputs []Variable
+	if opt.PublicInputsHasher != nil {
+		digest = builder.PublicVariable(schema.LeafInfo{
+			FullName:   func() string { return PublicInputsDigestName },
+			Visibility: schema.Public,
+		})
+	}
+
 	// leaf handlers are called when encoutering leafs in the circuit data struct
 	// leafs are Constraints that need to be initialized in the context of compiling a circuit
 	variableAdder := func(targetVisibility schema.Visibility) func(f schema.LeafInfo, tInput reflect.Value) error {
@@ -87,7 +99,11 @@ func parseCircuit(builder Builder, circuit Circuit) (err error) {
 					return errors.New("can't set val " + f.FullName() + " visibility is unset")
 				}
 				if f.Visibility == targetVisibility {
-					if f.Visibility == schema.Public {
+					if f.Visibility == schema.Public && opt.PublicInputsHasher != nil {
+						v := builder.SecretVariable(f)
+						hashedInputs = append(hashedInputs, v)
+						tInput.Set(reflect.ValueOf(v))
+					} else if f.Visibility == schema.Public {
 						tInput.Set(reflect.ValueOf(builder.PublicVariable(f)))
 					} else if f.Visibility == schema.Secret {
 						tInput.Set(reflect.ValueOf(builder.SecretVariable(f)))
@@ -119,6 +135,14 @@ func parseCircuit(builder Builder, circuit Circuit) (err error) {
 		}
 	}()
 
+	if opt.PublicInputsHasher != nil {
+		h, err := opt.PublicInputsHasher.HashVariables(builder, hashedInputs)
+		if err != nil {
+			return fmt.Errorf("hash public inputs: %w", err)
+		}
+		builder.AssertIsEqual(h, digest)
+	}
+
 	// call Define() to fill in the Constraints
 	if err = circuit.Define(builder); err != nil {
 		return fmt.Errorf("define circuit: %w", err)
@@ -153,7 +177,9 @@ func defaultCompileConfig() CompileConfig {
 type CompileConfig struct {
 	Capacity                  int
 	IgnoreUnconstrainedInputs bool
+	CheckUnusedSecretInputs   bool
 	CompressThreshold         int
+	PublicInputsHasher        PublicInputsHasher
 }
 
 // WithCapacity is a compile option that specifies the estimated capacity needed
@@ -180,6 +206,21 @@ func IgnoreUnconstrainedInputs() CompileOption {
 	}
 }
 
+// CheckUnusedSecretInputs is a compile option which enables the strict check
+// that every secret input is used in at least one constraint. If set, then the
+// compiler returns an error listing the secret inputs which are not used in any
+// constraint. The secret inputs which are only used as inputs to hints are also
+// considered unused.
+//
+// Unused secret inputs usually indicate an error in the circuit definition, as
+// the proof does not depend on their values.
+func CheckUnusedSecretInputs() CompileOption {
+	return func(opt *CompileConfig) error {
+		opt.CheckUnusedSecretInputs = true
+		return nil
+	}
+}
+
 // WithCompressThreshold is a compile option which enforces automatic variable
 // compression if the length of the linear expression in the variable exceeds
 // given threshold.
@@ -203,6 +244,41 @@ func WithCompressThreshold(threshold int) CompileOption {
 	}
 }
 
+// PublicInputsDigestName is the name of the single public input of the
+// circuits compiled with the [WithPublicInputsHash] option.
+const PublicInputsDigestName = "PublicInputsDigest"
+
+// PublicInputsHasher hashes the public inputs of a circuit into a single
+// digest. The in-circuit and native computations must be consistent. See
+// [WithPublicInputsHash] and [PublicInputsHashed].
+type PublicInputsHasher interface {
+	// HashVariables computes the digest of the inputs in-circuit.
+	HashVariables(api API, inputs []Variable) (Variable, error)
+	// HashValues computes the digest of the input values in the field natively.
+	HashValues(field *big.Int, inputs []*big.Int) (*big.Int, error)
+}
+
+// WithPublicInputsHash is a compile option which replaces the public inputs of
+// the circuit with their digest computed using the hasher h. The declared
+// public inputs are allocated as secret inputs (preceding the declared secret
+// inputs) and the digest (named [PublicInputsDigestName]) is the only public
+// input of the compiled circuit. The circuit asserts that the digest of the
+// declared public inputs corresponds to the public digest.
+//
+// This option reduces the cost of the verification when the circuit has many
+// public inputs, for example when verifying on-chain. The witness for such a
+// circuit must be created with the [PublicInputsHashed] witness option using
+// the same hasher.
+func WithPublicInputsHash(h PublicInputsHasher) CompileOption {
+	return func(opt *CompileConfig) error {
+		if h == nil {
+			return errors.New("public inputs hasher is nil")
+		}
+		opt.PublicInputsHasher = h
+		return nil
+	}
+}
+
 var tVariable reflect.Type
 
 func init() {
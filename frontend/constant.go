@@ -0,0 +1,35 @@
+package frontend
+
+import (
+	"fmt"
+)
+
+// Constant wraps a compile-time constant gadget parameter, such as table
+// contents, round constants or curve parameters.
+//
+// Unlike a Variable field, a Constant field in a circuit (or gadget) structure
+// is never walked when parsing the circuit: it is not allocated as a witness
+// input, and doesn't need to be assigned. The wrapped value is returned by
+// Value and is folded by the builders as any other constant.
+//
+// The zero value wraps the zero value of T.
+type Constant[T any] struct {
+	value T
+}
+
+// NewConstant returns a Constant wrapping v. It panics if v is a circuit
+// variable, as it would not be known at compile time.
+func NewConstant[T any](v T) Constant[T] {
+	if IsCanonical(v) {
+		panic(fmt.Sprintf("constant: %v is a circuit variable", v))
+	}
+	return Constant[T]{value: v}
+}
+
+// Value returns the wrapped constant.
+func (c Constant[T]) Value() T {
+	return c.value
+}
+
+// GnarkConstant implements schema.Constant.
+func (Constant[T]) GnarkConstant() {}
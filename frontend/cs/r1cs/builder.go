@@ -18,9 +18,11 @@ package r1cs
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 	"reflect"
 	"sort"
+	"strings"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/constraint"
@@ -141,12 +143,18 @@ func (builder *builder) newInternalVariable() expr.LinearExpression {
 // PublicVariable creates a new public Variable
 func (builder *builder) PublicVariable(f schema.LeafInfo) frontend.Variable {
 	idx := builder.cs.AddPublicVariable(f.FullName())
+	if f.Path != nil {
+		builder.cs.SetInputPath(idx, f.Path())
+	}
 	return expr.NewLinearExpression(idx, builder.tOne)
 }
 
 // SecretVariable creates a new secret Variable
 func (builder *builder) SecretVariable(f schema.LeafInfo) frontend.Variable {
 	idx := builder.cs.AddSecretVariable(f.FullName())
+	if f.Path != nil {
+		builder.cs.SetInputPath(idx, f.Path())
+	}
 	return expr.NewLinearExpression(idx, builder.tOne)
 }
 
@@ -286,6 +294,13 @@ func (builder *builder) Compile() (constraint.ConstraintSystem, error) {
 		}
 	}
 
+	// ensure all secret inputs are used in constraints
+	if builder.config.CheckUnusedSecretInputs {
+		if unused := builder.cs.UnusedSecretInputs(); len(unused) > 0 {
+			return nil, fmt.Errorf("unused secret inputs: %s", strings.Join(unused, ", "))
+		}
+	}
+
 	return builder.cs, nil
 }
 
@@ -19,6 +19,7 @@ package r1cs
 import (
 	"math/rand"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -180,3 +181,56 @@ func TestSubSameNoConstraint(t *testing.T) {
 		t.Fatal("expected 0 constraints")
 	}
 }
+
+type unusedSecretInputsCircuit struct {
+	A, B frontend.Variable
+	C    frontend.Variable `gnark:",public"`
+}
+
+func (c *unusedSecretInputsCircuit) Define(api frontend.API) error {
+	r := api.Sub(c.B, c.B)
+	api.AssertIsEqual(api.Add(c.A, r), c.C)
+	return nil
+}
+
+func TestUnusedSecretInputs(t *testing.T) {
+	_, err := frontend.Compile(ecc.BN254.ScalarField(), NewBuilder, &unusedSecretInputsCircuit{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = frontend.Compile(ecc.BN254.ScalarField(), NewBuilder, &unusedSecretInputsCircuit{}, frontend.CheckUnusedSecretInputs())
+	if err == nil {
+		t.Fatal("expected error for unused secret input")
+	}
+	if !strings.Contains(err.Error(), "unused secret inputs: B") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+type constantParamCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+	C frontend.Constant[int]
+}
+
+func (c *constantParamCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.C.Value()), c.Y)
+	return nil
+}
+
+func TestConstantParam(t *testing.T) {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), NewBuilder, &constantParamCircuit{C: frontend.NewConstant(7)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nbSecret, nbPublic := ccs.GetNbSecretVariables(), ccs.GetNbPublicVariables(); nbSecret != 1 || nbPublic != 2 {
+		t.Fatalf("expected 1 secret and 2 public variables, got %d and %d", nbSecret, nbPublic)
+	}
+	w, err := frontend.NewWitness(&constantParamCircuit{X: 3, Y: 21}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ccs.Solve(w); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -257,3 +257,22 @@ func TestSubSameNoConstraint(t *testing.T) {
 		t.Fatal("expected 0 constraints")
 	}
 }
+
+type unusedSecretInputsCircuit struct {
+	A, B frontend.Variable
+	C    frontend.Variable `gnark:",public"`
+}
+
+func (c *unusedSecretInputsCircuit) Define(api frontend.API) error {
+	r := api.Sub(c.B, c.B)
+	api.AssertIsEqual(api.Add(c.A, r), c.C)
+	return nil
+}
+
+func TestUnusedSecretInputs(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &unusedSecretInputsCircuit{})
+	assert.NoError(err)
+	_, err = frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &unusedSecretInputsCircuit{}, frontend.CheckUnusedSecretInputs())
+	assert.ErrorContains(err, "unused secret inputs: B")
+}
@@ -21,6 +21,7 @@ import (
 	"math/big"
 	"reflect"
 	"sort"
+	"strings"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/constraint"
@@ -232,12 +233,18 @@ func (builder *builder) newInternalVariable() expr.Term {
 // PublicVariable creates a new Public Variable
 func (builder *builder) PublicVariable(f schema.LeafInfo) frontend.Variable {
 	idx := builder.cs.AddPublicVariable(f.FullName())
+	if f.Path != nil {
+		builder.cs.SetInputPath(idx, f.Path())
+	}
 	return expr.NewTerm(idx, builder.tOne)
 }
 
 // SecretVariable creates a new Secret Variable
 func (builder *builder) SecretVariable(f schema.LeafInfo) frontend.Variable {
 	idx := builder.cs.AddSecretVariable(f.FullName())
+	if f.Path != nil {
+		builder.cs.SetInputPath(idx, f.Path())
+	}
 	return expr.NewTerm(idx, builder.tOne)
 }
 
@@ -306,6 +313,13 @@ func (builder *builder) Compile() (constraint.ConstraintSystem, error) {
 		}
 	}
 
+	// ensure all secret inputs are used in constraints
+	if builder.config.CheckUnusedSecretInputs {
+		if unused := builder.cs.UnusedSecretInputs(); len(unused) > 0 {
+			return nil, fmt.Errorf("unused secret inputs: %s", strings.Join(unused, ", "))
+		}
+	}
+
 	return builder.cs, nil
 }
 
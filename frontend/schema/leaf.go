@@ -7,7 +7,15 @@ import "reflect"
 type LeafInfo struct {
 	Visibility Visibility
 	FullName   func() string // in most instances, we don't need to actually evaluate the name.
-	name       string
+
+	// Path returns the path of the leaf in the circuit struct, with the struct
+	// fields separated by dots and the array indices in brackets, such as
+	// Transfers[1].Signature.S. As in FullName, the names of the struct tags
+	// replace the field names. It is nil for leaves not defined by the struct.
+	Path func() string
+
+	name  string
+	index bool // name is an array or slice index
 }
 
 // LeafCount stores the number of secret and public interface of type target(reflect.Type)
@@ -25,3 +33,9 @@ type LeafHandler func(field LeafInfo, tValue reflect.Value) error
 type InitHook interface {
 	GnarkInitHook() // TODO @gbotrel find a better home for this
 }
+
+// An object implementing Constant is a compile-time constant (see frontend.Constant).
+// The walker doesn't explore it, so it is never part of the witness.
+type Constant interface {
+	GnarkConstant()
+}
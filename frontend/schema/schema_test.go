@@ -165,6 +165,51 @@ func TestSchemaInherit(t *testing.T) {
 	}
 }
 
+type constantParam struct {
+	V     variable
+	value variable
+}
+
+func (constantParam) GnarkConstant() {}
+
+type circuitWithConstant struct {
+	X variable
+	Y variable `gnark:",public"`
+	C constantParam
+	D [2]constantParam
+}
+
+func TestSchemaConstant(t *testing.T) {
+	assert := require.New(t)
+
+	var c circuitWithConstant
+	s, err := Walk(&c, tVariable, nil)
+	assert.NoError(err)
+
+	assert.Equal(1, s.Public)
+	assert.Equal(1, s.Secret)
+}
+
+type circuitWithTaggedConfiguration struct {
+	X variable
+	N int `gnark:",public"`
+}
+
+type circuitWithTaggedConstant struct {
+	X variable
+	C [2]constantParam `gnark:",secret"`
+}
+
+func TestSchemaConfigurationTagged(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := Walk(&circuitWithTaggedConfiguration{}, tVariable, nil)
+	assert.ErrorContains(err, "N is a configuration field")
+
+	_, err = Walk(&circuitWithTaggedConstant{}, tVariable, nil)
+	assert.ErrorContains(err, "C is a configuration field")
+}
+
 type initableVariable struct {
 	Val []variable
 }
@@ -59,7 +59,7 @@ func (w *walker) Interface(value reflect.Value) error {
 
 	// call the handler.
 	if w.handler != nil {
-		if err := w.handler(LeafInfo{Visibility: v, FullName: w.name, name: ""}, value); err != nil {
+		if err := w.handler(LeafInfo{Visibility: v, FullName: w.name, Path: w.goPath, name: ""}, value); err != nil {
 			return err
 		}
 	}
@@ -92,7 +92,7 @@ func (w *walker) Slice(value reflect.Value) error {
 }
 
 func (w *walker) arraySliceElem(index int, v reflect.Value) error {
-	w.path.push(LeafInfo{Visibility: w.visibility(), name: strconv.Itoa(index)})
+	w.path.push(LeafInfo{Visibility: w.visibility(), name: strconv.Itoa(index), index: true})
 	if v.CanAddr() && v.Addr().CanInterface() {
 		// TODO @gbotrel don't like that hook, undesirable side effects
 		// will be hard to detect; (for example calling Parse multiple times will init multiple times!)
@@ -133,13 +133,16 @@ func (w *walker) handleLeaves(value reflect.Value) error {
 
 	// call the handler.
 	if w.handler != nil {
-		n := w.name()
+		n, p := w.name(), w.goPath()
 		for i := 0; i < value.Len(); i++ {
 			fName := func() string {
 				return n + "_" + strconv.Itoa(i)
 			}
+			fPath := func() string {
+				return p + "[" + strconv.Itoa(i) + "]"
+			}
 			vv := value.Index(i)
-			if err := w.handler(LeafInfo{Visibility: v, FullName: fName, name: ""}, vv); err != nil {
+			if err := w.handler(LeafInfo{Visibility: v, FullName: fName, Path: fPath, name: ""}, vv); err != nil {
 				return err
 			}
 		}
@@ -154,7 +157,13 @@ func (w *walker) handleLeaves(value reflect.Value) error {
 	return reflectwalk.ErrSkipEntry
 }
 
-func (w *walker) Struct(reflect.Value) error {
+var tConstant = reflect.TypeOf((*Constant)(nil)).Elem()
+
+func (w *walker) Struct(value reflect.Value) error {
+	if value.Type().Implements(tConstant) {
+		// compile-time constants are not part of the witness
+		return reflectwalk.ErrSkipEntry
+	}
 	return nil
 }
 
@@ -204,6 +213,9 @@ func (w *walker) StructField(sf reflect.StructField, v reflect.Value) error {
 		case opts.contains(TagOptPublic):
 			info.Visibility = Public
 		}
+		if (opts.contains(TagOptSecret) || opts.contains(TagOptPublic)) && isConfiguration(sf.Type, w.target) {
+			return fmt.Errorf("%s is a configuration field of type %s and can't be tagged %s; only circuit variables are witness inputs", sf.Name, sf.Type, info.Visibility.String())
+		}
 	}
 
 	if parentVisibility != Unset && parentVisibility != info.Visibility {
@@ -221,6 +233,29 @@ func (w *walker) StructField(sf reflect.StructField, v reflect.Value) error {
 	return nil
 }
 
+// isConfiguration returns true if a field of type t can't hold leaves of type
+// target: it is a configuration of the circuit (sizes, flags, constants), set
+// when defining it rather than assigned in the witness.
+func isConfiguration(t, target reflect.Type) bool {
+	if t == target {
+		return false
+	}
+	if t.Implements(tConstant) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array, reflect.Slice, reflect.Pointer:
+		return isConfiguration(t.Elem(), target)
+	default:
+		return false
+	}
+}
+
 func (w *walker) Enter(l reflectwalk.Location) error {
 	return nil
 }
@@ -258,6 +293,25 @@ func (w *walker) name() string {
 	return sbb.String()
 }
 
+// goPath returns the path of the current element as in [LeafInfo.Path].
+func (w *walker) goPath() string {
+	var sbb strings.Builder
+	sbb.Grow(w.path.len() * 10)
+	for i := 0; i < w.path.len(); i++ {
+		if w.path[i].index {
+			sbb.WriteByte('[')
+			sbb.WriteString(w.path[i].name)
+			sbb.WriteByte(']')
+			continue
+		}
+		if i > 0 {
+			sbb.WriteByte('.')
+		}
+		sbb.WriteString(w.path[i].name)
+	}
+	return sbb.String()
+}
+
 type pathStack []LeafInfo
 
 func (s *pathStack) len() int {
@@ -1,23 +1,43 @@
 package frontend
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 	"reflect"
 
 	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/frontend/schema"
+	"github.com/consensys/gnark/internal/utils"
 )
 
 // NewWitness build an ordered vector of field elements from the given assignment (Circuit)
 // if PublicOnly is specified, returns the public part of the witness only
 // else returns [public | secret]. The result can then be serialized to / from json & binary.
 //
+// If PublicInputsHashed is specified, the public part of the witness is the
+// digest of the public inputs and the secret part is [public | secret].
+//
+// Public inputs must be in the canonical range [0, r), r being the modulus of
+// the field, otherwise a [*PublicInputRangeError] is returned: they would be
+// silently reduced modulo r and the proof wouldn't verify against the
+// unreduced values (e.g. a keccak digest passed to a Solidity verifier). The
+// [ReducePublicInputs] option reduces them instead.
+//
 // See ExampleWitness in witness package for usage.
 func NewWitness(assignment Circuit, field *big.Int, opts ...WitnessOption) (witness.Witness, error) {
 	opt, err := options(opts...)
 	if err != nil {
 		return nil, err
 	}
+	if !opt.reducePublicInputs {
+		if err := checkPublicInputs(assignment, field); err != nil {
+			return nil, err
+		}
+	}
+	if opt.publicInputsHasher != nil {
+		return newHashedWitness(assignment, field, opt)
+	}
 
 	// count the leaves
 	s, err := schema.Walk(assignment, tVariable, nil)
@@ -60,6 +80,115 @@ func NewWitness(assignment Circuit, field *big.Int, opts ...WitnessOption) (witn
 	return w, nil
 }
 
+// newHashedWitness builds the witness for the circuits compiled with the
+// [WithPublicInputsHash] option.
+func newHashedWitness(assignment Circuit, field *big.Int, opt witnessConfig) (witness.Witness, error) {
+	var publicValues, secretValues []any
+	if _, err := schema.Walk(assignment, tVariable, func(leaf schema.LeafInfo, tValue reflect.Value) error {
+		switch leaf.Visibility {
+		case schema.Public:
+			publicValues = append(publicValues, tValue.Interface())
+		case schema.Secret:
+			secretValues = append(secretValues, tValue.Interface())
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	inputs := make([]*big.Int, len(publicValues))
+	for i := range publicValues {
+		if publicValues[i] == nil {
+			return nil, fmt.Errorf("public input %d is not assigned", i)
+		}
+		v := utils.FromInterface(publicValues[i])
+		inputs[i] = v.Mod(&v, field)
+	}
+	digest, err := opt.publicInputsHasher.HashValues(field, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("hash public inputs: %w", err)
+	}
+
+	w, err := witness.New(field)
+	if err != nil {
+		return nil, err
+	}
+	nbSecret := 0
+	if !opt.publicOnly {
+		nbSecret = len(publicValues) + len(secretValues)
+	}
+	chValues := make(chan any)
+	go func() {
+		defer close(chValues)
+		chValues <- digest
+		if !opt.publicOnly {
+			for _, v := range publicValues {
+				chValues <- v
+			}
+			for _, v := range secretValues {
+				chValues <- v
+			}
+		}
+	}()
+	if err := w.Fill(1, nbSecret, chValues); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// PublicInputRangeError is returned by [NewWitness] when a public input is not
+// in the canonical range [0, r).
+type PublicInputRangeError struct {
+	// Name is the full name of the public input in the assignment.
+	Name string
+	// Value is the assigned value.
+	Value *big.Int
+	// Modulus is the modulus r of the field.
+	Modulus *big.Int
+}
+
+func (e *PublicInputRangeError) Error() string {
+	return fmt.Sprintf("public input %s = %s is not reduced modulo %s; reduce it or use frontend.ReducePublicInputs", e.Name, e.Value, e.Modulus)
+}
+
+// checkPublicInputs returns a [*PublicInputRangeError] for the first public
+// input of the assignment greater than or equal to the modulus. Negative
+// values are the usual encoding of -x as r-x and are accepted, and field
+// elements are reduced by construction.
+func checkPublicInputs(assignment Circuit, field *big.Int) error {
+	_, err := schema.Walk(assignment, tVariable, func(leaf schema.LeafInfo, tValue reflect.Value) error {
+		if leaf.Visibility != schema.Public {
+			return nil
+		}
+		var v big.Int
+		switch tv := tValue.Interface().(type) {
+		case big.Int:
+			v.Set(&tv)
+		case *big.Int:
+			if tv == nil {
+				return nil
+			}
+			v.Set(tv)
+		case string:
+			if _, ok := v.SetString(tv, 0); !ok {
+				// reported when filling the witness
+				return nil
+			}
+		case []byte:
+			v.SetBytes(tv)
+		case uint, uint8, uint16, uint32, uint64, int, int8, int16, int32, int64:
+			v = utils.FromInterface(tv)
+		default:
+			return nil
+		}
+		if v.Cmp(field) >= 0 {
+			return &PublicInputRangeError{Name: leaf.FullName(), Value: &v, Modulus: new(big.Int).Set(field)}
+		}
+		return nil
+	})
+	return err
+}
+
 // NewSchema returns the schema corresponding to the circuit structure.
 //
 // This is used to JSON (un)marshall witnesses.
@@ -86,7 +215,9 @@ func options(opts ...WitnessOption) (witnessConfig, error) {
 type WitnessOption func(*witnessConfig) error
 
 type witnessConfig struct {
-	publicOnly bool
+	publicOnly         bool
+	publicInputsHasher PublicInputsHasher
+	reducePublicInputs bool
 }
 
 // PublicOnly enables to instantiate a witness with the public part only of the assignment
@@ -96,3 +227,26 @@ func PublicOnly() WitnessOption {
 		return nil
 	}
 }
+
+// ReducePublicInputs reduces the public inputs modulo the field instead of
+// returning a [*PublicInputRangeError] when they are not in the canonical
+// range. The verifier must then be given the reduced values.
+func ReducePublicInputs() WitnessOption {
+	return func(opt *witnessConfig) error {
+		opt.reducePublicInputs = true
+		return nil
+	}
+}
+
+// PublicInputsHashed enables to instantiate a witness for a circuit compiled
+// with the [WithPublicInputsHash] option. The hasher h must be the same as used
+// when compiling the circuit.
+func PublicInputsHashed(h PublicInputsHasher) WitnessOption {
+	return func(opt *witnessConfig) error {
+		if h == nil {
+			return errors.New("public inputs hasher is nil")
+		}
+		opt.publicInputsHasher = h
+		return nil
+	}
+}
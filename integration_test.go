@@ -17,12 +17,20 @@ limitations under the License.
 package gnark_test
 
 import (
+	"bytes"
 	"sort"
 	"testing"
 
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
 	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
 	"github.com/consensys/gnark/internal/backend/circuits"
 	"github.com/consensys/gnark/test"
+	"github.com/consensys/gnark/test/unsafekzg"
 )
 
 func TestIntegrationAPI(t *testing.T) {
@@ -67,3 +75,51 @@ func TestIntegrationAPI(t *testing.T) {
 	}
 
 }
+
+type witnessReuseCircuit struct {
+	X, Y frontend.Variable
+	Z    frontend.Variable `gnark:",public"`
+	W    frontend.Variable `gnark:",public"`
+}
+
+func (c *witnessReuseCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.Y), c.Z)
+	api.AssertIsEqual(api.Add(c.X, c.W), 10)
+	return nil
+}
+
+func TestWitnessReuseAcrossBackends(t *testing.T) {
+	assert := test.NewAssert(t)
+	field := ecc.BN254.ScalarField()
+
+	// build the witness once, and use it with both backends.
+	fullWitness, err := frontend.NewWitness(&witnessReuseCircuit{X: 3, Y: 5, Z: 15, W: 7}, field)
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+	before, err := fullWitness.MarshalBinary()
+	assert.NoError(err)
+
+	r1cs, err := frontend.Compile(field, r1cs.NewBuilder, &witnessReuseCircuit{})
+	assert.NoError(err)
+	groth16PK, groth16VK, err := groth16.Setup(r1cs)
+	assert.NoError(err)
+	groth16Proof, err := groth16.Prove(r1cs, groth16PK, fullWitness)
+	assert.NoError(err)
+	assert.NoError(groth16.Verify(groth16Proof, groth16VK, publicWitness))
+
+	scs, err := frontend.Compile(field, scs.NewBuilder, &witnessReuseCircuit{})
+	assert.NoError(err)
+	srs, srsLagrange, err := unsafekzg.NewSRS(scs)
+	assert.NoError(err)
+	plonkPK, plonkVK, err := plonk.Setup(scs, srs, srsLagrange)
+	assert.NoError(err)
+	plonkProof, err := plonk.Prove(scs, plonkPK, fullWitness)
+	assert.NoError(err)
+	assert.NoError(plonk.Verify(plonkProof, plonkVK, publicWitness))
+
+	// the provers must not mutate the witness.
+	after, err := fullWitness.MarshalBinary()
+	assert.NoError(err)
+	assert.True(bytes.Equal(before, after), "witness mutated by the provers")
+}
@@ -0,0 +1,59 @@
+// Package differential checks gadgets against the corresponding native
+// operations of gnark-crypto on random inputs.
+//
+// A [Case] samples random inputs, computes the expected output with the native
+// implementation and asserts in-circuit that the gadget agrees. [Run] solves
+// the resulting circuits with the test engine, so that a wrong constant or
+// formula in a gadget is caught without proving.
+package differential
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+// Case is a gadget and the native operation it is checked against.
+type Case[In, Out any] struct {
+	// Sample returns random inputs and the output of the native operation on
+	// them, as circuit assignments.
+	Sample func() (In, Out)
+	// Assert asserts that the gadget returns out on in.
+	Assert func(api frontend.API, in *In, out *Out) error
+}
+
+// Circuit asserts that the gadget of a [Case] returns Out on In.
+type Circuit[In, Out any] struct {
+	In  In
+	Out Out
+
+	// the case is referenced by pointer, as the test engine compares the
+	// circuit with its clone and functions are never deeply equal.
+	c *Case[In, Out]
+}
+
+func (c *Circuit[In, Out]) Define(api frontend.API) error {
+	return c.c.Assert(api, &c.In, &c.Out)
+}
+
+// Run checks each case nbRuns times, once in short mode, with fresh random
+// inputs solved by the test engine over the field. The circuits are built from
+// the samples, so that the cases may have inputs of random length.
+func Run[In, Out any](assert *test.Assert, field *big.Int, nbRuns int, cases map[string]Case[In, Out]) {
+	if testing.Short() {
+		nbRuns = 1
+	}
+	for name, c := range cases {
+		c := c
+		assert.Run(func(assert *test.Assert) {
+			for i := 0; i < nbRuns; i++ {
+				in, out := c.Sample()
+				circuit := &Circuit[In, Out]{In: in, Out: out, c: &c}
+				witness := &Circuit[In, Out]{In: in, Out: out}
+				assert.NoError(test.IsSolved(circuit, witness, field), "run %d", i)
+			}
+		}, name)
+	}
+}
@@ -0,0 +1,21 @@
+// Package errs defines the error sentinels shared by the solver and the
+// backends, so that the backends don't import the solver to expose them.
+package errs
+
+import "errors"
+
+var (
+	// ErrUnsatisfiedConstraint is wrapped by the errors returned when the
+	// witness does not satisfy a constraint of the system.
+	ErrUnsatisfiedConstraint = errors.New("constraint is not satisfied")
+
+	// ErrInvalidWitnessSize is wrapped by the errors returned when the number
+	// of values in the witness doesn't match the number of inputs of the
+	// system.
+	ErrInvalidWitnessSize = errors.New("invalid witness size")
+
+	// ErrCurveMismatch is wrapped by the errors returned when objects defined
+	// over different curves (e.g. a witness and a constraint system) are used
+	// together.
+	ErrCurveMismatch = errors.New("curve mismatch")
+)
@@ -0,0 +1,162 @@
+// Package security validates the parameters of the proof systems against a
+// target security level.
+//
+// The security of a proof is bounded by its weakest component: the discrete
+// logarithm on the curve, the collision resistance of the transcript hash
+// functions, the size of the Fiat-Shamir challenges, and for KZG the size of
+// the SRS, as the q-SDH assumption loses bits to Cheon's attack beyond the
+// size covered by the rating of the curve.
+// Each component is checked separately, so that a configuration below the
+// target (e.g. a short hash to field) is reported instead of silently
+// weakening the proofs.
+//
+// The provers run the checks when a level is set with
+// [backend.WithProverSecurityLevel], and the setups when it is set with
+// [backend.WithSetupSecurityLevel].
+package security
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+)
+
+// Level is a security level in bits.
+type Level int
+
+const (
+	// Level100 is the 100-bit security level.
+	Level100 Level = 100
+	// Level128 is the 128-bit security level.
+	Level128 Level = 128
+)
+
+// ErrBelowSecurityLevel is returned (wrapped) when a parameter doesn't reach
+// the target security level.
+var ErrBelowSecurityLevel = errors.New("below security level")
+
+// CurveLevel returns the security level in bits of the curve. It is the level
+// the curve was designed for, except for BN254 which provides about 100 bits
+// since the improvements of the number field sieve in 2016.
+func CurveLevel(curve ecc.ID) Level {
+	switch curve {
+	case ecc.BN254:
+		return Level100
+	case ecc.BLS12_377, ecc.BLS12_381, ecc.BLS24_315, ecc.BLS24_317, ecc.BW6_633, ecc.BW6_761:
+		return Level128
+	default:
+		return 0
+	}
+}
+
+// CheckCurve checks that the curve reaches the level.
+func CheckCurve(curve ecc.ID, level Level) error {
+	if l := CurveLevel(curve); l < level {
+		return fmt.Errorf("%w: curve %s provides %d bits, want %d", ErrBelowSecurityLevel, curve, l, level)
+	}
+	return nil
+}
+
+// CheckHash checks that the collision resistance of the hash function, half
+// of its output size, reaches the level.
+func CheckHash(h hash.Hash, level Level) error {
+	if nbBits := 8 * h.Size(); Level(nbBits/2) < level {
+		return fmt.Errorf("%w: hash output of %d bits provides %d bits of collision resistance, want %d", ErrBelowSecurityLevel, nbBits, nbBits/2, level)
+	}
+	return nil
+}
+
+// CheckChallenge checks that challenges of nbBits bits reach the level.
+func CheckChallenge(nbBits int, level Level) error {
+	if Level(nbBits) < level {
+		return fmt.Errorf("%w: challenges of %d bits, want %d", ErrBelowSecurityLevel, nbBits, level)
+	}
+	return nil
+}
+
+// RatedSRSSize returns the size of the largest KZG SRS covered by the rating of
+// the curve (see [CurveLevel]), or 0 if the curve is not rated. The ratings
+// follow the KZG deployments on the curves, which claim the level of the curve
+// for an SRS of up to 2²⁸ points, the size of the largest Powers of Tau
+// ceremonies.
+func RatedSRSSize(curve ecc.ID) int {
+	switch curve {
+	case ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BLS24_315, ecc.BLS24_317, ecc.BW6_633, ecc.BW6_761:
+		return 1 << 28
+	default:
+		return 0
+	}
+}
+
+// CheckSRS checks that a KZG SRS of the given size on the curve reaches the
+// level. An SRS of up to [RatedSRSSize] points provides the level of the
+// curve. Beyond, the q-SDH assumption loses half a bit to Cheon's attack each
+// time the size of the SRS doubles, rounded up.
+func CheckSRS(curve ecc.ID, size int, level Level) error {
+	if err := CheckCurve(curve, level); err != nil {
+		return err
+	}
+	l := CurveLevel(curve)
+	if bound := RatedSRSSize(curve); size > bound {
+		excess := bits.Len(uint((size - 1) / bound)) // ⌈log₂(size/bound)⌉
+		l -= Level((excess + 1) / 2)
+	}
+	if l < level {
+		return fmt.Errorf("%w: srs of %d points on %s provides %d bits, want %d", ErrBelowSecurityLevel, size, curve, l, level)
+	}
+	return nil
+}
+
+// CheckSetupConfig checks the curve and, if srsSize is positive, the SRS of
+// that size against the level set with [backend.WithSetupSecurityLevel].
+// Nothing is checked if no level is set.
+func CheckSetupConfig(curve ecc.ID, srsSize int, opt *backend.SetupConfig) error {
+	level := Level(opt.SecurityLevel)
+	if level == 0 {
+		return nil
+	}
+	if srsSize > 0 {
+		return CheckSRS(curve, srsSize, level)
+	}
+	return CheckCurve(curve, level)
+}
+
+// CheckProverConfig checks the curve and the hash functions of the prover
+// configuration against the level set with [backend.WithProverSecurityLevel].
+// The hash to field function is checked as a challenge generator, its output
+// being truncated to the size of the scalar field. Unset hash functions are
+// not checked, and nothing is checked if no level is set.
+func CheckProverConfig(curve ecc.ID, opt *backend.ProverConfig) error {
+	level := Level(opt.SecurityLevel)
+	if level == 0 {
+		return nil
+	}
+	if err := CheckCurve(curve, level); err != nil {
+		return err
+	}
+	for _, h := range []struct {
+		name string
+		h    hash.Hash
+	}{{"challenge", opt.ChallengeHash}, {"kzg folding", opt.KZGFoldingHash}} {
+		if h.h == nil {
+			continue
+		}
+		if err := CheckHash(h.h, level); err != nil {
+			return fmt.Errorf("%s hash: %w", h.name, err)
+		}
+	}
+	if opt.HashToFieldFn != nil {
+		nbBits := 8 * opt.HashToFieldFn.Size()
+		if fieldBits := curve.ScalarField().BitLen() - 1; nbBits > fieldBits {
+			nbBits = fieldBits
+		}
+		if err := CheckChallenge(nbBits, level); err != nil {
+			return fmt.Errorf("hash to field: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package security_test
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"hash/fnv"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/security"
+	"github.com/consensys/gnark/test"
+	"github.com/consensys/gnark/test/unsafekzg"
+)
+
+func TestChecks(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	assert.NoError(security.CheckCurve(ecc.BN254, security.Level100))
+	assert.ErrorIs(security.CheckCurve(ecc.BN254, security.Level128), security.ErrBelowSecurityLevel)
+	assert.NoError(security.CheckCurve(ecc.BLS12_381, security.Level128))
+
+	assert.NoError(security.CheckHash(sha256.New(), security.Level128))
+	assert.ErrorIs(security.CheckHash(fnv.New128(), security.Level100), security.ErrBelowSecurityLevel)
+	assert.NoError(security.CheckHash(sha512.New(), security.Level128))
+
+	assert.NoError(security.CheckChallenge(128, security.Level128))
+	assert.ErrorIs(security.CheckChallenge(64, security.Level100), security.ErrBelowSecurityLevel)
+
+	// the SRS up to the rated size provide the level of the curve
+	assert.NoError(security.CheckSRS(ecc.BLS12_381, 1, security.Level128))
+	assert.NoError(security.CheckSRS(ecc.BLS12_381, 3, security.Level128))
+	assert.NoError(security.CheckSRS(ecc.BLS12_381, 1<<28, security.Level128))
+	assert.NoError(security.CheckSRS(ecc.BN254, 1<<20, security.Level100))
+	assert.ErrorIs(security.CheckSRS(ecc.BN254, 1<<20, security.Level128), security.ErrBelowSecurityLevel)
+
+	// beyond, q-SDH loses half a bit per doubling of the size, rounded up
+	assert.ErrorIs(security.CheckSRS(ecc.BLS12_381, 1<<28+1, security.Level128), security.ErrBelowSecurityLevel)
+	assert.NoError(security.CheckSRS(ecc.BLS12_381, 1<<28+1, 127))
+	assert.NoError(security.CheckSRS(ecc.BLS12_381, 1<<30, 127))
+	assert.NoError(security.CheckSRS(ecc.BLS12_381, 1<<30+1, 126))
+	assert.ErrorIs(security.CheckSRS(ecc.BLS12_381, 1<<30+1, 127), security.ErrBelowSecurityLevel)
+	assert.ErrorIs(security.CheckSRS(ecc.BN254, 1<<29, security.Level100), security.ErrBelowSecurityLevel)
+}
+
+type circuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *circuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+// shortHash is a hash function with a 64-bit output.
+type shortHash struct{}
+
+func (shortHash) Write(p []byte) (int, error) { return len(p), nil }
+func (shortHash) Sum(b []byte) []byte         { return append(b, 1, 2, 3, 4, 5, 6, 7, 8) }
+func (shortHash) Reset()                      {}
+func (shortHash) Size() int                   { return 8 }
+func (shortHash) BlockSize() int              { return 64 }
+
+func TestProverSecurityLevel(t *testing.T) {
+	assert := test.NewAssert(t)
+	field := ecc.BN254.ScalarField()
+	witness, err := frontend.NewWitness(&circuit{X: 3, Y: 9}, field)
+	assert.NoError(err)
+
+	assert.Run(func(assert *test.Assert) {
+		ccs, err := frontend.Compile(field, r1cs.NewBuilder, &circuit{})
+		assert.NoError(err)
+		pk, _, err := groth16.Setup(ccs)
+		assert.NoError(err)
+
+		_, err = groth16.Prove(ccs, pk, witness, backend.WithProverSecurityLevel(100))
+		assert.NoError(err)
+		_, err = groth16.Prove(ccs, pk, witness, backend.WithProverSecurityLevel(128))
+		assert.True(errors.Is(err, security.ErrBelowSecurityLevel), "unexpected error: %v", err)
+		_, err = groth16.Prove(ccs, pk, witness, backend.WithProverSecurityLevel(100), backend.WithProverHashToFieldFunction(shortHash{}))
+		assert.True(errors.Is(err, security.ErrBelowSecurityLevel), "unexpected error: %v", err)
+	}, "groth16")
+
+	assert.Run(func(assert *test.Assert) {
+		ccs, err := frontend.Compile(field, scs.NewBuilder, &circuit{})
+		assert.NoError(err)
+		srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+		assert.NoError(err)
+		pk, _, err := plonk.Setup(ccs, srs, srsLagrange)
+		assert.NoError(err)
+
+		_, err = plonk.Prove(ccs, pk, witness, backend.WithProverSecurityLevel(100))
+		assert.NoError(err)
+		_, err = plonk.Prove(ccs, pk, witness, backend.WithProverSecurityLevel(128))
+		assert.True(errors.Is(err, security.ErrBelowSecurityLevel), "unexpected error: %v", err)
+		_, err = plonk.Prove(ccs, pk, witness, backend.WithProverSecurityLevel(100), backend.WithProverChallengeHashFunction(shortHash{}))
+		assert.True(errors.Is(err, security.ErrBelowSecurityLevel), "unexpected error: %v", err)
+	}, "plonk")
+}
+
+func TestSetupSecurityLevel(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	assert.Run(func(assert *test.Assert) {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit{})
+		assert.NoError(err)
+		_, _, err = groth16.Setup(ccs, backend.WithSetupSecurityLevel(100))
+		assert.NoError(err)
+		_, _, err = groth16.Setup(ccs, backend.WithSetupSecurityLevel(128))
+		assert.True(errors.Is(err, security.ErrBelowSecurityLevel), "unexpected error: %v", err)
+	}, "groth16")
+
+	assert.Run(func(assert *test.Assert) {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &circuit{})
+		assert.NoError(err)
+		srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+		assert.NoError(err)
+		_, _, err = plonk.Setup(ccs, srs, srsLagrange, backend.WithSetupSecurityLevel(100))
+		assert.NoError(err)
+		_, _, err = plonk.Setup(ccs, srs, srsLagrange, backend.WithSetupSecurityLevel(128))
+		assert.True(errors.Is(err, security.ErrBelowSecurityLevel), "unexpected error: %v", err)
+	}, "plonk")
+}
+
+func TestSecurityLevelBLS12381(t *testing.T) {
+	assert := test.NewAssert(t)
+	field := ecc.BLS12_381.ScalarField()
+	ccs, err := frontend.Compile(field, scs.NewBuilder, &circuit{})
+	assert.NoError(err)
+	witness, err := frontend.NewWitness(&circuit{X: 3, Y: 9}, field)
+	assert.NoError(err)
+	srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+	assert.NoError(err)
+
+	pk, vk, err := plonk.Setup(ccs, srs, srsLagrange, backend.WithSetupSecurityLevel(128))
+	assert.NoError(err)
+	proof, err := plonk.Prove(ccs, pk, witness, backend.WithProverSecurityLevel(128))
+	assert.NoError(err)
+	publicWitness, err := witness.Public()
+	assert.NoError(err)
+	assert.NoError(plonk.Verify(proof, vk, publicWitness))
+}
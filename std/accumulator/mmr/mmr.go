@@ -0,0 +1,118 @@
+// Package mmr provides a ZKP-circuit function to verify inclusion proofs in a
+// Merkle Mountain Range (MMR), and its native counterpart to build them.
+//
+// An MMR is an append-only accumulator: a list of perfect binary Merkle trees
+// (the mountains) of decreasing heights, one for each bit set in the number of
+// leaves. Their roots are the peaks, which are bagged from right to left and
+// bound to the size of the MMR to form the root:
+//
+//	bag = H(p₀, H(p₁, … H(pₖ₋₂, pₖ₋₁)))
+//	root = H(size, bag)
+//
+// where the nodes of the trees are H(left, right). The leaves are given as
+// field elements, usually the hashes of the data.
+//
+// The circuit is defined for a given size of the MMR, the proven leaf index is
+// a variable.
+package mmr
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+)
+
+// Proof is an inclusion proof in an MMR.
+type Proof struct {
+	// Path is the list of siblings from the leaf to its peak, padded with
+	// zeroes to the height of the highest peak.
+	Path []frontend.Variable
+
+	// Peaks are the roots of the mountains, from the highest to the lowest.
+	Peaks []frontend.Variable
+}
+
+// NbPath returns the length of the path of the proofs in an MMR of given size.
+func NbPath(size uint64) int {
+	return bits.Len64(size) - 1
+}
+
+// NbPeaks returns the number of peaks of an MMR of given size.
+func NbPeaks(size uint64) int {
+	return bits.OnesCount64(size)
+}
+
+// VerifyProof asserts that leaf is at position index in the MMR of the given
+// size and root.
+func (p *Proof) VerifyProof(api frontend.API, h hash.FieldHasher, size uint64, root, index, leaf frontend.Variable) error {
+	if size == 0 {
+		return errors.New("empty MMR")
+	}
+	if len(p.Path) != NbPath(size) || len(p.Peaks) != NbPeaks(size) {
+		return errors.New("invalid proof length for the MMR size")
+	}
+	nbBits := bits.Len64(size)
+	indexBits := api.ToBinary(index, nbBits)
+
+	// sums[k] is the root of the subtree of height k containing the leaf. The
+	// low bits of the index are its position in its mountain, as the offsets
+	// of the mountains are multiples of their size.
+	sums := make([]frontend.Variable, nbBits)
+	sums[0] = leaf
+	for k := 0; k < len(p.Path); k++ {
+		d1 := api.Select(indexBits[k], p.Path[k], sums[k])
+		d2 := api.Select(indexBits[k], sums[k], p.Path[k])
+		sums[k+1] = nodeSum(h, d1, d2)
+	}
+
+	// the leaf is in the mountain of height h and offset o iff the bits of
+	// its index above h are the ones of o.
+	var offset uint64
+	nbMountains := frontend.Variable(0)
+	for i, height := range peakHeights(size) {
+		inMountain := frontend.Variable(1)
+		for k := height; k < nbBits; k++ {
+			if offset>>k&1 == 1 {
+				inMountain = api.Mul(inMountain, indexBits[k])
+			} else {
+				inMountain = api.Mul(inMountain, api.Sub(1, indexBits[k]))
+			}
+		}
+		api.AssertIsEqual(api.Select(inMountain, sums[height], p.Peaks[i]), p.Peaks[i])
+		nbMountains = api.Add(nbMountains, inMountain)
+		offset += 1 << height
+	}
+	// the index is smaller than the size
+	api.AssertIsEqual(nbMountains, 1)
+
+	api.AssertIsEqual(root, bagPeaks(h, size, p.Peaks))
+	return nil
+}
+
+// peakHeights returns the heights of the mountains of an MMR of the given
+// size, from the highest to the lowest.
+func peakHeights(size uint64) []int {
+	res := make([]int, 0, NbPeaks(size))
+	for k := bits.Len64(size) - 1; k >= 0; k-- {
+		if size>>k&1 == 1 {
+			res = append(res, k)
+		}
+	}
+	return res
+}
+
+func bagPeaks(h hash.FieldHasher, size uint64, peaks []frontend.Variable) frontend.Variable {
+	bag := peaks[len(peaks)-1]
+	for i := len(peaks) - 2; i >= 0; i-- {
+		bag = nodeSum(h, peaks[i], bag)
+	}
+	return nodeSum(h, size, bag)
+}
+
+func nodeSum(h hash.FieldHasher, a, b frontend.Variable) frontend.Variable {
+	h.Reset()
+	h.Write(a, b)
+	return h.Sum()
+}
@@ -0,0 +1,75 @@
+package mmr
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	nativemimc "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type mmrCircuit struct {
+	size  uint64
+	Root  frontend.Variable `gnark:",public"`
+	Index frontend.Variable
+	Leaf  frontend.Variable
+	Proof Proof
+}
+
+func (c *mmrCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	return c.Proof.VerifyProof(api, &h, c.size, c.Root, c.Index, c.Leaf)
+}
+
+func TestVerifyProof(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	m, err := NewMMR(nativemimc.NewMiMC(), ecc.BN254.ScalarField())
+	assert.NoError(err)
+	for size := uint64(1); size <= 11; size++ {
+		assert.NoError(m.Append(big.NewInt(int64(100 + size))))
+		if size != 1 && size != 8 && size != 11 {
+			continue
+		}
+		root, err := m.Root()
+		assert.NoError(err)
+
+		circuit := mmrCircuit{size: size, Proof: Proof{
+			Path:  make([]frontend.Variable, NbPath(size)),
+			Peaks: make([]frontend.Variable, NbPeaks(size)),
+		}}
+		for _, index := range []uint64{0, size / 2, size - 1} {
+			path, peaks, err := m.Prove(index)
+			assert.NoError(err)
+
+			witness := mmrCircuit{Root: root, Index: index, Leaf: big.NewInt(int64(101 + index)), Proof: Proof{
+				Path:  make([]frontend.Variable, len(path)),
+				Peaks: make([]frontend.Variable, len(peaks)),
+			}}
+			for i := range path {
+				witness.Proof.Path[i] = path[i]
+			}
+			for i := range peaks {
+				witness.Proof.Peaks[i] = peaks[i]
+			}
+
+			wrongLeaf := witness
+			wrongLeaf.Leaf = 1
+
+			wrongIndex := witness
+			wrongIndex.Index = size
+
+			assert.CheckCircuit(&circuit,
+				test.WithValidAssignment(&witness),
+				test.WithInvalidAssignment(&wrongLeaf),
+				test.WithInvalidAssignment(&wrongIndex),
+				test.WithCurves(ecc.BN254))
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package mmr
+
+import (
+	"errors"
+	"hash"
+	"math/big"
+)
+
+// MMR is a native Merkle Mountain Range, to compute roots and inclusion
+// proofs matching [Proof.VerifyProof].
+type MMR struct {
+	h     hash.Hash
+	field *big.Int
+
+	// levels[k] are the roots of the perfect subtrees of height k
+	levels [][]*big.Int
+}
+
+// NewMMR returns an empty MMR over the given field. The field elements are
+// written to h as big-endian blocks of h.BlockSize() bytes and the digests
+// are reduced modulo the field, so that h matches the in-circuit hash function
+// (e.g. gnark-crypto's MiMC for std/hash/mimc).
+func NewMMR(h hash.Hash, field *big.Int) (*MMR, error) {
+	if (field.BitLen()+7)/8 > h.BlockSize() {
+		return nil, errors.New("hash block size smaller than field elements")
+	}
+	return &MMR{h: h, field: field}, nil
+}
+
+// Size returns the number of leaves of the MMR.
+func (m *MMR) Size() uint64 {
+	if len(m.levels) == 0 {
+		return 0
+	}
+	return uint64(len(m.levels[0]))
+}
+
+// Append appends a leaf to the MMR.
+func (m *MMR) Append(leaf *big.Int) error {
+	if leaf.Sign() < 0 || leaf.Cmp(m.field) >= 0 {
+		return errors.New("leaf is not a reduced field element")
+	}
+	node := new(big.Int).Set(leaf)
+	for k := 0; ; k++ {
+		if k == len(m.levels) {
+			m.levels = append(m.levels, nil)
+		}
+		m.levels[k] = append(m.levels[k], node)
+		n := len(m.levels[k])
+		if n%2 == 1 {
+			return nil
+		}
+		node = m.nodeSum(m.levels[k][n-2], m.levels[k][n-1])
+	}
+}
+
+// Root returns the root of the MMR.
+func (m *MMR) Root() (*big.Int, error) {
+	size := m.Size()
+	if size == 0 {
+		return nil, errors.New("empty MMR")
+	}
+	peaks := m.peaks()
+	bag := peaks[len(peaks)-1]
+	for i := len(peaks) - 2; i >= 0; i-- {
+		bag = m.nodeSum(peaks[i], bag)
+	}
+	return m.nodeSum(new(big.Int).SetUint64(size), bag), nil
+}
+
+// Prove returns the path and the peaks of the inclusion proof of the leaf at
+// the given index.
+func (m *MMR) Prove(index uint64) (path, peaks []*big.Int, err error) {
+	size := m.Size()
+	if index >= size {
+		return nil, nil, errors.New("index out of range")
+	}
+	var offset uint64
+	for _, height := range peakHeights(size) {
+		if index < offset+1<<height {
+			path = make([]*big.Int, NbPath(size))
+			for k := range path {
+				if k < height {
+					path[k] = m.levels[k][(index>>k)^1]
+				} else {
+					path[k] = new(big.Int)
+				}
+			}
+			break
+		}
+		offset += 1 << height
+	}
+	return path, m.peaks(), nil
+}
+
+func (m *MMR) peaks() []*big.Int {
+	size := m.Size()
+	var res []*big.Int
+	var offset uint64
+	for _, height := range peakHeights(size) {
+		res = append(res, m.levels[height][offset>>height])
+		offset += 1 << height
+	}
+	return res
+}
+
+func (m *MMR) nodeSum(a, b *big.Int) *big.Int {
+	m.h.Reset()
+	buf := make([]byte, m.h.BlockSize())
+	a.FillBytes(buf)
+	m.h.Write(buf)
+	b.FillBytes(buf)
+	m.h.Write(buf)
+	res := new(big.Int).SetBytes(m.h.Sum(nil))
+	return res.Mod(res, m.field)
+}
@@ -0,0 +1,179 @@
+package fields_bls12377
+
+import "github.com/consensys/gnark/std/math/emulated"
+
+func (e Ext12) nSquareTorus(z *E6, n int) *E6 {
+	for i := 0; i < n; i++ {
+		z = e.SquareTorus(z)
+	}
+	return z
+}
+
+// ExptTorus set z to xᵗ in E6 and return z
+// const t uint64 = 9586122913090633729
+func (e Ext12) ExptTorus(x *E6) *E6 {
+	// t in binary: 1000010100001000110000000000000000000000000000000000000000000001
+	// The high 18 bits 100001010000100011 = 136227 are computed with a
+	// shortest addition chain and the remaining 46 bits are all 0 except the
+	// least significant one.
+
+	// z = x^0x21
+	z := e.nSquareTorus(x, 5)
+	z = e.MulTorus(z, x)
+	x33 := z
+
+	// z = x^0x10a1
+	z = e.nSquareTorus(z, 7)
+	z = e.MulTorus(z, x33)
+
+	// z = x^0x10a11
+	z = e.nSquareTorus(z, 4)
+	z = e.MulTorus(z, x)
+
+	// z = x^0x21423
+	z = e.SquareTorus(z)
+	z = e.MulTorus(z, x)
+
+	// z = x^0x8508c00000000001
+	z = e.nSquareTorus(z, 46)
+	z = e.MulTorus(z, x)
+
+	return z
+}
+
+// MulBy034 multiplies z by an E12 sparse element of the form
+//
+//	E12{
+//		C0: E6{B0: 1, B1: 0, B2: 0},
+//		C1: E6{B0: c3, B1: c4, B2: 0},
+//	}
+func (e *Ext12) MulBy034(z *E12, c3, c4 *E2) *E12 {
+
+	a := z.C0
+	b := e.Ext6.MulBy01(&z.C1, c3, c4)
+
+	one := e.Ext2.One()
+	d0 := e.Ext2.Add(c3, one)
+
+	d := e.Ext6.Add(&z.C0, &z.C1)
+	d = e.Ext6.MulBy01(d, d0, c4)
+
+	zC1 := e.Ext6.Add(&a, b)
+	zC1 = e.Ext6.Neg(zC1)
+	zC1 = e.Ext6.Add(zC1, d)
+	zC0 := e.Ext6.MulByNonResidue(b)
+	zC0 = e.Ext6.Add(zC0, &a)
+
+	return &E12{
+		C0: *zC0,
+		C1: *zC1,
+	}
+}
+
+// Torus-based arithmetic:
+//
+// After the easy part of the final exponentiation the elements are in a proper
+// subgroup of Fpk (E12) that coincides with some algebraic tori. The elements
+// are in the torus Tk(Fp) and thus in each torus Tk/d(Fp^d) for d|k, d≠k.  We
+// take d=6. So the elements are in T2(Fp6).
+// Let G_{q,2} = {m ∈ Fq^2 | m^(q+1) = 1} where q = p^6.
+// When m.C1 = 0, then m.C0 must be 1 or −1.
+//
+// We recall the tower construction:
+//
+//	𝔽p²[u] = 𝔽p/u²+5
+//	𝔽p⁶[v] = 𝔽p²/v³-u
+//	𝔽p¹²[w] = 𝔽p⁶/w²-v
+
+// CompressTorus compresses x ∈ E12 to (x.C0 + 1)/x.C1 ∈ E6
+func (e Ext12) CompressTorus(x *E12) *E6 {
+	// x ∈ G_{q,2} \ {-1,1}
+	y := e.Ext6.Add(&x.C0, e.Ext6.One())
+	y = e.Ext6.DivUnchecked(y, &x.C1)
+	return y
+}
+
+// DecompressTorus decompresses y ∈ E6 to (y+w)/(y-w) ∈ E12
+func (e Ext12) DecompressTorus(y *E6) *E12 {
+	var n, d E12
+	one := e.Ext6.One()
+	n.C0 = *y
+	n.C1 = *one
+	d.C0 = *y
+	d.C1 = *e.Ext6.Neg(one)
+
+	x := e.DivUnchecked(&n, &d)
+	return x
+}
+
+// MulTorus multiplies two compressed elements y1, y2 ∈ E6
+// and returns (y1 * y2 + v)/(y1 + y2)
+// N.B.: we use MulTorus in the final exponentiation throughout y1 ≠ -y2 always.
+func (e Ext12) MulTorus(y1, y2 *E6) *E6 {
+	n := e.Ext6.Mul(y1, y2)
+	n.B1 = *e.Ext2.Add(&n.B1, e.Ext2.One())
+	d := e.Ext6.Add(y1, y2)
+	y3 := e.Ext6.DivUnchecked(n, d)
+	return y3
+}
+
+// InverseTorus inverses a compressed elements y ∈ E6
+// and returns -y
+func (e Ext12) InverseTorus(y *E6) *E6 {
+	return e.Ext6.Neg(y)
+}
+
+// SquareTorus squares a compressed elements y ∈ E6
+// and returns (y + v/y)/2
+//
+// It uses a hint to verify that (2x-y)y = v saving one E6 AssertIsEqual.
+func (e Ext12) SquareTorus(y *E6) *E6 {
+	res, err := e.fp.NewHint(squareTorusHint, 6, &y.B0.A0, &y.B0.A1, &y.B1.A0, &y.B1.A1, &y.B2.A0, &y.B2.A1)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+
+	sq := E6{
+		B0: E2{A0: *res[0], A1: *res[1]},
+		B1: E2{A0: *res[2], A1: *res[3]},
+		B2: E2{A0: *res[4], A1: *res[5]},
+	}
+
+	// v = (2x-y)y
+	v := e.Ext6.Double(&sq)
+	v = e.Ext6.Sub(v, y)
+	v = e.Ext6.Mul(v, y)
+
+	_v := E6{B0: *e.Ext2.Zero(), B1: *e.Ext2.One(), B2: *e.Ext2.Zero()}
+	e.Ext6.AssertIsEqual(v, &_v)
+
+	return &sq
+
+}
+
+// FrobeniusTorus raises a compressed elements y ∈ E6 to the modulus p
+// and returns y^p / v^((p-1)/2)
+func (e Ext12) FrobeniusTorus(y *E6) *E6 {
+	t0 := e.Ext2.Conjugate(&y.B0)
+	t1 := e.Ext2.Conjugate(&y.B1)
+	t2 := e.Ext2.Conjugate(&y.B2)
+	// v^((p-1)/2) = u^((p-1)/6) lies in 𝔽p, so that the division is absorbed
+	// in the Frobenius coefficients.
+	v0 := emulated.ValueOf[emulated.BLS12377Fp]("135148009893022339379906188398761468584194992116912126664040619889416147222474808140862391813728516072597320238031")
+	t0 = e.Ext2.MulByElement(t0, &v0)
+	t1 = e.Ext2.MulByNonResidue1Power1(t1)
+	t2 = e.Ext2.MulByNonResidue1Power3(t2)
+
+	return &E6{B0: *t0, B1: *t1, B2: *t2}
+}
+
+// FrobeniusSquareTorus raises a compressed elements y ∈ E6 to the square modulus p^2
+// and returns y^(p^2) / v^((p^2-1)/2)
+func (e Ext12) FrobeniusSquareTorus(y *E6) *E6 {
+	t0 := e.Ext2.MulByNonResidue2Power5(&y.B0)
+	t1 := e.Ext2.MulByNonResidue2Power1(&y.B1)
+	t2 := e.Ext2.Neg(&y.B2)
+
+	return &E6{B0: *t0, B1: *t1, B2: *t2}
+}
@@ -197,3 +197,22 @@ func (e Ext12) Lookup2(s1, s2 frontend.Variable, a, b, c, d *E12) *E12 {
 	c1 := e.Ext6.Lookup2(s1, s2, &a.C1, &b.C1, &c.C1, &d.C1)
 	return &E12{C0: *c0, C1: *c1}
 }
+
+// Frobenius computes x^p.
+func (e Ext12) Frobenius(x *E12) *E12 {
+	z00 := e.Ext2.Conjugate(&x.C0.B0)
+	z01 := e.Ext2.Conjugate(&x.C0.B1)
+	z02 := e.Ext2.Conjugate(&x.C0.B2)
+	z10 := e.Ext2.Conjugate(&x.C1.B0)
+	z11 := e.Ext2.Conjugate(&x.C1.B1)
+	z12 := e.Ext2.Conjugate(&x.C1.B2)
+	z01 = e.Ext2.MulByNonResidue1Power2(z01)
+	z02 = e.Ext2.MulByNonResidue1Power4(z02)
+	z10 = e.Ext2.MulByNonResidue1Power1(z10)
+	z11 = e.Ext2.MulByNonResidue1Power3(z11)
+	z12 = e.Ext2.MulByNonResidue1Power5(z12)
+	return &E12{
+		C0: E6{B0: *z00, B1: *z01, B2: *z02},
+		C1: E6{B0: *z10, B1: *z11, B2: *z12},
+	}
+}
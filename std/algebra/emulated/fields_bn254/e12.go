@@ -115,6 +115,236 @@ func (e Ext12) Square(x *E12) *E12 {
 	}
 }
 
+// CyclotomicSquare computes the square of x in the cyclotomic subgroup using
+// the Granger-Scott formulas (https://eprint.iacr.org/2009/565.pdf, 3.2). The
+// result is undefined if x is not in the cyclotomic subgroup.
+func (e Ext12) CyclotomicSquare(x *E12) *E12 {
+	t0 := e.Ext2.Square(&x.C1.B1)
+	t1 := e.Ext2.Square(&x.C0.B0)
+	t6 := e.Ext2.Add(&x.C1.B1, &x.C0.B0)
+	t6 = e.Ext2.Square(t6)
+	t6 = e.Ext2.Sub(t6, t0)
+	t6 = e.Ext2.Sub(t6, t1)
+	t2 := e.Ext2.Square(&x.C0.B2)
+	t3 := e.Ext2.Square(&x.C1.B0)
+	t7 := e.Ext2.Add(&x.C0.B2, &x.C1.B0)
+	t7 = e.Ext2.Square(t7)
+	t7 = e.Ext2.Sub(t7, t2)
+	t7 = e.Ext2.Sub(t7, t3)
+	t4 := e.Ext2.Square(&x.C1.B2)
+	t5 := e.Ext2.Square(&x.C0.B1)
+	t8 := e.Ext2.Add(&x.C1.B2, &x.C0.B1)
+	t8 = e.Ext2.Square(t8)
+	t8 = e.Ext2.Sub(t8, t4)
+	t8 = e.Ext2.Sub(t8, t5)
+	t8 = e.Ext2.MulByNonResidue(t8)
+	t0 = e.Ext2.MulByNonResidue(t0)
+	t0 = e.Ext2.Add(t0, t1)
+	t2 = e.Ext2.MulByNonResidue(t2)
+	t2 = e.Ext2.Add(t2, t3)
+	t4 = e.Ext2.MulByNonResidue(t4)
+	t4 = e.Ext2.Add(t4, t5)
+	z00 := e.Ext2.Sub(t0, &x.C0.B0)
+	z00 = e.Ext2.Double(z00)
+	z00 = e.Ext2.Add(z00, t0)
+	z01 := e.Ext2.Sub(t2, &x.C0.B1)
+	z01 = e.Ext2.Double(z01)
+	z01 = e.Ext2.Add(z01, t2)
+	z02 := e.Ext2.Sub(t4, &x.C0.B2)
+	z02 = e.Ext2.Double(z02)
+	z02 = e.Ext2.Add(z02, t4)
+	z10 := e.Ext2.Add(t8, &x.C1.B0)
+	z10 = e.Ext2.Double(z10)
+	z10 = e.Ext2.Add(z10, t8)
+	z11 := e.Ext2.Add(t6, &x.C1.B1)
+	z11 = e.Ext2.Double(z11)
+	z11 = e.Ext2.Add(z11, t6)
+	z12 := e.Ext2.Add(t7, &x.C1.B2)
+	z12 = e.Ext2.Double(z12)
+	z12 = e.Ext2.Add(z12, t7)
+	return &E12{
+		C0: E6{B0: *z00, B1: *z01, B2: *z02},
+		C1: E6{B0: *z10, B1: *z11, B2: *z12},
+	}
+}
+
+// CyclotomicSquareCompressed computes the square of x in the cyclotomic
+// subgroup using Karabina's compressed representation
+// (https://eprint.iacr.org/2010/542.pdf, Th. 3.2). Only the coordinates
+// (g1, g2, g3, g5) = (C0.B1, C0.B2, C1.B0, C1.B2) are computed, the remaining
+// ones are zero and should be recovered with [Ext12.DecompressKarabina].
+func (e Ext12) CyclotomicSquareCompressed(x *E12) *E12 {
+	// t0 = g1²
+	t0 := e.Ext2.Square(&x.C0.B1)
+	// t1 = g5²
+	t1 := e.Ext2.Square(&x.C1.B2)
+	// t5 = g1 + g5
+	t5 := e.Ext2.Add(&x.C0.B1, &x.C1.B2)
+	// t2 = (g1 + g5)²
+	t2 := e.Ext2.Square(t5)
+	// t3 = g1² + g5²
+	t3 := e.Ext2.Add(t0, t1)
+	// t5 = 2 * g1 * g5
+	t5 = e.Ext2.Sub(t2, t3)
+	// t6 = g3 + g2
+	t6 := e.Ext2.Add(&x.C1.B0, &x.C0.B2)
+	// t3 = (g3 + g2)²
+	t3 = e.Ext2.Square(t6)
+	// t2 = g3²
+	t2 = e.Ext2.Square(&x.C1.B0)
+	// t6 = 2 * nr * g1 * g5
+	t6 = e.Ext2.MulByNonResidue(t5)
+	// t5 = 4 * nr * g1 * g5 + 2 * g3
+	t5 = e.Ext2.Add(t6, &x.C1.B0)
+	t5 = e.Ext2.Double(t5)
+	// z3 = 6 * nr * g1 * g5 + 2 * g3
+	z3 := e.Ext2.Add(t5, t6)
+	// t4 = nr * g5²
+	t4 := e.Ext2.MulByNonResidue(t1)
+	// t5 = nr * g5² + g1²
+	t5 = e.Ext2.Add(t0, t4)
+	// t6 = nr * g5² + g1² - g2
+	t6 = e.Ext2.Sub(t5, &x.C0.B2)
+	// t1 = g2²
+	t1 = e.Ext2.Square(&x.C0.B2)
+	// t6 = 2 * nr * g5² + 2 * g1² - 2*g2
+	t6 = e.Ext2.Double(t6)
+	// z2 = 3 * nr * g5² + 3 * g1² - 2*g2
+	z2 := e.Ext2.Add(t6, t5)
+	// t4 = nr * g2²
+	t4 = e.Ext2.MulByNonResidue(t1)
+	// t5 = g3² + nr * g2²
+	t5 = e.Ext2.Add(t2, t4)
+	// t6 = g3² + nr * g2² - g1
+	t6 = e.Ext2.Sub(t5, &x.C0.B1)
+	// t6 = 2 * g3² + 2 * nr * g2² - 2 * g1
+	t6 = e.Ext2.Double(t6)
+	// z1 = 3 * g3² + 3 * nr * g2² - 2 * g1
+	z1 := e.Ext2.Add(t6, t5)
+	// t0 = g2² + g3²
+	t0 = e.Ext2.Add(t2, t1)
+	// t5 = 2 * g3 * g2
+	t5 = e.Ext2.Sub(t3, t0)
+	// t6 = 2 * g3 * g2 + g5
+	t6 = e.Ext2.Add(t5, &x.C1.B2)
+	// t6 = 4 * g3 * g2 + 2 * g5
+	t6 = e.Ext2.Double(t6)
+	// z5 = 6 * g3 * g2 + 2 * g5
+	z5 := e.Ext2.Add(t5, t6)
+	zero := e.Ext2.Zero()
+	return &E12{
+		C0: E6{B0: *zero, B1: *z1, B2: *z2},
+		C1: E6{B0: *z3, B1: *zero, B2: *z5},
+	}
+}
+
+// DecompressKarabina recovers the full representation of the output of
+// [Ext12.CyclotomicSquareCompressed]:
+//
+//	g4 = (nr * g5² + 3 * g1² - 2 * g2)/4g3  if g3 != 0
+//	g4 = 2 * g1 * g5/g2                     if g3 == 0
+//	g0 = nr * (2 * g4² + g3 * g5 - 3 * g2 * g1) + 1
+//
+// If g2 = g3 = 0 then x is the compressed form of 1 and 1 is returned.
+func (e Ext12) DecompressKarabina(x *E12) *E12 {
+	one := e.Ext2.One()
+	isG3Zero := e.Ext2.IsZero(&x.C1.B0)
+	isOne := e.api.And(isG3Zero, e.Ext2.IsZero(&x.C0.B2))
+
+	// g3 != 0: (nr * g5² + 3 * g1² - 2 * g2) / 4g3
+	t0 := e.Ext2.Square(&x.C0.B1)
+	t1 := e.Ext2.Sub(t0, &x.C0.B2)
+	t1 = e.Ext2.Double(t1)
+	t1 = e.Ext2.Add(t1, t0)
+	t2 := e.Ext2.Square(&x.C1.B2)
+	num := e.Ext2.MulByNonResidue(t2)
+	num = e.Ext2.Add(num, t1)
+	den := e.Ext2.Double(&x.C1.B0)
+	den = e.Ext2.Double(den)
+
+	// g3 == 0: 2 * g1 * g5 / g2
+	t0 = e.Ext2.Mul(&x.C0.B1, &x.C1.B2)
+	t0 = e.Ext2.Double(t0)
+	num = e.Ext2.Select(isG3Zero, t0, num)
+	den = e.Ext2.Select(isG3Zero, &x.C0.B2, den)
+	// avoid division by zero when x is the compressed form of 1
+	den = e.Ext2.Select(isOne, one, den)
+
+	// z4 = g4
+	z4 := e.Ext2.DivUnchecked(num, den)
+
+	// t1 = g2 * g1
+	t1 = e.Ext2.Mul(&x.C0.B2, &x.C0.B1)
+	// t2 = 2 * g4² - 3 * g2 * g1
+	t2 = e.Ext2.Square(z4)
+	t2 = e.Ext2.Sub(t2, t1)
+	t2 = e.Ext2.Double(t2)
+	t2 = e.Ext2.Sub(t2, t1)
+	// t1 = g3 * g5
+	t1 = e.Ext2.Mul(&x.C1.B0, &x.C1.B2)
+	// z0 = nr * (2 * g4² + g3 * g5 - 3 * g2 * g1) + 1
+	t2 = e.Ext2.Add(t2, t1)
+	z0 := e.Ext2.MulByNonResidue(t2)
+	z0 = e.Ext2.Add(z0, one)
+
+	res := &E12{
+		C0: E6{B0: *z0, B1: x.C0.B1, B2: x.C0.B2},
+		C1: E6{B0: x.C1.B0, B1: *z4, B2: x.C1.B2},
+	}
+	return e.Select(isOne, e.One(), res)
+}
+
+// Frobenius computes x^p.
+func (e Ext12) Frobenius(x *E12) *E12 {
+	z00 := e.Ext2.Conjugate(&x.C0.B0)
+	z01 := e.Ext2.Conjugate(&x.C0.B1)
+	z02 := e.Ext2.Conjugate(&x.C0.B2)
+	z10 := e.Ext2.Conjugate(&x.C1.B0)
+	z11 := e.Ext2.Conjugate(&x.C1.B1)
+	z12 := e.Ext2.Conjugate(&x.C1.B2)
+	z01 = e.Ext2.MulByNonResidue1Power2(z01)
+	z02 = e.Ext2.MulByNonResidue1Power4(z02)
+	z10 = e.Ext2.MulByNonResidue1Power1(z10)
+	z11 = e.Ext2.MulByNonResidue1Power3(z11)
+	z12 = e.Ext2.MulByNonResidue1Power5(z12)
+	return &E12{
+		C0: E6{B0: *z00, B1: *z01, B2: *z02},
+		C1: E6{B0: *z10, B1: *z11, B2: *z12},
+	}
+}
+
+// FrobeniusSquare computes x^(p²).
+func (e Ext12) FrobeniusSquare(x *E12) *E12 {
+	z01 := e.Ext2.MulByNonResidue2Power2(&x.C0.B1)
+	z02 := e.Ext2.MulByNonResidue2Power4(&x.C0.B2)
+	z10 := e.Ext2.MulByNonResidue2Power1(&x.C1.B0)
+	z11 := e.Ext2.MulByNonResidue2Power3(&x.C1.B1)
+	z12 := e.Ext2.MulByNonResidue2Power5(&x.C1.B2)
+	return &E12{
+		C0: E6{B0: x.C0.B0, B1: *z01, B2: *z02},
+		C1: E6{B0: *z10, B1: *z11, B2: *z12},
+	}
+}
+
+// FrobeniusCube computes x^(p³).
+func (e Ext12) FrobeniusCube(x *E12) *E12 {
+	z00 := e.Ext2.Conjugate(&x.C0.B0)
+	z01 := e.Ext2.Conjugate(&x.C0.B1)
+	z02 := e.Ext2.Conjugate(&x.C0.B2)
+	z10 := e.Ext2.Conjugate(&x.C1.B0)
+	z11 := e.Ext2.Conjugate(&x.C1.B1)
+	z12 := e.Ext2.Conjugate(&x.C1.B2)
+	z01 = e.Ext2.MulByNonResidue3Power2(z01)
+	z02 = e.Ext2.MulByNonResidue3Power4(z02)
+	z10 = e.Ext2.MulByNonResidue3Power1(z10)
+	z11 = e.Ext2.MulByNonResidue3Power3(z11)
+	z12 = e.Ext2.MulByNonResidue3Power5(z12)
+	return &E12{
+		C0: E6{B0: *z00, B1: *z01, B2: *z02},
+		C1: E6{B0: *z10, B1: *z11, B2: *z12},
+	}
+}
+
 func (e Ext12) AssertIsEqual(x, y *E12) {
 	e.Ext6.AssertIsEqual(&x.C0, &y.C0)
 	e.Ext6.AssertIsEqual(&x.C1, &y.C1)
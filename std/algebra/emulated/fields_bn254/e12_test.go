@@ -6,6 +6,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
 	"github.com/consensys/gnark/test"
 )
 
@@ -618,3 +619,198 @@ func TestTorusSquare(t *testing.T) {
 	err := test.IsSolved(&torusSquare{}, &witness, ecc.BN254.ScalarField())
 	assert.NoError(err)
 }
+
+type e12Frobenius struct {
+	A E12
+	C E12 `gnark:",public"`
+}
+
+func (circuit *e12Frobenius) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.Frobenius(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestFrobeniusFp12(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c bn254.E12
+	_, _ = a.SetRandom()
+	c.Frobenius(&a)
+
+	witness := e12Frobenius{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12Frobenius{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e12FrobeniusSquare struct {
+	A E12
+	C E12 `gnark:",public"`
+}
+
+func (circuit *e12FrobeniusSquare) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.FrobeniusSquare(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestFrobeniusSquareFp12(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c bn254.E12
+	_, _ = a.SetRandom()
+	c.FrobeniusSquare(&a)
+
+	witness := e12FrobeniusSquare{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12FrobeniusSquare{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e12FrobeniusCube struct {
+	A E12
+	C E12 `gnark:",public"`
+}
+
+func (circuit *e12FrobeniusCube) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.FrobeniusCube(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestFrobeniusCubeFp12(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c bn254.E12
+	_, _ = a.SetRandom()
+	c.FrobeniusCube(&a)
+
+	witness := e12FrobeniusCube{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12FrobeniusCube{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e12CyclotomicSquare struct {
+	A E12
+	C E12 `gnark:",public"`
+}
+
+func (circuit *e12CyclotomicSquare) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.CyclotomicSquare(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestCyclotomicSquareFp12(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c, tmp bn254.E12
+	_, _ = a.SetRandom()
+
+	// put a in the cyclotomic subgroup
+	tmp.Conjugate(&a)
+	a.Inverse(&a)
+	tmp.Mul(&tmp, &a)
+	a.FrobeniusSquare(&tmp).Mul(&a, &tmp)
+
+	c.Square(&a)
+
+	witness := e12CyclotomicSquare{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12CyclotomicSquare{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e12CyclotomicSquareKarabina struct {
+	A E12
+	C E12 `gnark:",public"`
+}
+
+func (circuit *e12CyclotomicSquareKarabina) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.CyclotomicSquareCompressed(&circuit.A)
+	expected = e.CyclotomicSquareCompressed(expected)
+	expected = e.DecompressKarabina(expected)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestCyclotomicSquareKarabinaFp12(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c, tmp bn254.E12
+	_, _ = a.SetRandom()
+
+	// put a in the cyclotomic subgroup
+	tmp.Conjugate(&a)
+	a.Inverse(&a)
+	tmp.Mul(&tmp, &a)
+	a.FrobeniusSquare(&tmp).Mul(&a, &tmp)
+
+	c.Square(&a).Square(&c)
+
+	witness := e12CyclotomicSquareKarabina{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+	err := test.IsSolved(&e12CyclotomicSquareKarabina{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// compressed form of 1
+	a.SetOne()
+	c.SetOne()
+	witness = e12CyclotomicSquareKarabina{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+	err = test.IsSolved(&e12CyclotomicSquareKarabina{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestNbConstraintsFp12(t *testing.T) {
+	assert := test.NewAssert(t)
+	circuits := []struct {
+		name    string
+		circuit frontend.Circuit
+	}{
+		{"Mul", &e12Mul{}},
+		{"Square", &e12Square{}},
+		{"CyclotomicSquare", &e12CyclotomicSquare{}},
+		{"CyclotomicSquareCompressed+DecompressKarabina", &e12CyclotomicSquareKarabina{}},
+		{"Frobenius", &e12Frobenius{}},
+		{"FrobeniusSquare", &e12FrobeniusSquare{}},
+		{"FrobeniusCube", &e12FrobeniusCube{}},
+	}
+	nbConstraints := make(map[string]int)
+	for _, c := range circuits {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, c.circuit)
+		assert.NoError(err)
+		nbConstraints[c.name] = ccs.GetNbConstraints()
+		t.Logf("%s: %d constraints", c.name, ccs.GetNbConstraints())
+	}
+	assert.Less(nbConstraints["CyclotomicSquare"], nbConstraints["Square"], "cyclotomic square should be cheaper than generic square")
+	assert.Less(nbConstraints["FrobeniusSquare"], nbConstraints["Frobenius"], "Frobenius square should be cheaper than Frobenius")
+}
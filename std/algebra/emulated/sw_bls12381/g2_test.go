@@ -7,6 +7,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/differential"
 	"github.com/consensys/gnark/test"
 )
 
@@ -118,3 +119,50 @@ func TestScalarMulG2BySeedTestSolve(t *testing.T) {
 	err := test.IsSolved(&scalarMulG2BySeedCircuit{}, &witness, ecc.BN254.ScalarField())
 	assert.NoError(err)
 }
+
+// TestG2Differential runs the G2 gadgets and the corresponding native
+// operations on random inputs, and checks the results are equal.
+func TestG2Differential(t *testing.T) {
+	assert := test.NewAssert(t)
+	p := ecc.BLS12_381.BaseField()
+	seed, _ := new(big.Int).SetString("15132376222941642752", 10)
+	g2Case := func(gadget func(g2 *G2, p, q *G2Affine) *G2Affine, native func(p, q *bls12381.G2Affine) bls12381.G2Affine) differential.Case[[2]G2Affine, G2Affine] {
+		return differential.Case[[2]G2Affine, G2Affine]{
+			Sample: func() ([2]G2Affine, G2Affine) {
+				_, in1 := randomG1G2Affines()
+				_, in2 := randomG1G2Affines()
+				return [2]G2Affine{NewG2Affine(in1), NewG2Affine(in2)}, NewG2Affine(native(&in1, &in2))
+			},
+			Assert: func(api frontend.API, in *[2]G2Affine, out *G2Affine) error {
+				g2 := NewG2(api)
+				g2.AssertIsEqual(gadget(g2, &in[0], &in[1]), out)
+				return nil
+			},
+		}
+	}
+	differential.Run(assert, ecc.BN254.ScalarField(), 5, map[string]differential.Case[[2]G2Affine, G2Affine]{
+		"add": g2Case(
+			func(g2 *G2, p, q *G2Affine) *G2Affine { return g2.add(p, q) },
+			func(p, q *bls12381.G2Affine) (res bls12381.G2Affine) { res.Add(p, q); return }),
+		"double": g2Case(
+			func(g2 *G2, p, _ *G2Affine) *G2Affine { return g2.double(p) },
+			func(p, _ *bls12381.G2Affine) (res bls12381.G2Affine) { res.Double(p); return }),
+		"doubleAndAdd": g2Case(
+			func(g2 *G2, p, q *G2Affine) *G2Affine { return g2.doubleAndAdd(p, q) },
+			func(p, q *bls12381.G2Affine) (res bls12381.G2Affine) { res.Double(p).Add(&res, q); return }),
+		"neg": g2Case(
+			func(g2 *G2, p, _ *G2Affine) *G2Affine { return g2.neg(p) },
+			func(p, _ *bls12381.G2Affine) (res bls12381.G2Affine) { res.Neg(p); return }),
+		// on G2, the endomorphism ψ acts as the multiplication by p.
+		"psi": g2Case(
+			func(g2 *G2, q, _ *G2Affine) *G2Affine { return g2.psi(q) },
+			func(q, _ *bls12381.G2Affine) (res bls12381.G2Affine) { res.ScalarMultiplication(q, p); return }),
+		// the seed is negative.
+		"scalarMulBySeed": g2Case(
+			func(g2 *G2, p, _ *G2Affine) *G2Affine { return g2.scalarMulBySeed(p) },
+			func(p, _ *bls12381.G2Affine) (res bls12381.G2Affine) {
+				res.ScalarMultiplication(p, seed).Neg(&res)
+				return
+			}),
+	})
+}
@@ -0,0 +1,127 @@
+package sw_bls12381
+
+import (
+	"errors"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hint functions used in the package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{finalExpHint}
+}
+
+// exponents used for computing the residue witness of the final
+// exponentiation, see [finalExpWitness].
+var (
+	// finalExpH = (p¹²-1)/r
+	finalExpH big.Int
+	// finalExpScalingExp = h'·(h'⁻¹ mod s) where λ = p-x₀ = r·m and
+	// (p¹²-1)/r = s·h', s being made of the prime factors of m and h' coprime
+	// with m. It projects onto the subgroup of order s, which divides p⁶-1.
+	finalExpScalingExp big.Int
+	// finalExpLambdaInv = λ⁻¹ mod h'
+	finalExpLambdaInv big.Int
+)
+
+func init() {
+	p := bls12381.ID.BaseField()
+	r := bls12381.ID.ScalarField()
+	x0, _ := new(big.Int).SetString("-15132376222941642752", 10)
+
+	var n, lambda, m big.Int
+	n.Exp(p, big.NewInt(12), nil).Sub(&n, big.NewInt(1))
+	// λ = p-x₀
+	lambda.Sub(p, x0)
+	m.Div(&lambda, r)
+	finalExpH.Div(&n, r)
+
+	// split (p¹²-1)/r = s·h' with gcd(h', m) = 1
+	one := big.NewInt(1)
+	s := big.NewInt(1)
+	hPrime := new(big.Int).Set(&finalExpH)
+	for {
+		g := new(big.Int).GCD(nil, nil, hPrime, &m)
+		if g.Cmp(one) == 0 {
+			break
+		}
+		s.Mul(s, g)
+		hPrime.Div(hPrime, g)
+	}
+	finalExpScalingExp.ModInverse(hPrime, s)
+	finalExpScalingExp.Mul(&finalExpScalingExp, hPrime)
+	finalExpLambdaInv.ModInverse(&lambda, hPrime)
+}
+
+// finalExpWitness returns the residue witness c ∈ 𝔽p¹² and the scaling factor
+// w ∈ 𝔽p⁶ such that f·w = c^λ with λ = p-x₀. They exist if and only if
+// f^((p¹²-1)/r) = 1. See https://eprint.iacr.org/2024/640.pdf.
+func finalExpWitness(f *bls12381.E12) (c bls12381.E12, w bls12381.E6, err error) {
+	var t bls12381.E12
+	if t.Exp(*f, &finalExpH); !t.IsOne() {
+		return c, w, errors.New("final exponentiation is not one")
+	}
+	// 1. the component of f of order s, which is not a λ-th power, is removed
+	// by the scaling factor. As s divides p⁶-1, it lies in 𝔽p⁶.
+	var fw bls12381.E12
+	t.Exp(*f, &finalExpScalingExp)
+	t.Inverse(&t)
+	w = t.C0
+	fw.Mul(f, &t)
+	// 2. f·w is of order dividing h', which is coprime with λ.
+	c.Exp(fw, &finalExpLambdaInv)
+	return c, w, nil
+}
+
+func finalExpHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var f bls12381.E12
+
+			f.C0.B0.A0.SetBigInt(inputs[0])
+			f.C0.B0.A1.SetBigInt(inputs[1])
+			f.C0.B1.A0.SetBigInt(inputs[2])
+			f.C0.B1.A1.SetBigInt(inputs[3])
+			f.C0.B2.A0.SetBigInt(inputs[4])
+			f.C0.B2.A1.SetBigInt(inputs[5])
+			f.C1.B0.A0.SetBigInt(inputs[6])
+			f.C1.B0.A1.SetBigInt(inputs[7])
+			f.C1.B1.A0.SetBigInt(inputs[8])
+			f.C1.B1.A1.SetBigInt(inputs[9])
+			f.C1.B2.A0.SetBigInt(inputs[10])
+			f.C1.B2.A1.SetBigInt(inputs[11])
+
+			c, w, err := finalExpWitness(&f)
+			if err != nil {
+				return err
+			}
+
+			c.C0.B0.A0.BigInt(outputs[0])
+			c.C0.B0.A1.BigInt(outputs[1])
+			c.C0.B1.A0.BigInt(outputs[2])
+			c.C0.B1.A1.BigInt(outputs[3])
+			c.C0.B2.A0.BigInt(outputs[4])
+			c.C0.B2.A1.BigInt(outputs[5])
+			c.C1.B0.A0.BigInt(outputs[6])
+			c.C1.B0.A1.BigInt(outputs[7])
+			c.C1.B1.A0.BigInt(outputs[8])
+			c.C1.B1.A1.BigInt(outputs[9])
+			c.C1.B2.A0.BigInt(outputs[10])
+			c.C1.B2.A1.BigInt(outputs[11])
+			w.B0.A0.BigInt(outputs[12])
+			w.B0.A1.BigInt(outputs[13])
+			w.B1.A0.BigInt(outputs[14])
+			w.B1.A1.BigInt(outputs[15])
+			w.B2.A0.BigInt(outputs[16])
+			w.B2.A1.BigInt(outputs[17])
+
+			return nil
+		})
+}
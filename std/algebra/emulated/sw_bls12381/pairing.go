@@ -256,6 +256,63 @@ func (pr Pairing) PairingCheck(P []*G1Affine, Q []*G2Affine) error {
 	return nil
 }
 
+// AssertFinalExponentiationIsOne asserts that x^((p¹²-1)/r) = 1, where x is
+// typically the output of [Pairing.MillerLoop]. Instead of computing the final
+// exponentiation, the residue witness c ∈ 𝔽p¹² and the scaling factor
+// w ∈ 𝔽p⁶ are provided by a hint and the circuit checks that
+//
+//	x·w = c^λ, with λ = p-x₀
+//
+// which holds for some c and w if and only if the final exponentiation of x
+// is one, see https://eprint.iacr.org/2024/640.pdf. As λ is a multiple of r
+// and w^((p¹²-1)/r) = 1 for any w ∈ 𝔽p⁶, the check implies x^((p¹²-1)/r) = 1.
+// It is cheaper than computing the final exponentiation.
+func (pr Pairing) AssertFinalExponentiationIsOne(x *GTEl) {
+	res, err := pr.curveF.NewHint(finalExpHint, 18, &x.C0.B0.A0, &x.C0.B0.A1, &x.C0.B1.A0, &x.C0.B1.A1, &x.C0.B2.A0, &x.C0.B2.A1, &x.C1.B0.A0, &x.C1.B0.A1, &x.C1.B1.A0, &x.C1.B1.A1, &x.C1.B2.A0, &x.C1.B2.A1)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+	residueWitness := GTEl{
+		C0: fields_bls12381.E6{
+			B0: fields_bls12381.E2{A0: *res[0], A1: *res[1]},
+			B1: fields_bls12381.E2{A0: *res[2], A1: *res[3]},
+			B2: fields_bls12381.E2{A0: *res[4], A1: *res[5]},
+		},
+		C1: fields_bls12381.E6{
+			B0: fields_bls12381.E2{A0: *res[6], A1: *res[7]},
+			B1: fields_bls12381.E2{A0: *res[8], A1: *res[9]},
+			B2: fields_bls12381.E2{A0: *res[10], A1: *res[11]},
+		},
+	}
+	// the scaling factor is constrained to be in 𝔽p⁶
+	scalingFactor := fields_bls12381.E6{
+		B0: fields_bls12381.E2{A0: *res[12], A1: *res[13]},
+		B1: fields_bls12381.E2{A0: *res[14], A1: *res[15]},
+		B2: fields_bls12381.E2{A0: *res[16], A1: *res[17]},
+	}
+	// c ≠ 0, otherwise x·w = c^λ would hold for w = 0
+	pr.Ext12.Inverse(&residueWitness)
+
+	// t = c^(-x₀), x₀ being negative
+	t := &residueWitness
+	for i := len(loopCounter) - 2; i >= 0; i-- {
+		t = pr.Ext12.Square(t)
+		if loopCounter[i] == 1 {
+			t = pr.Ext12.Mul(t, &residueWitness)
+		}
+	}
+	// t = c^λ = c^p · c^(-x₀)
+	t = pr.Ext12.Mul(t, pr.Ext12.Frobenius(&residueWitness))
+
+	// x·w == c^λ
+	xw := GTEl{
+		C0: *pr.Ext6.Mul(&x.C0, &scalingFactor),
+		C1: *pr.Ext6.Mul(&x.C1, &scalingFactor),
+	}
+	pr.AssertIsEqual(&xw, t)
+}
+
 func (pr Pairing) AssertIsEqual(x, y *GTEl) {
 	pr.Ext12.AssertIsEqual(x, y)
 }
@@ -321,6 +378,9 @@ var loopCounter = [64]int8{
 
 // MillerLoop computes the multi-Miller loop
 // ∏ᵢ { fᵢ_{u,Q}(P) }
+//
+// The squarings of the accumulator are shared across all the pairs, so that
+// computing a product of n pairings is much cheaper than n pairings.
 func (pr Pairing) MillerLoop(P []*G1Affine, Q []*G2Affine) (*GTEl, error) {
 
 	// check input size match
@@ -340,6 +400,80 @@ func (pr Pairing) MillerLoop(P []*G1Affine, Q []*G2Affine) (*GTEl, error) {
 
 }
 
+// MillerLoopFixedLines computes the multi-Miller loop as [Pairing.MillerLoop]
+// when the G2 points are known at circuit compilation time and their line
+// evaluations precomputed with [NewG2Lines]. Only the evaluations of the lines
+// at the G1 points are computed in-circuit.
+func (pr Pairing) MillerLoopFixedLines(P []*G1Affine, lines []*G2Lines) (*GTEl, error) {
+	n := len(P)
+	if n == 0 || n != len(lines) {
+		return nil, errors.New("invalid inputs sizes")
+	}
+	cLines := make([]lineEvaluations, n)
+	for i := range lines {
+		cLines[i] = lines[i].lines
+	}
+	return pr.millerLoopLines(P, cLines)
+}
+
+// MillerLoopFixedQ computes the multi-Miller loop as
+// [Pairing.MillerLoopFixedLines], precomputing the line evaluations of Q.
+func (pr Pairing) MillerLoopFixedQ(P []*G1Affine, Q []bls12381.G2Affine) (*GTEl, error) {
+	return pr.MillerLoopFixedLines(P, precomputeG2Lines(Q))
+}
+
+// PairFixedLines calculates the reduced pairing ∏ᵢ e(Pᵢ, Qᵢ) as [Pairing.Pair]
+// from the precomputed line evaluations of Qᵢ. See
+// [Pairing.MillerLoopFixedLines].
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairFixedLines(P []*G1Affine, lines []*G2Lines) (*GTEl, error) {
+	res, err := pr.MillerLoopFixedLines(P, lines)
+	if err != nil {
+		return nil, fmt.Errorf("miller loop: %w", err)
+	}
+	res = pr.finalExponentiation(res, len(P) == 1)
+	return res, nil
+}
+
+// PairFixedQ calculates the reduced pairing ∏ᵢ e(Pᵢ, Qᵢ) as
+// [Pairing.PairFixedLines], precomputing the line evaluations of Q.
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairFixedQ(P []*G1Affine, Q []bls12381.G2Affine) (*GTEl, error) {
+	return pr.PairFixedLines(P, precomputeG2Lines(Q))
+}
+
+// PairingCheckFixedLines asserts that ∏ᵢ e(Pᵢ, Qᵢ) = 1 as
+// [Pairing.PairingCheck] from the precomputed line evaluations of Qᵢ. See
+// [Pairing.MillerLoopFixedLines].
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairingCheckFixedLines(P []*G1Affine, lines []*G2Lines) error {
+	f, err := pr.PairFixedLines(P, lines)
+	if err != nil {
+		return err
+	}
+	pr.AssertIsEqual(f, pr.One())
+	return nil
+}
+
+// PairingCheckFixedQ asserts that ∏ᵢ e(Pᵢ, Qᵢ) = 1 as
+// [Pairing.PairingCheckFixedLines], precomputing the line evaluations of Q.
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairingCheckFixedQ(P []*G1Affine, Q []bls12381.G2Affine) error {
+	return pr.PairingCheckFixedLines(P, precomputeG2Lines(Q))
+}
+
+func precomputeG2Lines(Q []bls12381.G2Affine) []*G2Lines {
+	lines := make([]*G2Lines, len(Q))
+	for i := range Q {
+		lines[i] = NewG2Lines(Q[i])
+	}
+	return lines
+}
+
 // millerLoopLines computes the multi-Miller loop from points in G1 and precomputed lines in G2
 func (pr Pairing) millerLoopLines(P []*G1Affine, lines []lineEvaluations) (*GTEl, error) {
 
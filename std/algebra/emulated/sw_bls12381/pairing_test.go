@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/rand"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -112,6 +113,73 @@ func TestPairFixedTestSolve(t *testing.T) {
 	assert.NoError(err)
 }
 
+type PairFixedQCircuit struct {
+	InG1 G1Affine
+	Res  GTEl
+	Q    bls12381.G2Affine `gnark:"-"`
+}
+
+func (c *PairFixedQCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	res, err := pairing.PairFixedQ([]*G1Affine{&c.InG1}, []bls12381.G2Affine{c.Q})
+	if err != nil {
+		return fmt.Errorf("pair: %w", err)
+	}
+	pairing.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestPairFixedQTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p, q := randomG1G2Affines()
+	res, err := bls12381.Pair([]bls12381.G1Affine{p}, []bls12381.G2Affine{q})
+	assert.NoError(err)
+	witness := PairFixedQCircuit{
+		InG1: NewG1Affine(p),
+		Res:  NewGTEl(res),
+	}
+	err = test.IsSolved(&PairFixedQCircuit{Q: q}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type PairingCheckFixedLinesCircuit struct {
+	In1G1 G1Affine
+	In2G1 G1Affine
+	Lines [2]*G2Lines `gnark:"-"`
+}
+
+func (c *PairingCheckFixedLinesCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	return pairing.PairingCheckFixedLines([]*G1Affine{&c.In1G1, &c.In2G1}, c.Lines[:])
+}
+
+func TestPairingCheckFixedLinesTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p1, q1 := randomG1G2Affines()
+	_, q2 := randomG1G2Affines()
+	var p2 bls12381.G1Affine
+	p2.Neg(&p1)
+	circuit := PairingCheckFixedLinesCircuit{
+		Lines: [2]*G2Lines{NewG2Lines(q1), NewG2Lines(q1)},
+	}
+	witness := PairingCheckFixedLinesCircuit{
+		In1G1: NewG1Affine(p1),
+		In2G1: NewG1Affine(p2),
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// e(P, Q1)·e(-P, Q2) ≠ 1
+	circuit.Lines[1] = NewG2Lines(q2)
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
 type MultiPairCircuit struct {
 	InG1 G1Affine
 	InG2 G2Affine
@@ -200,6 +268,66 @@ func TestPairingCheckTestSolve(t *testing.T) {
 	assert.NoError(err)
 }
 
+type FinalExponentiationIsOneCircuit struct {
+	InG1 [2]G1Affine
+	InG2 [2]G2Affine
+}
+
+func (c *FinalExponentiationIsOneCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	ml, err := pairing.MillerLoop([]*G1Affine{&c.InG1[0], &c.InG1[1]}, []*G2Affine{&c.InG2[0], &c.InG2[1]})
+	if err != nil {
+		return fmt.Errorf("miller loop: %w", err)
+	}
+	pairing.AssertFinalExponentiationIsOne(ml)
+	return nil
+}
+
+func TestFinalExponentiationIsOneTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p1, q1 := randomG1G2Affines()
+	_, q2 := randomG1G2Affines()
+	var p2 bls12381.G1Affine
+	p2.Neg(&p1)
+	witness := FinalExponentiationIsOneCircuit{
+		InG1: [2]G1Affine{NewG1Affine(p1), NewG1Affine(p2)},
+		InG2: [2]G2Affine{NewG2Affine(q1), NewG2Affine(q1)},
+	}
+	err := test.IsSolved(&FinalExponentiationIsOneCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// e(P, Q1)·e(-P, Q2) ≠ 1
+	witness.InG2[1] = NewG2Affine(q2)
+	err = test.IsSolved(&FinalExponentiationIsOneCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestFinalExpWitness(t *testing.T) {
+	assert := test.NewAssert(t)
+	// a random element whose final exponentiation is one: f = g^r
+	var f bls12381.E12
+	_, _ = f.SetRandom()
+	f.Exp(f, bls12381.ID.ScalarField())
+	c, w, err := finalExpWitness(&f)
+	assert.NoError(err)
+	// check f·w = c^λ
+	var lhs, rhs, wE12, t0 bls12381.E12
+	wE12.C0 = w
+	lhs.Mul(&f, &wE12)
+	x0, _ := new(big.Int).SetString("15132376222941642752", 10)
+	rhs.Exp(c, x0)
+	t0.Frobenius(&c)
+	rhs.Mul(&rhs, &t0)
+	assert.True(lhs.Equal(&rhs))
+
+	// random element, final exponentiation is not one
+	_, _ = f.SetRandom()
+	_, _, err = finalExpWitness(&f)
+	assert.Error(err)
+}
+
 type GroupMembershipCircuit struct {
 	InG1 G1Affine
 	InG2 G2Affine
@@ -13,6 +13,20 @@ type lineEvaluation struct {
 }
 type lineEvaluations [2][len(bls12381.LoopCounter) - 1]*lineEvaluation
 
+// G2Lines holds the Miller loop line evaluations of a G2 point known at
+// circuit compilation time, for example a verifying key element. The
+// evaluations are computed out-of-circuit and used as constants in the circuit
+// instead of being computed in-circuit. G2Lines is not part of the witness and
+// circuit fields of this type must be tagged with `gnark:"-"`.
+type G2Lines struct {
+	lines lineEvaluations
+}
+
+// NewG2Lines precomputes the Miller loop line evaluations of Q.
+func NewG2Lines(Q bls12381.G2Affine) *G2Lines {
+	return &G2Lines{lines: precomputeLines(Q)}
+}
+
 func precomputeLines(Q bls12381.G2Affine) lineEvaluations {
 	var cLines lineEvaluations
 	nLines := bls12381.PrecomputeLines(Q)
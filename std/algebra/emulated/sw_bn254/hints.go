@@ -0,0 +1,153 @@
+package sw_bn254
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hint functions used in the package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{finalExpHint}
+}
+
+// exponents used for computing the residue witness of the final
+// exponentiation, see [finalExpWitness].
+var (
+	// finalExpCubicExp = (p¹²-1)/3r
+	finalExpCubicExp big.Int
+	// finalExpMInv = m⁻¹ mod p¹²-1 where λ = 6x₀+2+p-p²+p³ = 3·r·m
+	finalExpMInv big.Int
+	// finalExpRInv = r⁻¹ mod (p¹²-1)/r
+	finalExpRInv big.Int
+	// finalExpT = (p¹²-1)/27, the 3-adic valuation of p¹²-1 being 3
+	finalExpT big.Int
+	// finalExpThirdInv = 3⁻¹ mod finalExpT
+	finalExpThirdInv big.Int
+)
+
+func init() {
+	p := bn254.ID.BaseField()
+	r := bn254.ID.ScalarField()
+	x0, _ := new(big.Int).SetString("4965661367192848881", 10)
+
+	var n, lambda, t big.Int
+	n.Exp(p, big.NewInt(12), nil).Sub(&n, big.NewInt(1))
+	// λ = 6x₀+2+p-p²+p³
+	lambda.Mul(x0, big.NewInt(6)).Add(&lambda, big.NewInt(2)).Add(&lambda, p)
+	t.Exp(p, big.NewInt(2), nil)
+	lambda.Sub(&lambda, &t)
+	t.Exp(p, big.NewInt(3), nil)
+	lambda.Add(&lambda, &t)
+
+	var threeR, m, nOverR big.Int
+	threeR.Mul(r, big.NewInt(3))
+	finalExpCubicExp.Div(&n, &threeR)
+	m.Div(&lambda, &threeR)
+	finalExpMInv.ModInverse(&m, &n)
+	nOverR.Div(&n, r)
+	finalExpRInv.ModInverse(r, &nOverR)
+	finalExpT.Div(&n, big.NewInt(27))
+	finalExpThirdInv.ModInverse(big.NewInt(3), &finalExpT)
+}
+
+// finalExpWitness returns the residue witness c ∈ 𝔽p¹² and the scaling factor
+// w ∈ 𝔽p⁶ such that f·w = c^λ with λ = 6x₀+2+p-p²+p³. They exist if and only
+// if f^((p¹²-1)/r) = 1. See https://eprint.iacr.org/2024/640.pdf.
+func finalExpWitness(f *bn254.E12) (c bn254.E12, w bn254.E6, err error) {
+	// v is a cubic non-residue in 𝔽p⁶ and 𝔽p¹²
+	var v, one, fw, t bn254.E12
+	v.C0.B1.SetOne()
+	one.SetOne()
+
+	// 1. scale f by a power of v so that f·w is a λ-th power, that is
+	// (f·w)^((p¹²-1)/3r) = 1.
+	var wi bn254.E12
+	wi.SetOne()
+	found := false
+	for i := 0; i < 3; i++ {
+		fw.Mul(f, &wi)
+		if t.Exp(fw, &finalExpCubicExp); t.Equal(&one) {
+			found = true
+			break
+		}
+		wi.Mul(&wi, &v)
+	}
+	if !found {
+		return c, w, errors.New("final exponentiation is not one")
+	}
+	w = wi.C0
+
+	// 2. m-th root, m being coprime with p¹²-1.
+	var z bn254.E12
+	z.Exp(fw, &finalExpMInv)
+	// 3. r-th root, r² not dividing p¹²-1.
+	z.Exp(z, &finalExpRInv)
+	// 4. cube root. Compute a candidate root up to an element of the 3-Sylow
+	// subgroup of order 27, generated by v^((p¹²-1)/27).
+	var root, omega, omegaJ bn254.E12
+	root.Exp(z, &finalExpThirdInv)
+	omega.Exp(v, &finalExpT)
+	omegaJ.SetOne()
+	for j := 0; j < 27; j++ {
+		c.Mul(&root, &omegaJ)
+		if t.Square(&c).Mul(&t, &c); t.Equal(&z) {
+			return c, w, nil
+		}
+		omegaJ.Mul(&omegaJ, &omega)
+	}
+	return c, w, errors.New("final exponentiation is not one")
+}
+
+func finalExpHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var f bn254.E12
+
+			f.C0.B0.A0.SetBigInt(inputs[0])
+			f.C0.B0.A1.SetBigInt(inputs[1])
+			f.C0.B1.A0.SetBigInt(inputs[2])
+			f.C0.B1.A1.SetBigInt(inputs[3])
+			f.C0.B2.A0.SetBigInt(inputs[4])
+			f.C0.B2.A1.SetBigInt(inputs[5])
+			f.C1.B0.A0.SetBigInt(inputs[6])
+			f.C1.B0.A1.SetBigInt(inputs[7])
+			f.C1.B1.A0.SetBigInt(inputs[8])
+			f.C1.B1.A1.SetBigInt(inputs[9])
+			f.C1.B2.A0.SetBigInt(inputs[10])
+			f.C1.B2.A1.SetBigInt(inputs[11])
+
+			c, w, err := finalExpWitness(&f)
+			if err != nil {
+				return err
+			}
+
+			c.C0.B0.A0.BigInt(outputs[0])
+			c.C0.B0.A1.BigInt(outputs[1])
+			c.C0.B1.A0.BigInt(outputs[2])
+			c.C0.B1.A1.BigInt(outputs[3])
+			c.C0.B2.A0.BigInt(outputs[4])
+			c.C0.B2.A1.BigInt(outputs[5])
+			c.C1.B0.A0.BigInt(outputs[6])
+			c.C1.B0.A1.BigInt(outputs[7])
+			c.C1.B1.A0.BigInt(outputs[8])
+			c.C1.B1.A1.BigInt(outputs[9])
+			c.C1.B2.A0.BigInt(outputs[10])
+			c.C1.B2.A1.BigInt(outputs[11])
+			w.B0.A0.BigInt(outputs[12])
+			w.B0.A1.BigInt(outputs[13])
+			w.B1.A0.BigInt(outputs[14])
+			w.B1.A1.BigInt(outputs[15])
+			w.B2.A0.BigInt(outputs[16])
+			w.B2.A1.BigInt(outputs[17])
+
+			return nil
+		})
+}
@@ -57,6 +57,22 @@ func NewGTEl(v bn254.GT) GTEl {
 	}
 }
 
+// GTCompressed is an element of GT compressed in the torus T₂(𝔽p⁶). It takes
+// half the size of [GTEl], which makes it cheaper to pass around as a witness
+// in protocols manipulating pairing results.
+type GTCompressed = fields_bn254.E6
+
+// NewGTCompressed compresses v in the torus and returns the corresponding
+// witness value. It returns an error if v is 1, which is not representable in
+// compressed form.
+func NewGTCompressed(v bn254.GT) (GTCompressed, error) {
+	y, err := v.CompressTorus()
+	if err != nil {
+		return GTCompressed{}, err
+	}
+	return fields_bn254.FromE6(&y), nil
+}
+
 func NewPairing(api frontend.API) (*Pairing, error) {
 	ba, err := emulated.NewField[BaseField](api)
 	if err != nil {
@@ -260,6 +276,34 @@ func (pr Pairing) AssertIsEqual(x, y *GTEl) {
 	pr.Ext12.AssertIsEqual(x, y)
 }
 
+// CompressGT compresses x ∈ GT in the torus T₂(𝔽p⁶). x must not be 1 as it is
+// not representable in compressed form, in which case the circuit is not
+// satisfiable.
+func (pr Pairing) CompressGT(x *GTEl) *GTCompressed {
+	return pr.CompressTorus(x)
+}
+
+// DecompressGT returns the element of GT corresponding to the compressed y.
+func (pr Pairing) DecompressGT(y *GTCompressed) *GTEl {
+	return pr.DecompressTorus(y)
+}
+
+// MulGTCompressed multiplies x and y in compressed form. The product must not
+// be 1, that is x ≠ -y, in which case the circuit is not satisfiable.
+func (pr Pairing) MulGTCompressed(x, y *GTCompressed) *GTCompressed {
+	return pr.MulTorus(x, y)
+}
+
+// AssertIsEqualCompressed asserts that x ∈ GT equals the decompression of y.
+// It only costs one 𝔽p⁶ multiplication as it checks x.C0 + 1 == y * x.C1
+// instead of decompressing y. x must be in GT, which is the case for the
+// outputs of [Pairing.Pair].
+func (pr Pairing) AssertIsEqualCompressed(x *GTEl, y *GTCompressed) {
+	lhs := pr.Ext6.Add(&x.C0, pr.Ext6.One())
+	rhs := pr.Ext6.Mul(y, &x.C1)
+	pr.Ext6.AssertIsEqual(lhs, rhs)
+}
+
 func (pr Pairing) AssertIsOnCurve(P *G1Affine) {
 	pr.curve.AssertIsOnCurve(P)
 }
@@ -324,6 +368,9 @@ var loopCounter = [66]int8{
 
 // MillerLoop computes the multi-Miller loop
 // ∏ᵢ { fᵢ_{6x₀+2,Q}(P) · ℓᵢ_{[6x₀+2]Q,π(Q)}(P) · ℓᵢ_{[6x₀+2]Q+π(Q),-π²(Q)}(P) }
+//
+// The squarings of the accumulator are shared across all the pairs, so that
+// computing a product of n pairings is much cheaper than n pairings.
 func (pr Pairing) MillerLoop(P []*G1Affine, Q []*G2Affine) (*GTEl, error) {
 
 	// check input size match
@@ -343,6 +390,80 @@ func (pr Pairing) MillerLoop(P []*G1Affine, Q []*G2Affine) (*GTEl, error) {
 
 }
 
+// MillerLoopFixedLines computes the multi-Miller loop as [Pairing.MillerLoop]
+// when the G2 points are known at circuit compilation time and their line
+// evaluations precomputed with [NewG2Lines]. Only the evaluations of the lines
+// at the G1 points are computed in-circuit.
+func (pr Pairing) MillerLoopFixedLines(P []*G1Affine, lines []*G2Lines) (*GTEl, error) {
+	n := len(P)
+	if n == 0 || n != len(lines) {
+		return nil, errors.New("invalid inputs sizes")
+	}
+	cLines := make([]lineEvaluations, n)
+	for i := range lines {
+		cLines[i] = lines[i].lines
+	}
+	return pr.millerLoopLines(P, cLines)
+}
+
+// MillerLoopFixedQ computes the multi-Miller loop as
+// [Pairing.MillerLoopFixedLines], precomputing the line evaluations of Q.
+func (pr Pairing) MillerLoopFixedQ(P []*G1Affine, Q []bn254.G2Affine) (*GTEl, error) {
+	return pr.MillerLoopFixedLines(P, precomputeG2Lines(Q))
+}
+
+// PairFixedLines calculates the reduced pairing ∏ᵢ e(Pᵢ, Qᵢ) as [Pairing.Pair]
+// from the precomputed line evaluations of Qᵢ. See
+// [Pairing.MillerLoopFixedLines].
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairFixedLines(P []*G1Affine, lines []*G2Lines) (*GTEl, error) {
+	res, err := pr.MillerLoopFixedLines(P, lines)
+	if err != nil {
+		return nil, fmt.Errorf("miller loop: %w", err)
+	}
+	res = pr.finalExponentiation(res, len(P) == 1)
+	return res, nil
+}
+
+// PairFixedQ calculates the reduced pairing ∏ᵢ e(Pᵢ, Qᵢ) as
+// [Pairing.PairFixedLines], precomputing the line evaluations of Q.
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairFixedQ(P []*G1Affine, Q []bn254.G2Affine) (*GTEl, error) {
+	return pr.PairFixedLines(P, precomputeG2Lines(Q))
+}
+
+// PairingCheckFixedLines asserts that ∏ᵢ e(Pᵢ, Qᵢ) = 1 as
+// [Pairing.PairingCheck] from the precomputed line evaluations of Qᵢ. See
+// [Pairing.MillerLoopFixedLines].
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairingCheckFixedLines(P []*G1Affine, lines []*G2Lines) error {
+	f, err := pr.PairFixedLines(P, lines)
+	if err != nil {
+		return err
+	}
+	pr.AssertIsEqual(f, pr.One())
+	return nil
+}
+
+// PairingCheckFixedQ asserts that ∏ᵢ e(Pᵢ, Qᵢ) = 1 as
+// [Pairing.PairingCheckFixedLines], precomputing the line evaluations of Q.
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairingCheckFixedQ(P []*G1Affine, Q []bn254.G2Affine) error {
+	return pr.PairingCheckFixedLines(P, precomputeG2Lines(Q))
+}
+
+func precomputeG2Lines(Q []bn254.G2Affine) []*G2Lines {
+	lines := make([]*G2Lines, len(Q))
+	for i := range Q {
+		lines[i] = NewG2Lines(Q[i])
+	}
+	return lines
+}
+
 // millerLoopLines computes the multi-Miller loop from points in G1 and precomputed lines in G2
 func (pr Pairing) millerLoopLines(P []*G1Affine, lines []lineEvaluations) (*GTEl, error) {
 
@@ -653,3 +774,66 @@ func (pr Pairing) FinalExponentiationIsOne(e *GTEl) {
 	one := pr.One()
 	pr.AssertIsEqual(res, one)
 }
+
+// AssertFinalExponentiationIsOne asserts that x^((p¹²-1)/r) = 1, where x is
+// typically the output of [Pairing.MillerLoop]. Instead of computing the final
+// exponentiation, the residue witness c ∈ 𝔽p¹² and the scaling factor
+// w ∈ 𝔽p⁶ are provided by a hint and the circuit checks that
+//
+//	x·w = c^λ, with λ = 6x₀+2+p-p²+p³
+//
+// which holds for some c and w if and only if the final exponentiation of x
+// is one, see https://eprint.iacr.org/2024/640.pdf. As λ is a multiple of r
+// and w^((p¹²-1)/r) = 1 for any w ∈ 𝔽p⁶, the check implies x^((p¹²-1)/r) = 1.
+// It is cheaper than [Pairing.FinalExponentiationIsOne].
+func (pr Pairing) AssertFinalExponentiationIsOne(x *GTEl) {
+	res, err := pr.curveF.NewHint(finalExpHint, 18, &x.C0.B0.A0, &x.C0.B0.A1, &x.C0.B1.A0, &x.C0.B1.A1, &x.C0.B2.A0, &x.C0.B2.A1, &x.C1.B0.A0, &x.C1.B0.A1, &x.C1.B1.A0, &x.C1.B1.A1, &x.C1.B2.A0, &x.C1.B2.A1)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+	residueWitness := GTEl{
+		C0: fields_bn254.E6{
+			B0: fields_bn254.E2{A0: *res[0], A1: *res[1]},
+			B1: fields_bn254.E2{A0: *res[2], A1: *res[3]},
+			B2: fields_bn254.E2{A0: *res[4], A1: *res[5]},
+		},
+		C1: fields_bn254.E6{
+			B0: fields_bn254.E2{A0: *res[6], A1: *res[7]},
+			B1: fields_bn254.E2{A0: *res[8], A1: *res[9]},
+			B2: fields_bn254.E2{A0: *res[10], A1: *res[11]},
+		},
+	}
+	// the scaling factor is constrained to be in 𝔽p⁶
+	scalingFactor := fields_bn254.E6{
+		B0: fields_bn254.E2{A0: *res[12], A1: *res[13]},
+		B1: fields_bn254.E2{A0: *res[14], A1: *res[15]},
+		B2: fields_bn254.E2{A0: *res[16], A1: *res[17]},
+	}
+	// residueWitnessInv = 1/c, which also asserts c ≠ 0
+	residueWitnessInv := pr.Ext12.Inverse(&residueWitness)
+
+	// t = c^(-(6x₀+2)), using c for the negative digits of the 2-NAF
+	t := residueWitnessInv
+	for i := len(loopCounter) - 2; i >= 0; i-- {
+		t = pr.Ext12.Square(t)
+		switch loopCounter[i] {
+		case 1:
+			t = pr.Ext12.Mul(t, residueWitnessInv)
+		case -1:
+			t = pr.Ext12.Mul(t, &residueWitness)
+		}
+	}
+	// t = c^(-λ) = c^(-(6x₀+2)) · c^(-p) · c^(p²) · c^(-p³)
+	t = pr.Ext12.Mul(t, pr.Ext12.Frobenius(residueWitnessInv))
+	t = pr.Ext12.Mul(t, pr.Ext12.FrobeniusSquare(&residueWitness))
+	t = pr.Ext12.Mul(t, pr.Ext12.FrobeniusCube(residueWitnessInv))
+
+	// x·w·c^(-λ) == 1
+	xw := GTEl{
+		C0: *pr.Ext6.Mul(&x.C0, &scalingFactor),
+		C1: *pr.Ext6.Mul(&x.C1, &scalingFactor),
+	}
+	t = pr.Ext12.Mul(t, &xw)
+	pr.AssertIsEqual(t, pr.One())
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/rand"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -112,6 +113,73 @@ func TestPairFixedTestSolve(t *testing.T) {
 	assert.NoError(err)
 }
 
+type PairFixedQCircuit struct {
+	InG1 G1Affine
+	Res  GTEl
+	Q    bn254.G2Affine `gnark:"-"`
+}
+
+func (c *PairFixedQCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	res, err := pairing.PairFixedQ([]*G1Affine{&c.InG1}, []bn254.G2Affine{c.Q})
+	if err != nil {
+		return fmt.Errorf("pair: %w", err)
+	}
+	pairing.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestPairFixedQTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p, q := randomG1G2Affines()
+	res, err := bn254.Pair([]bn254.G1Affine{p}, []bn254.G2Affine{q})
+	assert.NoError(err)
+	witness := PairFixedQCircuit{
+		InG1: NewG1Affine(p),
+		Res:  NewGTEl(res),
+	}
+	err = test.IsSolved(&PairFixedQCircuit{Q: q}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type PairingCheckFixedLinesCircuit struct {
+	In1G1 G1Affine
+	In2G1 G1Affine
+	Lines [2]*G2Lines `gnark:"-"`
+}
+
+func (c *PairingCheckFixedLinesCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	return pairing.PairingCheckFixedLines([]*G1Affine{&c.In1G1, &c.In2G1}, c.Lines[:])
+}
+
+func TestPairingCheckFixedLinesTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p1, q1 := randomG1G2Affines()
+	_, q2 := randomG1G2Affines()
+	var p2 bn254.G1Affine
+	p2.Neg(&p1)
+	circuit := PairingCheckFixedLinesCircuit{
+		Lines: [2]*G2Lines{NewG2Lines(q1), NewG2Lines(q1)},
+	}
+	witness := PairingCheckFixedLinesCircuit{
+		In1G1: NewG1Affine(p1),
+		In2G1: NewG1Affine(p2),
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// e(P, Q1)·e(-P, Q2) ≠ 1
+	circuit.Lines[1] = NewG2Lines(q2)
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
 type MultiPairCircuit struct {
 	InG1 G1Affine
 	InG2 G2Affine
@@ -165,6 +233,155 @@ func TestMultiPairTestSolve(t *testing.T) {
 	}
 }
 
+type PairCompressedCircuit struct {
+	InG1 G1Affine
+	InG2 G2Affine
+	Res  GTCompressed
+}
+
+func (c *PairCompressedCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	res, err := pairing.Pair([]*G1Affine{&c.InG1}, []*G2Affine{&c.InG2})
+	if err != nil {
+		return fmt.Errorf("pair: %w", err)
+	}
+	pairing.AssertIsEqualCompressed(res, &c.Res)
+	return nil
+}
+
+func TestPairCompressedTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p, q := randomG1G2Affines()
+	res, err := bn254.Pair([]bn254.G1Affine{p}, []bn254.G2Affine{q})
+	assert.NoError(err)
+	compressed, err := NewGTCompressed(res)
+	assert.NoError(err)
+	witness := PairCompressedCircuit{
+		InG1: NewG1Affine(p),
+		InG2: NewG2Affine(q),
+		Res:  compressed,
+	}
+	err = test.IsSolved(&PairCompressedCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// wrong result
+	res.Square(&res)
+	witness.Res, err = NewGTCompressed(res)
+	assert.NoError(err)
+	err = test.IsSolved(&PairCompressedCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type GTCompressionCircuit struct {
+	A, B GTEl
+	AB   GTCompressed
+}
+
+func (c *GTCompressionCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	a := pairing.CompressGT(&c.A)
+	pairing.AssertIsEqual(pairing.DecompressGT(a), &c.A)
+	pairing.AssertIsEqualCompressed(&c.A, a)
+	b := pairing.CompressGT(&c.B)
+	ab := pairing.MulGTCompressed(a, b)
+	pairing.AssertIsEqualCompressed(pairing.Mul(&c.A, &c.B), ab)
+	pairing.AssertIsEqualCompressed(pairing.DecompressGT(&c.AB), ab)
+	return nil
+}
+
+func TestGTCompressionTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p1, q1 := randomG1G2Affines()
+	p2, q2 := randomG1G2Affines()
+	a, err := bn254.Pair([]bn254.G1Affine{p1}, []bn254.G2Affine{q1})
+	assert.NoError(err)
+	b, err := bn254.Pair([]bn254.G1Affine{p2}, []bn254.G2Affine{q2})
+	assert.NoError(err)
+	var ab bn254.GT
+	ab.Mul(&a, &b)
+	abCompressed, err := NewGTCompressed(ab)
+	assert.NoError(err)
+	witness := GTCompressionCircuit{
+		A:  NewGTEl(a),
+		B:  NewGTEl(b),
+		AB: abCompressed,
+	}
+	err = test.IsSolved(&GTCompressionCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type FinalExponentiationIsOneCircuit struct {
+	InG1 [2]G1Affine
+	InG2 [2]G2Affine
+}
+
+func (c *FinalExponentiationIsOneCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	ml, err := pairing.MillerLoop([]*G1Affine{&c.InG1[0], &c.InG1[1]}, []*G2Affine{&c.InG2[0], &c.InG2[1]})
+	if err != nil {
+		return fmt.Errorf("miller loop: %w", err)
+	}
+	pairing.AssertFinalExponentiationIsOne(ml)
+	return nil
+}
+
+func TestFinalExponentiationIsOneTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p1, q1 := randomG1G2Affines()
+	_, q2 := randomG1G2Affines()
+	var p2 bn254.G1Affine
+	p2.Neg(&p1)
+	witness := FinalExponentiationIsOneCircuit{
+		InG1: [2]G1Affine{NewG1Affine(p1), NewG1Affine(p2)},
+		InG2: [2]G2Affine{NewG2Affine(q1), NewG2Affine(q1)},
+	}
+	err := test.IsSolved(&FinalExponentiationIsOneCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// e(P, Q1)·e(-P, Q2) ≠ 1
+	witness.InG2[1] = NewG2Affine(q2)
+	err = test.IsSolved(&FinalExponentiationIsOneCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestFinalExpWitness(t *testing.T) {
+	assert := test.NewAssert(t)
+	// a random element whose final exponentiation is one: f = g^r
+	var f bn254.E12
+	_, _ = f.SetRandom()
+	f.Exp(f, bn254.ID.ScalarField())
+	c, w, err := finalExpWitness(&f)
+	assert.NoError(err)
+	// check f·w = c^λ
+	var lhs, rhs, wE12, t0 bn254.E12
+	wE12.C0 = w
+	lhs.Mul(&f, &wE12)
+	x0, _ := new(big.Int).SetString("4965661367192848881", 10)
+	exp := new(big.Int).Mul(x0, big.NewInt(6))
+	exp.Add(exp, big.NewInt(2))
+	rhs.Exp(c, exp)
+	t0.Frobenius(&c)
+	rhs.Mul(&rhs, &t0)
+	t0.FrobeniusSquare(&c)
+	t0.Inverse(&t0)
+	rhs.Mul(&rhs, &t0)
+	t0.FrobeniusCube(&c)
+	rhs.Mul(&rhs, &t0)
+	assert.True(lhs.Equal(&rhs))
+
+	// random element, final exponentiation is not one
+	_, _ = f.SetRandom()
+	_, _, err = finalExpWitness(&f)
+	assert.Error(err)
+}
+
 type PairingCheckCircuit struct {
 	In1G1 G1Affine
 	In2G1 G1Affine
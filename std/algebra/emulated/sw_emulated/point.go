@@ -167,6 +167,43 @@ func (c *Curve[B, S]) AssertIsEqual(p, q *AffinePoint[B]) {
 	c.baseApi.AssertIsEqual(&p.Y, &q.Y)
 }
 
+// IsInfinity returns 1 if p is the point at infinity and 0 otherwise. The
+// point at infinity is conventionally represented as (0,0), see
+// [Curve.AddUnified].
+func (c *Curve[B, S]) IsInfinity(p *AffinePoint[B]) frontend.Variable {
+	return c.api.And(c.baseApi.IsZero(&p.X), c.baseApi.IsZero(&p.Y))
+}
+
+// AssertIsInfinity asserts that p is the point at infinity (0,0).
+func (c *Curve[B, S]) AssertIsInfinity(p *AffinePoint[B]) {
+	c.api.AssertIsEqual(c.IsInfinity(p), 1)
+}
+
+// IsEqual returns 1 if p and q are the same point and 0 otherwise. Either or
+// both can be the point at infinity (0,0).
+func (c *Curve[B, S]) IsEqual(p, q *AffinePoint[B]) frontend.Variable {
+	xEq := c.baseApi.IsZero(c.baseApi.Sub(&p.X, &q.X))
+	yEq := c.baseApi.IsZero(c.baseApi.Sub(&p.Y, &q.Y))
+	return c.api.And(xEq, yEq)
+}
+
+// IsEqualOrNeg returns 1 if p = q or p = -q and 0 otherwise. Either or both
+// can be the point at infinity (0,0), which is its own negation.
+func (c *Curve[B, S]) IsEqualOrNeg(p, q *AffinePoint[B]) frontend.Variable {
+	xEq := c.baseApi.IsZero(c.baseApi.Sub(&p.X, &q.X))
+	yEq := c.baseApi.IsZero(c.baseApi.Sub(&p.Y, &q.Y))
+	yNeg := c.baseApi.IsZero(c.baseApi.Add(&p.Y, &q.Y))
+	return c.api.And(xEq, c.api.Or(yEq, yNeg))
+}
+
+// AssertIsEqualOrNeg asserts that p = q or p = -q. Either or both can be the
+// point at infinity (0,0).
+func (c *Curve[B, S]) AssertIsEqualOrNeg(p, q *AffinePoint[B]) {
+	c.baseApi.AssertIsEqual(&p.X, &q.X)
+	// (p.Y - q.Y)(p.Y + q.Y) = p.Y² - q.Y² == 0
+	c.baseApi.AssertIsEqual(c.baseApi.Mul(&p.Y, &p.Y), c.baseApi.Mul(&q.Y, &q.Y))
+}
+
 // add adds p and q and returns it. It doesn't modify p nor q.
 //
 // ⚠️  p must be different than q and -q, and both nonzero.
@@ -225,9 +262,9 @@ func (c *Curve[B, S]) AssertIsOnCurve(p *AffinePoint[B]) {
 func (c *Curve[B, S]) AddUnified(p, q *AffinePoint[B]) *AffinePoint[B] {
 
 	// selector1 = 1 when p is (0,0) and 0 otherwise
-	selector1 := c.api.And(c.baseApi.IsZero(&p.X), c.baseApi.IsZero(&p.Y))
+	selector1 := c.IsInfinity(p)
 	// selector2 = 1 when q is (0,0) and 0 otherwise
-	selector2 := c.api.And(c.baseApi.IsZero(&q.X), c.baseApi.IsZero(&q.Y))
+	selector2 := c.IsInfinity(q)
 
 	// λ = ((p.x+q.x)² - p.x*q.x + a)/(p.y + q.y)
 	pxqx := c.baseApi.MulMod(&p.X, &q.X)
@@ -536,28 +573,8 @@ func (c *Curve[B, S]) scalarMulGLV(Q *AffinePoint[B], s *emulated.Element[S], op
 	// sub-scalars.
 
 	// decompose s into s1 and s2
-	sd, err := c.scalarApi.NewHint(decomposeScalarG1Subscalars, 2, s, c.eigenvalue)
-	if err != nil {
-		panic(fmt.Sprintf("compute GLV decomposition: %v", err))
-	}
-	s1, s2 := sd[0], sd[1]
-	sdBits, err := c.scalarApi.NewHintWithNativeOutput(decomposeScalarG1Signs, 2, s, c.eigenvalue)
-	if err != nil {
-		panic(fmt.Sprintf("compute GLV decomposition bits: %v", err))
-	}
-	selector1, selector2 := sdBits[0], sdBits[1]
-	s3 := c.scalarApi.Select(selector1, c.scalarApi.Neg(s1), s1)
-	s4 := c.scalarApi.Select(selector2, c.scalarApi.Neg(s2), s2)
-	// s == s3 + [λ]s4
-	c.scalarApi.AssertIsEqual(
-		c.scalarApi.Add(s3, c.scalarApi.Mul(s4, c.eigenvalue)),
-		s,
-	)
-
-	s1bits := c.scalarApi.ToBits(s1)
-	s2bits := c.scalarApi.ToBits(s2)
-	var st S
-	nbits := st.Modulus().BitLen()>>1 + 2
+	s1bits, s2bits, selector1, selector2 := c.decomposeScalarGLV(s)
+	nbits := len(s1bits)
 
 	// precompute -Q, -Φ(Q), Φ(Q)
 	var tableQ, tablePhiQ [3]*AffinePoint[B]
@@ -711,6 +728,62 @@ func (c *Curve[B, S]) scalarMulGLV(Q *AffinePoint[B], s *emulated.Element[S], op
 	return Acc
 }
 
+// decomposeScalarGLV decomposes s with the eigenvalue of the endomorphism of
+// the curve, see [DecomposeScalarGLV].
+func (c *Curve[B, S]) decomposeScalarGLV(s *emulated.Element[S]) (s1bits, s2bits []frontend.Variable, selector1, selector2 frontend.Variable) {
+	return DecomposeScalarGLV(c.api, c.scalarApi, s, c.eigenvalue)
+}
+
+// DecomposeScalarGLV decomposes s into sub-scalars s1, s2 such that
+//
+//	s = ±|s1| ± [λ]|s2| mod r,
+//
+// where λ is the eigenvalue of an endomorphism, e.g. of the GLV endomorphism on
+// G1 or of ψ on G2. It returns the bits of |s1| and |s2| in little-endian
+// order and the selectors which are 1 when the corresponding sub-scalar is
+// negative.
+//
+// The decomposition is computed in a hint and constrained in-circuit: the
+// selectors are boolean, the sub-scalars recombine to s and both |s1| and |s2|
+// fit in the returned number of bits (half the scalar field size plus 2).
+// Without the bound check, a malicious prover could return sub-scalars whose
+// high-order bits are silently ignored by the scalar multiplication loops.
+func DecomposeScalarGLV[S emulated.FieldParams](api frontend.API, scalarApi *emulated.Field[S], s, eigenvalue *emulated.Element[S]) (s1bits, s2bits []frontend.Variable, selector1, selector2 frontend.Variable) {
+	sd, err := scalarApi.NewHint(decomposeScalarG1Subscalars, 2, s, eigenvalue)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+	s1, s2 := sd[0], sd[1]
+	sdBits, err := scalarApi.NewHintWithNativeOutput(decomposeScalarG1Signs, 2, s, eigenvalue)
+	if err != nil {
+		panic(fmt.Sprintf("compute GLV decomposition bits: %v", err))
+	}
+	selector1, selector2 = sdBits[0], sdBits[1]
+	api.AssertIsBoolean(selector1)
+	api.AssertIsBoolean(selector2)
+	s3 := scalarApi.Select(selector1, scalarApi.Neg(s1), s1)
+	s4 := scalarApi.Select(selector2, scalarApi.Neg(s2), s2)
+	// s == s3 + [λ]s4
+	scalarApi.AssertIsEqual(
+		scalarApi.Add(s3, scalarApi.Mul(s4, eigenvalue)),
+		s,
+	)
+
+	var st S
+	nbits := st.Modulus().BitLen()>>1 + 2
+	s1bits = scalarApi.ToBits(s1)
+	s2bits = scalarApi.ToBits(s2)
+	// |s1|, |s2| < 2^nbits
+	for i := nbits; i < len(s1bits); i++ {
+		api.AssertIsEqual(s1bits[i], 0)
+	}
+	for i := nbits; i < len(s2bits); i++ {
+		api.AssertIsEqual(s2bits[i], 0)
+	}
+	return s1bits[:nbits], s2bits[:nbits], selector1, selector2
+}
+
 // scalarMulGeneric computes [s]p and returns it. It doesn't modify p nor s.
 // This function doesn't check that the p is on the curve. See AssertIsOnCurve.
 //
@@ -884,44 +957,10 @@ func (c *Curve[B, S]) jointScalarMulGLVUnsafe(Q, R *AffinePoint[B], s, t *emulat
 	// Φ(R) instead of the corresponding sub-scalars.
 
 	// decompose s into s1 and s2
-	sd, err := c.scalarApi.NewHint(decomposeScalarG1Subscalars, 2, s, c.eigenvalue)
-	if err != nil {
-		// err is non-nil only for invalid number of inputs
-		panic(err)
-	}
-	s1, s2 := sd[0], sd[1]
-	sdBits, err := c.scalarApi.NewHintWithNativeOutput(decomposeScalarG1Signs, 2, s, c.eigenvalue)
-	if err != nil {
-		panic(fmt.Sprintf("compute s GLV decomposition bits: %v", err))
-	}
-	selector1, selector2 := sdBits[0], sdBits[1]
-	s3 := c.scalarApi.Select(selector1, c.scalarApi.Neg(s1), s1)
-	s4 := c.scalarApi.Select(selector2, c.scalarApi.Neg(s2), s2)
-	// s == s3 + [λ]s4
-	c.scalarApi.AssertIsEqual(
-		c.scalarApi.Add(s3, c.scalarApi.Mul(s4, c.eigenvalue)),
-		s,
-	)
-
+	s1bits, s2bits, selector1, selector2 := c.decomposeScalarGLV(s)
 	// decompose t into t1 and t2
-	td, err := c.scalarApi.NewHint(decomposeScalarG1Subscalars, 2, t, c.eigenvalue)
-	if err != nil {
-		// err is non-nil only for invalid number of inputs
-		panic(err)
-	}
-	t1, t2 := td[0], td[1]
-	tdBits, err := c.scalarApi.NewHintWithNativeOutput(decomposeScalarG1Signs, 2, t, c.eigenvalue)
-	if err != nil {
-		panic(fmt.Sprintf("compute t GLV decomposition bits: %v", err))
-	}
-	selector3, selector4 := tdBits[0], tdBits[1]
-	t3 := c.scalarApi.Select(selector3, c.scalarApi.Neg(t1), t1)
-	t4 := c.scalarApi.Select(selector4, c.scalarApi.Neg(t2), t2)
-	// t == t3 + [λ]t4
-	c.scalarApi.AssertIsEqual(
-		c.scalarApi.Add(t3, c.scalarApi.Mul(t4, c.eigenvalue)),
-		t,
-	)
+	t1bits, t2bits, selector3, selector4 := c.decomposeScalarGLV(t)
+	nbits := len(s1bits)
 
 	// precompute -Q, -Φ(Q), Φ(Q)
 	var tableQ, tablePhiQ [2]*AffinePoint[B]
@@ -999,13 +1038,6 @@ func (c *Curve[B, S]) jointScalarMulGLVUnsafe(Q, R *AffinePoint[B], s, t *emulat
 	// Q + R + Φ(Q) + Φ(R) + Φ²(G) ( = -G+Φ²(G) = -2G-Φ(G) )
 	Acc = c.Add(Acc, g)
 
-	s1bits := c.scalarApi.ToBits(s1)
-	s2bits := c.scalarApi.ToBits(s2)
-	t1bits := c.scalarApi.ToBits(t1)
-	t2bits := c.scalarApi.ToBits(t2)
-	var st S
-	nbits := st.Modulus().BitLen()>>1 + 2
-
 	// At each iteration we look up the point Bi from:
 	// 		B1  = +Q + R + Φ(Q) + Φ(R)
 	// 		B2  = +Q + R + Φ(Q) - Φ(R)
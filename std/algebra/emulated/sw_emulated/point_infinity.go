@@ -0,0 +1,180 @@
+package sw_emulated
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// AffinePointWithInfinity represents a point on the elliptic curve with an
+// explicit flag for the point at infinity. When IsInfinity is 1, the
+// coordinates X and Y are ignored.
+//
+// Contrary to [AffinePoint], which represents the point at infinity as (0,0),
+// the operations on this representation are complete: they are correct for
+// any inputs, including the point at infinity and the exceptional cases of
+// the addition formulas. It is meant for gadgets which may legitimately hit
+// the point at infinity, for example multi-scalar multiplications over
+// untrusted scalars.
+type AffinePointWithInfinity[Base emulated.FieldParams] struct {
+	X, Y       emulated.Element[Base]
+	IsInfinity frontend.Variable
+}
+
+// InfinityWithFlag returns the point at infinity.
+func (c *Curve[B, S]) InfinityWithFlag() *AffinePointWithInfinity[B] {
+	zero := c.baseApi.Zero()
+	return &AffinePointWithInfinity[B]{X: *zero, Y: *zero, IsInfinity: 1}
+}
+
+// WithInfinity converts p to the representation with an infinity flag. The
+// point (0,0) is mapped to the point at infinity.
+func (c *Curve[B, S]) WithInfinity(p *AffinePoint[B]) *AffinePointWithInfinity[B] {
+	return &AffinePointWithInfinity[B]{X: p.X, Y: p.Y, IsInfinity: c.IsInfinity(p)}
+}
+
+// WithoutInfinity converts p to the [AffinePoint] representation, mapping the
+// point at infinity to (0,0).
+func (c *Curve[B, S]) WithoutInfinity(p *AffinePointWithInfinity[B]) *AffinePoint[B] {
+	zero := c.baseApi.Zero()
+	return &AffinePoint[B]{
+		X: *c.baseApi.Select(p.IsInfinity, zero, &p.X),
+		Y: *c.baseApi.Select(p.IsInfinity, zero, &p.Y),
+	}
+}
+
+// AssertIsOnCurveWithInfinity asserts that the infinity flag of p is boolean
+// and that p is on the curve when it is not the point at infinity. The other
+// methods on [AffinePointWithInfinity] assume that the flags are boolean.
+func (c *Curve[B, S]) AssertIsOnCurveWithInfinity(p *AffinePointWithInfinity[B]) {
+	c.api.AssertIsBoolean(p.IsInfinity)
+	// replace p by the generator when it is the point at infinity
+	q := c.Select(p.IsInfinity, &c.g, &AffinePoint[B]{X: p.X, Y: p.Y})
+	left := c.baseApi.Mul(&q.Y, &q.Y)
+	right := c.baseApi.Mul(&q.X, c.baseApi.Mul(&q.X, &q.X))
+	right = c.baseApi.Add(right, &c.b)
+	if c.addA {
+		ax := c.baseApi.Mul(&c.a, &q.X)
+		right = c.baseApi.Add(right, ax)
+	}
+	c.baseApi.AssertIsEqual(left, right)
+}
+
+// AssertIsEqualWithInfinity asserts that p and q are the same point, either
+// both the point at infinity or with equal coordinates.
+func (c *Curve[B, S]) AssertIsEqualWithInfinity(p, q *AffinePointWithInfinity[B]) {
+	c.api.AssertIsEqual(p.IsInfinity, q.IsInfinity)
+	c.AssertIsEqual(c.WithoutInfinity(p), c.WithoutInfinity(q))
+}
+
+// SelectWithInfinity selects between p and q given the selector b. If b == 1,
+// then returns p and q otherwise.
+func (c *Curve[B, S]) SelectWithInfinity(b frontend.Variable, p, q *AffinePointWithInfinity[B]) *AffinePointWithInfinity[B] {
+	return &AffinePointWithInfinity[B]{
+		X:          *c.baseApi.Select(b, &p.X, &q.X),
+		Y:          *c.baseApi.Select(b, &p.Y, &q.Y),
+		IsInfinity: c.api.Select(b, p.IsInfinity, q.IsInfinity),
+	}
+}
+
+// NegWithInfinity returns -p.
+func (c *Curve[B, S]) NegWithInfinity(p *AffinePointWithInfinity[B]) *AffinePointWithInfinity[B] {
+	return &AffinePointWithInfinity[B]{
+		X:          p.X,
+		Y:          *c.baseApi.Neg(&p.Y),
+		IsInfinity: p.IsInfinity,
+	}
+}
+
+// AddComplete adds p and q and returns it. It doesn't modify p nor q.
+//
+// ✅ p can be equal to q or -q, and either or both can be the point at
+// infinity.
+//
+// It computes both the chord and the tangent slopes and selects the relevant
+// one, so it is more expensive than [Curve.AddUnified] but doesn't have
+// exceptional cases.
+func (c *Curve[B, S]) AddComplete(p, q *AffinePointWithInfinity[B]) *AffinePointWithInfinity[B] {
+	one := c.baseApi.One()
+	isXEqual := c.baseApi.IsZero(c.baseApi.Sub(&p.X, &q.X))
+	isYOpposite := c.baseApi.IsZero(c.baseApi.Add(&p.Y, &q.Y))
+
+	// chord: λ = (q.y - p.y)/(q.x - p.x)
+	// tangent: λ = (3p.x² + a)/2p.y
+	pxpx := c.baseApi.MulMod(&p.X, &p.X)
+	tangentNum := c.baseApi.MulConst(pxpx, big.NewInt(3))
+	if c.addA {
+		tangentNum = c.baseApi.Add(tangentNum, &c.a)
+	}
+	num := c.baseApi.Select(isXEqual, tangentNum, c.baseApi.Sub(&q.Y, &p.Y))
+	denom := c.baseApi.Select(isXEqual, c.baseApi.MulConst(&p.Y, big.NewInt(2)), c.baseApi.Sub(&q.X, &p.X))
+	// the denominator is zero only when p = -q or in the dummy computations
+	// involving the point at infinity. Assign dummy 1 and continue.
+	denom = c.baseApi.Select(c.baseApi.IsZero(denom), one, denom)
+	λ := c.baseApi.Div(num, denom)
+
+	// x = λ² - p.x - q.x
+	xr := c.baseApi.MulMod(λ, λ)
+	xr = c.baseApi.Sub(xr, c.baseApi.Add(&p.X, &q.X))
+	// y = λ(p.x - x) - p.y
+	yr := c.baseApi.Sub(&p.X, xr)
+	yr = c.baseApi.MulMod(yr, λ)
+	yr = c.baseApi.Sub(yr, &p.Y)
+	result := &AffinePointWithInfinity[B]{
+		X: *c.baseApi.Reduce(xr),
+		Y: *c.baseApi.Reduce(yr),
+		// p = -q
+		IsInfinity: c.api.And(isXEqual, isYOpposite),
+	}
+
+	// if p is the point at infinity return q
+	result = c.SelectWithInfinity(p.IsInfinity, q, result)
+	// if q is the point at infinity return p
+	result = c.SelectWithInfinity(q.IsInfinity, p, result)
+	return result
+}
+
+// ScalarMulComplete computes [s]p and returns it. It doesn't modify p nor s.
+//
+// ✅ p can be the point at infinity and s can be zero, in which case the point
+// at infinity is returned.
+//
+// It uses a double-and-add algorithm with complete formulas, so it is
+// considerably more expensive than [Curve.ScalarMul].
+func (c *Curve[B, S]) ScalarMulComplete(p *AffinePointWithInfinity[B], s *emulated.Element[S]) *AffinePointWithInfinity[B] {
+	res, err := c.MultiScalarMulComplete([]*AffinePointWithInfinity[B]{p}, []*emulated.Element[S]{s})
+	if err != nil {
+		// err is non-nil only for mismatching input lengths
+		panic(err)
+	}
+	return res
+}
+
+// MultiScalarMulComplete computes ∑ᵢ [sᵢ]pᵢ and returns it. It returns an
+// error if the length of the slices mismatch. The doublings are shared across
+// all the points.
+//
+// ✅ Points can be the point at infinity and scalars can be zero.
+func (c *Curve[B, S]) MultiScalarMulComplete(p []*AffinePointWithInfinity[B], s []*emulated.Element[S]) (*AffinePointWithInfinity[B], error) {
+	if len(p) != len(s) {
+		return nil, fmt.Errorf("mismatching points and scalars slice lengths")
+	}
+	sBits := make([][]frontend.Variable, len(s))
+	for i := range s {
+		sBits[i] = c.scalarApi.ToBits(c.scalarApi.Reduce(s[i]))
+	}
+	var st S
+	n := st.Modulus().BitLen()
+	res := c.InfinityWithFlag()
+	for i := n - 1; i >= 0; i-- {
+		if i != n-1 {
+			res = c.AddComplete(res, res)
+		}
+		for j := range p {
+			res = c.SelectWithInfinity(sBits[j][i], c.AddComplete(res, p[j]), res)
+		}
+	}
+	return res, nil
+}
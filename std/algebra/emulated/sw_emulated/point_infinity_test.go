@@ -0,0 +1,132 @@
+package sw_emulated
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	fr_secp "github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+func newSecp256k1PointWithInfinity(p secp256k1.G1Affine) AffinePointWithInfinity[emulated.Secp256k1Fp] {
+	isInfinity := 0
+	if p.IsInfinity() {
+		isInfinity = 1
+	}
+	return AffinePointWithInfinity[emulated.Secp256k1Fp]{
+		X:          emulated.ValueOf[emulated.Secp256k1Fp](p.X),
+		Y:          emulated.ValueOf[emulated.Secp256k1Fp](p.Y),
+		IsInfinity: isInfinity,
+	}
+}
+
+type AddCompleteTest[T, S emulated.FieldParams] struct {
+	P, Q, R AffinePointWithInfinity[T]
+}
+
+func (c *AddCompleteTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	cr.AssertIsOnCurveWithInfinity(&c.P)
+	cr.AssertIsOnCurveWithInfinity(&c.Q)
+	res := cr.AddComplete(&c.P, &c.Q)
+	cr.AssertIsEqualWithInfinity(res, &c.R)
+	return nil
+}
+
+func TestAddComplete(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, g := secp256k1.Generators()
+	var gNeg, h, inf secp256k1.G1Affine
+	gNeg.Neg(&g)
+	h.Double(&g)
+	add := func(p, q secp256k1.G1Affine) secp256k1.G1Affine {
+		var pj, qj secp256k1.G1Jac
+		pj.FromAffine(&p)
+		qj.FromAffine(&q)
+		pj.AddAssign(&qj)
+		var r secp256k1.G1Affine
+		r.FromJacobian(&pj)
+		return r
+	}
+	for _, tc := range [][2]secp256k1.G1Affine{
+		{g, h}, {g, g}, {g, gNeg}, {inf, g}, {g, inf}, {inf, inf},
+	} {
+		circuit := AddCompleteTest[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{}
+		witness := AddCompleteTest[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			P: newSecp256k1PointWithInfinity(tc[0]),
+			Q: newSecp256k1PointWithInfinity(tc[1]),
+			R: newSecp256k1PointWithInfinity(add(tc[0], tc[1])),
+		}
+		err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type MultiScalarMulCompleteTest[T, S emulated.FieldParams] struct {
+	P [2]AffinePointWithInfinity[T]
+	S [2]emulated.Element[S]
+	R AffinePointWithInfinity[T]
+}
+
+func (c *MultiScalarMulCompleteTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	res, err := cr.MultiScalarMulComplete([]*AffinePointWithInfinity[T]{&c.P[0], &c.P[1]}, []*emulated.Element[S]{&c.S[0], &c.S[1]})
+	if err != nil {
+		return err
+	}
+	cr.AssertIsEqualWithInfinity(res, &c.R)
+	return nil
+}
+
+func TestMultiScalarMulComplete(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, g := secp256k1.Generators()
+	var p, pNeg, inf secp256k1.G1Affine
+	s1, err := rand.Int(rand.Reader, fr_secp.Modulus())
+	assert.NoError(err)
+	s2, err := rand.Int(rand.Reader, fr_secp.Modulus())
+	assert.NoError(err)
+	p.ScalarMultiplication(&g, s1)
+	pNeg.Neg(&p)
+	msm := func(p1, p2 secp256k1.G1Affine, s1, s2 *big.Int) secp256k1.G1Affine {
+		var r1, r2 secp256k1.G1Jac
+		r1.FromAffine(&p1)
+		r1.ScalarMultiplication(&r1, s1)
+		r2.FromAffine(&p2)
+		r2.ScalarMultiplication(&r2, s2)
+		r1.AddAssign(&r2)
+		var r secp256k1.G1Affine
+		r.FromJacobian(&r1)
+		return r
+	}
+	zero := big.NewInt(0)
+	for _, tc := range []struct {
+		p1, p2 secp256k1.G1Affine
+		s1, s2 *big.Int
+	}{
+		{g, p, s1, s2},
+		{g, p, zero, s2},
+		{g, p, zero, zero},
+		{inf, p, s1, s2},
+		{p, pNeg, s1, s1},
+	} {
+		circuit := MultiScalarMulCompleteTest[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{}
+		witness := MultiScalarMulCompleteTest[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			P: [2]AffinePointWithInfinity[emulated.Secp256k1Fp]{newSecp256k1PointWithInfinity(tc.p1), newSecp256k1PointWithInfinity(tc.p2)},
+			S: [2]emulated.Element[emulated.Secp256k1Fr]{emulated.ValueOf[emulated.Secp256k1Fr](tc.s1), emulated.ValueOf[emulated.Secp256k1Fr](tc.s2)},
+			R: newSecp256k1PointWithInfinity(msm(tc.p1, tc.p2, tc.s1, tc.s2)),
+		}
+		err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+		assert.NoError(err)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	fr_bls377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	fr_bls381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
@@ -17,7 +19,9 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/secp256k1"
 	fp_secp "github.com/consensys/gnark-crypto/ecc/secp256k1/fp"
 	fr_secp "github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
 	"github.com/consensys/gnark/std/algebra/algopts"
 	"github.com/consensys/gnark/std/math/emulated"
 	"github.com/consensys/gnark/std/math/emulated/emparams"
@@ -195,6 +199,104 @@ func TestNeg(t *testing.T) {
 	assert.NoError(err)
 }
 
+type IsEqualTest[T, S emulated.FieldParams] struct {
+	P, Q                     AffinePoint[T]
+	IsEqual, IsEqualOrNeg    frontend.Variable
+	IsPInfinity, IsQInfinity frontend.Variable
+}
+
+func (c *IsEqualTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(cr.IsEqual(&c.P, &c.Q), c.IsEqual)
+	api.AssertIsEqual(cr.IsEqualOrNeg(&c.P, &c.Q), c.IsEqualOrNeg)
+	api.AssertIsEqual(cr.IsInfinity(&c.P), c.IsPInfinity)
+	api.AssertIsEqual(cr.IsInfinity(&c.Q), c.IsQInfinity)
+	return nil
+}
+
+func TestIsEqual(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, g := secp256k1.Generators()
+	var gNeg, h secp256k1.G1Affine
+	gNeg.Neg(&g)
+	h.Double(&g)
+	var inf secp256k1.G1Affine
+	newPoint := func(p secp256k1.G1Affine) AffinePoint[emulated.Secp256k1Fp] {
+		return AffinePoint[emulated.Secp256k1Fp]{
+			X: emulated.ValueOf[emulated.Secp256k1Fp](p.X),
+			Y: emulated.ValueOf[emulated.Secp256k1Fp](p.Y),
+		}
+	}
+	for _, tc := range []struct {
+		p, q                          secp256k1.G1Affine
+		isEqual, isEqualOrNeg, pI, qI int
+	}{
+		{g, g, 1, 1, 0, 0},
+		{g, gNeg, 0, 1, 0, 0},
+		{g, h, 0, 0, 0, 0},
+		{inf, inf, 1, 1, 1, 1},
+		{inf, g, 0, 0, 1, 0},
+		{g, inf, 0, 0, 0, 1},
+	} {
+		circuit := IsEqualTest[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{}
+		witness := IsEqualTest[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			P:            newPoint(tc.p),
+			Q:            newPoint(tc.q),
+			IsEqual:      tc.isEqual,
+			IsEqualOrNeg: tc.isEqualOrNeg,
+			IsPInfinity:  tc.pI,
+			IsQInfinity:  tc.qI,
+		}
+		err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type AssertIsEqualOrNegTest[T, S emulated.FieldParams] struct {
+	P, Q AffinePoint[T]
+}
+
+func (c *AssertIsEqualOrNegTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	cr.AssertIsEqualOrNeg(&c.P, &c.Q)
+	return nil
+}
+
+func TestAssertIsEqualOrNeg(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, g := secp256k1.Generators()
+	var gNeg, h secp256k1.G1Affine
+	gNeg.Neg(&g)
+	h.Double(&g)
+	newPoint := func(p secp256k1.G1Affine) AffinePoint[emulated.Secp256k1Fp] {
+		return AffinePoint[emulated.Secp256k1Fp]{
+			X: emulated.ValueOf[emulated.Secp256k1Fp](p.X),
+			Y: emulated.ValueOf[emulated.Secp256k1Fp](p.Y),
+		}
+	}
+	circuit := AssertIsEqualOrNegTest[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{}
+	for _, q := range []secp256k1.G1Affine{g, gNeg} {
+		witness := AssertIsEqualOrNegTest[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			P: newPoint(g),
+			Q: newPoint(q),
+		}
+		err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+		assert.NoError(err)
+	}
+	witness := AssertIsEqualOrNegTest[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		P: newPoint(g),
+		Q: newPoint(h),
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.Error(err)
+}
+
 type AddTest[T, S emulated.FieldParams] struct {
 	P, Q, R AffinePoint[T]
 }
@@ -619,6 +721,28 @@ func TestScalarMulBase6(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestScalarMulBase7(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g, _ := bls12377.Generators()
+	var r fr_bls377.Element
+	_, _ = r.SetRandom()
+	s := new(big.Int)
+	r.BigInt(s)
+	var S bls12377.G1Affine
+	S.ScalarMultiplication(&g, s)
+
+	circuit := ScalarMulBaseTest[emulated.BLS12377Fp, emulated.BLS12377Fr]{}
+	witness := ScalarMulBaseTest[emulated.BLS12377Fp, emulated.BLS12377Fr]{
+		S: emulated.ValueOf[emulated.BLS12377Fr](s),
+		Q: AffinePoint[emulated.BLS12377Fp]{
+			X: emulated.ValueOf[emulated.BLS12377Fp](S.X),
+			Y: emulated.ValueOf[emulated.BLS12377Fp](S.Y),
+		},
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
 type ScalarMulTest[T, S emulated.FieldParams] struct {
 	P, Q AffinePoint[T]
 	S    emulated.Element[S]
@@ -784,6 +908,32 @@ func TestScalarMul6(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestScalarMul7(t *testing.T) {
+	assert := test.NewAssert(t)
+	var r fr_bls377.Element
+	_, _ = r.SetRandom()
+	s := new(big.Int)
+	r.BigInt(s)
+	var res bls12377.G1Affine
+	_, _, gen, _ := bls12377.Generators()
+	res.ScalarMultiplication(&gen, s)
+
+	circuit := ScalarMulTest[emulated.BLS12377Fp, emulated.BLS12377Fr]{}
+	witness := ScalarMulTest[emulated.BLS12377Fp, emulated.BLS12377Fr]{
+		S: emulated.ValueOf[emulated.BLS12377Fr](s),
+		P: AffinePoint[emulated.BLS12377Fp]{
+			X: emulated.ValueOf[emulated.BLS12377Fp](gen.X),
+			Y: emulated.ValueOf[emulated.BLS12377Fp](gen.Y),
+		},
+		Q: AffinePoint[emulated.BLS12377Fp]{
+			X: emulated.ValueOf[emulated.BLS12377Fp](res.X),
+			Y: emulated.ValueOf[emulated.BLS12377Fp](res.Y),
+		},
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
 type ScalarMulEdgeCasesTest[T, S emulated.FieldParams] struct {
 	P, R AffinePoint[T]
 	S    emulated.Element[S]
@@ -1912,3 +2062,82 @@ func TestMux(t *testing.T) {
 	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
 	assert.NoError(err)
 }
+
+type DecomposeScalarGLVTest[T, S emulated.FieldParams] struct {
+	S emulated.Element[S]
+}
+
+func (c *DecomposeScalarGLVTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	cr.decomposeScalarGLV(&c.S)
+	return nil
+}
+
+func TestDecomposeScalarGLV(t *testing.T) {
+	assert := test.NewAssert(t)
+	var r fr_secp.Element
+	_, _ = r.SetRandom()
+	s := new(big.Int)
+	r.BigInt(s)
+	ccs, err := frontend.Compile(testCurve.ScalarField(), scs.NewBuilder, &DecomposeScalarGLVTest[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{})
+	assert.NoError(err)
+	witness, err := frontend.NewWitness(&DecomposeScalarGLVTest[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+	}, testCurve.ScalarField())
+	assert.NoError(err)
+	err = ccs.IsSolved(witness)
+	assert.NoError(err)
+
+	// the trivial decomposition s = s + [λ]0 recombines correctly but the
+	// first sub-scalar doesn't fit in the expected number of bits.
+	maliciousSubscalars := func(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+		return emulated.UnwrapHint(inputs, outputs, func(field *big.Int, inputs, outputs []*big.Int) error {
+			outputs[0].Set(inputs[0])
+			outputs[1].SetUint64(0)
+			return nil
+		})
+	}
+	maliciousSigns := func(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+		return emulated.UnwrapHintWithNativeOutput(inputs, outputs, func(field *big.Int, inputs, outputs []*big.Int) error {
+			outputs[0].SetUint64(0)
+			outputs[1].SetUint64(0)
+			return nil
+		})
+	}
+	err = ccs.IsSolved(witness,
+		solver.OverrideHint(solver.GetHintID(decomposeScalarG1Subscalars), maliciousSubscalars),
+		solver.OverrideHint(solver.GetHintID(decomposeScalarG1Signs), maliciousSigns),
+	)
+	assert.Error(err)
+}
+
+type DecomposeScalarGLVEigenvalueTest[S emulated.FieldParams] struct {
+	S          emulated.Element[S]
+	Eigenvalue emulated.Element[S]
+}
+
+func (c *DecomposeScalarGLVEigenvalueTest[S]) Define(api frontend.API) error {
+	scalarApi, err := emulated.NewField[S](api)
+	if err != nil {
+		return err
+	}
+	DecomposeScalarGLV(api, scalarApi, &c.S, &c.Eigenvalue)
+	return nil
+}
+
+func TestDecomposeScalarGLVG2(t *testing.T) {
+	assert := test.NewAssert(t)
+	// ψ acts on the G2 of BN254 as the multiplication by p mod r.
+	r := emulated.BN254Fr{}.Modulus()
+	eigenvalue := new(big.Int).Mod(emulated.BN254Fp{}.Modulus(), r)
+	var s fr_bn.Element
+	_, _ = s.SetRandom()
+	err := test.IsSolved(&DecomposeScalarGLVEigenvalueTest[emulated.BN254Fr]{}, &DecomposeScalarGLVEigenvalueTest[emulated.BN254Fr]{
+		S:          emulated.ValueOf[emulated.BN254Fr](s.BigInt(new(big.Int))),
+		Eigenvalue: emulated.ValueOf[emulated.BN254Fr](eigenvalue),
+	}, testCurve.ScalarField())
+	assert.NoError(err)
+}
@@ -5,4 +5,7 @@
 // Examples:
 // Jubjub, Bandersnatch (a twisted Edwards) is defined over BLS12-381's scalar field
 // Baby-Jubjub (a twisted Edwards) is defined over BN254's salar fields
+//
+// Curves which are not in gnark-crypto, or other representations of the same
+// curves (e.g. circomlib's Baby-Jubjub), can be used with NewEdCurveFromParams.
 package twistededwards
@@ -0,0 +1,103 @@
+package twistededwards
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type customCurveCircuit struct {
+	params          *CurveParams `gnark:"-"`
+	S               frontend.Variable
+	ScalarMulResult Point
+}
+
+func (circuit *customCurveCircuit) Define(api frontend.API) error {
+	curve, err := NewEdCurveFromParams(api, circuit.params, nil)
+	if err != nil {
+		return err
+	}
+	base := Point{X: circuit.params.Base[0], Y: circuit.params.Base[1]}
+	curve.AssertIsOnCurve(base)
+
+	res := curve.ScalarMul(base, circuit.S)
+	api.AssertIsEqual(res.X, circuit.ScalarMulResult.X)
+	api.AssertIsEqual(res.Y, circuit.ScalarMulResult.Y)
+
+	// the base point generates the subgroup of the given order
+	res = curve.ScalarMul(base, circuit.params.Order)
+	api.AssertIsEqual(res.X, 0)
+	api.AssertIsEqual(res.Y, 1)
+	return nil
+}
+
+// nativeScalarMul computes [s]p on the curve of the given parameters over the
+// field of modulus q, with the affine addition law.
+func nativeScalarMul(params *CurveParams, q *big.Int, p Point, s *big.Int) Point {
+	add := func(p1, p2 [2]*big.Int) [2]*big.Int {
+		var t, num, den big.Int
+		t.Mul(params.D, p1[0]).Mul(&t, p2[0]).Mul(&t, p1[1]).Mul(&t, p2[1]).Mod(&t, q)
+		x, y := new(big.Int), new(big.Int)
+		num.Mul(p1[0], p2[1]).Add(&num, new(big.Int).Mul(p1[1], p2[0]))
+		den.Add(big.NewInt(1), &t).ModInverse(&den, q)
+		x.Mul(&num, &den).Mod(x, q)
+		num.Mul(p1[1], p2[1]).Sub(&num, new(big.Int).Mul(params.A, new(big.Int).Mul(p1[0], p2[0])))
+		den.Sub(big.NewInt(1), &t).Mod(&den, q).ModInverse(&den, q)
+		y.Mul(&num, &den).Mod(y, q)
+		return [2]*big.Int{x, y}
+	}
+	res := [2]*big.Int{big.NewInt(0), big.NewInt(1)}
+	acc := [2]*big.Int{p.X.(*big.Int), p.Y.(*big.Int)}
+	for i := 0; i < s.BitLen(); i++ {
+		if s.Bit(i) == 1 {
+			res = add(res, acc)
+		}
+		acc = add(acc, acc)
+	}
+	return Point{X: res[0], Y: res[1]}
+}
+
+func TestCircomBabyJubjub(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	params := GetCircomBabyJubjubParams()
+	assert.NoError(params.check(ecc.BN254.ScalarField()))
+
+	s := params.randomScalar()
+	base := Point{X: params.Base[0], Y: params.Base[1]}
+
+	var witness, invalidWitness customCurveCircuit
+	witness.S = s
+	witness.ScalarMulResult = nativeScalarMul(params, ecc.BN254.ScalarField(), base, s)
+	invalidWitness.S = new(big.Int).Add(s, big.NewInt(1))
+	invalidWitness.ScalarMulResult = witness.ScalarMulResult
+
+	assert.CheckCircuit(&customCurveCircuit{params: params},
+		test.WithValidAssignment(&witness),
+		test.WithInvalidAssignment(&invalidWitness),
+		test.WithCurves(ecc.BN254))
+}
+
+func TestCurveParamsCheck(t *testing.T) {
+	assert := test.NewAssert(t)
+	q := ecc.BN254.ScalarField()
+
+	for _, curve := range curves {
+		params, err := GetCurveParams(curve)
+		assert.NoError(err)
+		snarkField, err := GetSnarkField(curve)
+		assert.NoError(err)
+		assert.NoError(params.check(snarkField), "curve %d", curve)
+	}
+
+	params := GetCircomBabyJubjubParams()
+	params.Base[1].Add(params.Base[1], big.NewInt(1))
+	assert.Error(params.check(q), "base point not on the curve")
+
+	params = GetCircomBabyJubjubParams()
+	params.D.Set(params.A)
+	assert.Error(params.check(q), "singular curve")
+}
@@ -77,21 +77,66 @@ func NewEdCurve(api frontend.API, id twistededwards.ID) (Curve, error) {
 	if err != nil {
 		return nil, err
 	}
-	var endo *EndoParams
 
-	// bandersnatch
-	if id == twistededwards.BLS12_381_BANDERSNATCH {
-		endo = &EndoParams{
-			Endo:   [2]*big.Int{new(big.Int), new(big.Int)},
-			Lambda: new(big.Int),
-		}
-		endo.Endo[0].SetString("37446463827641770816307242315180085052603635617490163568005256780843403514036", 10)
-		endo.Endo[1].SetString("49199877423542878313146170939139662862850515542392585932876811575731455068989", 10)
-		endo.Lambda.SetString("8913659658109529928382530854484400854125314752504019737736543920008458395397", 10)
+	// default
+	return &curve{api: api, params: params, endo: getEndoParams(id), id: id}, nil
+}
+
+// NewEdCurveFromParams returns a twisted Edwards curve ax^2 + y^2 = 1 + d*x^2*y^2
+// defined over the native field of api by the given parameters. It allows using
+// curves which are not in gnark-crypto, or other representations of the same
+// curve, for example [GetCircomBabyJubjubParams]. endo may be nil if the curve
+// has no efficient endomorphism.
+//
+// The parameters are checked to define a non-singular curve, and the base point
+// to be on it.
+func NewEdCurveFromParams(api frontend.API, params *CurveParams, endo *EndoParams) (Curve, error) {
+	if err := params.check(api.Compiler().Field()); err != nil {
+		return nil, err
 	}
+	return &curve{api: api, params: params, endo: endo}, nil
+}
 
-	// default
-	return &curve{api: api, params: params, endo: endo, id: id}, nil
+// check checks that the parameters define a twisted Edwards curve over the
+// field of modulus q, with the base point on it.
+func (p *CurveParams) check(q *big.Int) error {
+	if p.A == nil || p.D == nil || p.Order == nil || p.Cofactor == nil || p.Base[0] == nil || p.Base[1] == nil {
+		return errors.New("missing twisted edwards curve parameter")
+	}
+	var a, d big.Int
+	a.Mod(p.A, q)
+	d.Mod(p.D, q)
+	if a.Sign() == 0 || d.Sign() == 0 || a.Cmp(&d) == 0 {
+		return errors.New("singular twisted edwards curve; a and d must be distinct and non-zero")
+	}
+
+	// a*x^2 + y^2 == 1 + d*x^2*y^2
+	var xx, yy, lhs, rhs big.Int
+	xx.Mul(p.Base[0], p.Base[0])
+	yy.Mul(p.Base[1], p.Base[1])
+	lhs.Mul(&a, &xx).Add(&lhs, &yy).Mod(&lhs, q)
+	rhs.Mul(&d, &xx).Mul(&rhs, &yy).Add(&rhs, big.NewInt(1)).Mod(&rhs, q)
+	if lhs.Cmp(&rhs) != 0 {
+		return errors.New("twisted edwards base point is not on the curve")
+	}
+	return nil
+}
+
+// getEndoParams returns the endomorphism parameters of the curve, or nil if
+// the curve has no efficient endomorphism.
+func getEndoParams(id twistededwards.ID) *EndoParams {
+	// bandersnatch
+	if id != twistededwards.BLS12_381_BANDERSNATCH {
+		return nil
+	}
+	endo := &EndoParams{
+		Endo:   [2]*big.Int{new(big.Int), new(big.Int)},
+		Lambda: new(big.Int),
+	}
+	endo.Endo[0].SetString("37446463827641770816307242315180085052603635617490163568005256780843403514036", 10)
+	endo.Endo[1].SetString("49199877423542878313146170939139662862850515542392585932876811575731455068989", 10)
+	endo.Lambda.SetString("8913659658109529928382530854484400854125314752504019737736543920008458395397", 10)
+	return endo
 }
 
 func GetCurveParams(id twistededwards.ID) (*CurveParams, error) {
@@ -119,6 +164,26 @@ func GetCurveParams(id twistededwards.ID) (*CurveParams, error) {
 	return params, nil
 }
 
+// GetCircomBabyJubjubParams returns the parameters of Baby Jubjub as defined
+// in EIP-2494 and used by circomlib, to interoperate with circuits and
+// signatures from the circom ecosystem. This is the same curve as
+// [twistededwards.BN254] in a different representation (a = 168700,
+// d = 168696), so points are not interchangeable between the two. The base
+// point is the generator of the prime order subgroup (Base8 in circomlib).
+//
+// The returned parameters are to be used with [NewEdCurveFromParams] over the
+// BN254 scalar field.
+func GetCircomBabyJubjubParams() *CurveParams {
+	r := newCurveParams()
+	r.A.SetUint64(168700)
+	r.D.SetUint64(168696)
+	r.Cofactor.SetUint64(8)
+	r.Order.SetString("2736030358979909402780800718157159386076813972158567259200215660948447373041", 10)
+	r.Base[0].SetString("5299619240641551281634865583518297030282874472190772894086521144482721001553", 10)
+	r.Base[1].SetString("16950150798460657717958625567821834550301663161624707787222815936182638968203", 10)
+	return r
+}
+
 // GetSnarkField returns the matching snark curve for a twisted edwards curve
 func GetSnarkField(id twistededwards.ID) (*big.Int, error) {
 	switch id {
@@ -0,0 +1,113 @@
+// Package bitcoin provides components to verify Bitcoin simplified payment
+// verification (SPV) proofs in circuit: the transaction Merkle branches and
+// the proof of work of the 80-byte block headers.
+//
+// Hashes are given in internal byte order, as they are serialized in the
+// headers and computed by [DoubleSHA256]. Block explorers display them
+// reversed.
+package bitcoin
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+const (
+	// HashSize is the size in bytes of the hashes.
+	HashSize = 32
+	// HeaderSize is the size in bytes of a serialized block header.
+	HeaderSize = 80
+)
+
+// offsets of the fields of a block header.
+const (
+	offsetPrevHash   = 4
+	offsetMerkleRoot = 36
+	offsetBits       = 72
+)
+
+// DoubleSHA256 returns SHA-256(SHA-256(data)).
+func DoubleSHA256(api frontend.API, data ...[]uints.U8) ([]uints.U8, error) {
+	h, err := sha2.New(api)
+	if err != nil {
+		return nil, err
+	}
+	for i := range data {
+		h.Write(data[i])
+	}
+	first := h.Sum()
+	if h, err = sha2.New(api); err != nil {
+		return nil, err
+	}
+	h.Write(first)
+	return h.Sum(), nil
+}
+
+// VerifyMerkleBranch asserts that txid is the transaction at position index in
+// the block of the given Merkle root. The branch holds the siblings of the
+// path, from the transaction up to the root, and the index is range checked to
+// len(branch) bits.
+func VerifyMerkleBranch(api frontend.API, txid []uints.U8, branch [][]uints.U8, index frontend.Variable, root []uints.U8) error {
+	if len(txid) != HashSize || len(root) != HashSize {
+		return fmt.Errorf("hashes must be %d bytes long", HashSize)
+	}
+	path := api.ToBinary(index, len(branch))
+	node := txid
+	for i := range branch {
+		if len(branch[i]) != HashSize {
+			return fmt.Errorf("sibling %d is %d bytes long", i, len(branch[i]))
+		}
+		// the node is on the right when the bit of the index is set.
+		left, right := selectBytes(api, path[i], branch[i], node), selectBytes(api, path[i], node, branch[i])
+		var err error
+		if node, err = DoubleSHA256(api, left, right); err != nil {
+			return err
+		}
+	}
+	return assertBytesEqual(api, node, root)
+}
+
+// HeaderHash returns the hash of the block header.
+func HeaderHash(api frontend.API, header []uints.U8) ([]uints.U8, error) {
+	if len(header) != HeaderSize {
+		return nil, fmt.Errorf("header must be %d bytes long", HeaderSize)
+	}
+	return DoubleSHA256(api, header)
+}
+
+// MerkleRoot returns the transactions Merkle root field of the block header.
+func MerkleRoot(header []uints.U8) []uints.U8 {
+	return header[offsetMerkleRoot : offsetMerkleRoot+HashSize]
+}
+
+// PrevHash returns the previous block hash field of the block header.
+func PrevHash(header []uints.U8) []uints.U8 {
+	return header[offsetPrevHash : offsetPrevHash+HashSize]
+}
+
+// Bits returns the compact target field of the block header.
+func Bits(header []uints.U8) []uints.U8 {
+	return header[offsetBits : offsetBits+4]
+}
+
+func selectBytes(api frontend.API, b frontend.Variable, x, y []uints.U8) []uints.U8 {
+	res := make([]uints.U8, len(x))
+	for i := range x {
+		res[i] = uints.U8{Val: api.Select(b, x[i].Val, y[i].Val)}
+	}
+	return res
+}
+
+func assertBytesEqual(api frontend.API, a, b []uints.U8) error {
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	for i := range a {
+		uapi.ByteAssertEq(a[i], b[i])
+	}
+	return nil
+}
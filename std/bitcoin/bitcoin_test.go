@@ -0,0 +1,132 @@
+package bitcoin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+// headers of the blocks 0 and 1 of the Bitcoin network.
+const (
+	genesisHeader = "0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4a29ab5f49ffff001d1dac2b7c"
+	block1Header  = "010000006fe28c0ab6f1b372c1a6a246ae63f74f931e8365e15a089c68d6190000000000982051fd1e4ba744bbbe680e1fee14677ba1a3c3540bf7b1cdb606e857233e0e61bc6649ffff001d01e36299"
+)
+
+func nativeDoubleSHA256(data ...[]byte) []byte {
+	h := sha256.New()
+	for i := range data {
+		h.Write(data[i])
+	}
+	first := sha256.Sum256(h.Sum(nil))
+	return first[:]
+}
+
+type chainCircuit struct {
+	Headers [2][HeaderSize]uints.U8
+	Hash    [HashSize]uints.U8
+}
+
+func (c *chainCircuit) Define(api frontend.API) error {
+	hash, err := AssertHeaderChain(api, c.Headers[0][:], c.Headers[1][:])
+	if err != nil {
+		return err
+	}
+	return assertBytesEqual(api, hash, c.Hash[:])
+}
+
+func TestHeaderChain(t *testing.T) {
+	assert := test.NewAssert(t)
+	headers := make([][]byte, 2)
+	for i, h := range []string{genesisHeader, block1Header} {
+		var err error
+		headers[i], err = hex.DecodeString(h)
+		assert.NoError(err)
+	}
+	assignment := func(headers [][]byte) *chainCircuit {
+		var w chainCircuit
+		for i := range headers {
+			copy(w.Headers[i][:], uints.NewU8Array(headers[i]))
+		}
+		copy(w.Hash[:], uints.NewU8Array(nativeDoubleSHA256(headers[1])))
+		return &w
+	}
+	assert.NoError(test.IsSolved(&chainCircuit{}, assignment(headers), ecc.BN254.ScalarField()))
+
+	// the nonce doesn't give a valid proof of work
+	tampered := [][]byte{headers[0], append([]byte{}, headers[1]...)}
+	tampered[1][HeaderSize-1] ^= 1
+	assert.Error(test.IsSolved(&chainCircuit{}, assignment(tampered), ecc.BN254.ScalarField()))
+}
+
+type targetCircuit struct {
+	Hash [HashSize]uints.U8
+	Bits [4]uints.U8
+}
+
+func (c *targetCircuit) Define(api frontend.API) error {
+	assertTarget(api, c.Hash[:], c.Bits[:])
+	return nil
+}
+
+func TestTarget(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := func(hash map[int]uint8, bits ...uint8) *targetCircuit {
+		var w targetCircuit
+		var h [HashSize]byte
+		for i, b := range hash {
+			h[i] = b
+		}
+		copy(w.Hash[:], uints.NewU8Array(h[:]))
+		copy(w.Bits[:], uints.NewU8Array(bits))
+		return &w
+	}
+	// target = 0x123456 · 256^2
+	bits := []uint8{0x56, 0x34, 0x12, 5}
+	assert.CheckCircuit(&targetCircuit{},
+		test.WithValidAssignment(assignment(map[int]uint8{4: 0x12, 3: 0x34, 2: 0x55, 1: 0xff}, bits...)),
+		test.WithValidAssignment(assignment(map[int]uint8{4: 0x12, 3: 0x34, 2: 0x56}, bits...)),
+		test.WithValidAssignment(assignment(map[int]uint8{0: 0xff}, bits...)),
+		test.WithInvalidAssignment(assignment(map[int]uint8{4: 0x12, 3: 0x34, 2: 0x56, 0: 1}, bits...)),
+		test.WithInvalidAssignment(assignment(map[int]uint8{4: 0x12, 3: 0x34, 2: 0x57}, bits...)),
+		test.WithInvalidAssignment(assignment(map[int]uint8{5: 1}, bits...)),
+		test.WithInvalidAssignment(assignment(nil, 0x56, 0x34, 0x12, 33)),
+		test.WithInvalidAssignment(assignment(nil, 0x56, 0x34, 0x92, 5)),
+		test.WithCurves(ecc.BN254))
+}
+
+type branchCircuit struct {
+	TxID   [HashSize]uints.U8
+	Branch [2][HashSize]uints.U8
+	Index  frontend.Variable
+	Root   [HashSize]uints.U8
+}
+
+func (c *branchCircuit) Define(api frontend.API) error {
+	return VerifyMerkleBranch(api, c.TxID[:], [][]uints.U8{c.Branch[0][:], c.Branch[1][:]}, c.Index, c.Root[:])
+}
+
+func TestMerkleBranch(t *testing.T) {
+	assert := test.NewAssert(t)
+	var txids [4][]byte
+	for i := range txids {
+		txids[i] = nativeDoubleSHA256([]byte{byte(i)})
+	}
+	left, right := nativeDoubleSHA256(txids[0], txids[1]), nativeDoubleSHA256(txids[2], txids[3])
+	root := nativeDoubleSHA256(left, right)
+
+	var w branchCircuit
+	copy(w.TxID[:], uints.NewU8Array(txids[2]))
+	copy(w.Branch[0][:], uints.NewU8Array(txids[3]))
+	copy(w.Branch[1][:], uints.NewU8Array(left))
+	copy(w.Root[:], uints.NewU8Array(root))
+	w.Index = 2
+	assert.NoError(test.IsSolved(&branchCircuit{}, &w, ecc.BN254.ScalarField()))
+
+	w.Index = 3
+	assert.Error(test.IsSolved(&branchCircuit{}, &w, ecc.BN254.ScalarField()))
+}
@@ -0,0 +1,107 @@
+package bitcoin
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// AssertProofOfWork asserts that the hash of the block header, read as a
+// little-endian 256-bit integer, is at most the target encoded in the compact
+// bits field of the header, and returns the hash.
+//
+// The proof of work is checked against the target of the header itself. The
+// caller is responsible for constraining it, with [Bits], to the difficulty
+// expected for the chain.
+func AssertProofOfWork(api frontend.API, header []uints.U8) ([]uints.U8, error) {
+	hash, err := HeaderHash(api, header)
+	if err != nil {
+		return nil, err
+	}
+	assertTarget(api, hash, Bits(header))
+	return hash, nil
+}
+
+// AssertHeaderChain asserts that each header satisfies its proof of work, as
+// [AssertProofOfWork], and extends the previous header. It returns the hash of
+// the last header.
+func AssertHeaderChain(api frontend.API, headers ...[]uints.U8) ([]uints.U8, error) {
+	if len(headers) == 0 {
+		return nil, fmt.Errorf("no header")
+	}
+	var prev []uints.U8
+	for i := range headers {
+		hash, err := AssertProofOfWork(api, headers[i])
+		if err != nil {
+			return nil, fmt.Errorf("header %d: %w", i, err)
+		}
+		if prev != nil {
+			if err = assertBytesEqual(api, PrevHash(headers[i]), prev); err != nil {
+				return nil, err
+			}
+		}
+		prev = hash
+	}
+	return prev, nil
+}
+
+// assertTarget asserts that hash ≤ target, where the target is encoded in the
+// compact form bits = mantissa || exponent (little-endian), for
+//
+//	target = mantissa · 256^(exponent-3).
+//
+// The exponent must be in [3, 32] and the mantissa must be positive, as for the
+// targets of the Bitcoin network. With the window w of the three bytes of the
+// hash at [exponent-3, exponent), hash ≤ target iff the bytes above the window
+// are zero and w < mantissa, or w = mantissa and the bytes below are zero.
+func assertTarget(api frontend.API, hash, bits []uints.U8) {
+	rc := rangecheck.New(api)
+	rc.Check(bits[0].Val, 8)
+	rc.Check(bits[1].Val, 8)
+	rc.Check(bits[3].Val, 8)
+	// the sign bit of the mantissa is not set
+	rc.Check(bits[2].Val, 7)
+	mantissa := api.Add(bits[0].Val, api.Mul(bits[1].Val, 1<<8), api.Mul(bits[2].Val, 1<<16))
+	exponent := bits[3].Val
+
+	// isExponent[k] = [exponent = k], exactly one is set.
+	var isExponent [HashSize + 1]frontend.Variable
+	nbSet := frontend.Variable(0)
+	for k := range isExponent {
+		if k < 3 {
+			isExponent[k] = 0
+			continue
+		}
+		isExponent[k] = api.IsZero(api.Sub(exponent, k))
+		nbSet = api.Add(nbSet, isExponent[k])
+	}
+	api.AssertIsEqual(nbSet, 1)
+
+	// geExponent[j] = [j ≥ exponent]
+	var geExponent [HashSize + 1]frontend.Variable
+	geExponent[0] = isExponent[0]
+	for j := 1; j < len(geExponent); j++ {
+		geExponent[j] = api.Add(geExponent[j-1], isExponent[j])
+	}
+
+	window, below := frontend.Variable(0), frontend.Variable(0)
+	for j := 0; j < HashSize; j++ {
+		// bytes above the window are zero
+		api.AssertIsEqual(api.Mul(geExponent[j], hash[j].Val), 0)
+		// bytes below the window, j < exponent-3
+		if j+3 < len(geExponent) {
+			below = api.Add(below, api.Mul(api.Sub(1, geExponent[j+3]), hash[j].Val))
+		}
+	}
+	for k := 3; k < len(isExponent); k++ {
+		w := api.Add(hash[k-3].Val, api.Mul(hash[k-2].Val, 1<<8), api.Mul(hash[k-1].Val, 1<<16))
+		window = api.Add(window, api.Mul(isExponent[k], w))
+	}
+
+	// the difference wraps around the field if w > mantissa
+	diff := api.Sub(mantissa, window)
+	rc.Check(diff, 24)
+	api.AssertIsEqual(api.Mul(api.IsZero(diff), below), 0)
+}
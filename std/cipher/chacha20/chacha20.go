@@ -0,0 +1,153 @@
+// Package chacha20 implements the ChaCha20 stream cipher, as defined in RFC
+// 8439, in a circuit.
+//
+// It allows the witness of a circuit to be given encrypted: the circuit takes
+// the ciphertext, the key and a commitment to the key, and decrypts the
+// ciphertext before operating on the plaintext (see [ChaCha20.DecryptCommitted]).
+// When the ciphertext and the key commitment are public inputs, the proof
+// attests that the statement holds for the data encrypted by the holder of
+// the key, and the data can be stored or forwarded encrypted. Note that the
+// prover still needs the key to compute the witness.
+//
+// The operations on 32-bit words use the lookup tables of [uints], so that the
+// builder must implement [frontend.Committer].
+package chacha20
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+const (
+	// KeySize is the size of the key in bytes.
+	KeySize = 32
+	// NonceSize is the size of the nonce in bytes.
+	NonceSize = 12
+	// BlockSize is the size of a keystream block in bytes.
+	BlockSize = 64
+)
+
+// constants are the words of "expand 32-byte k".
+var constants = [4]uint32{0x61707865, 0x3320646e, 0x79622d32, 0x6b206574}
+
+// ChaCha20 computes the ChaCha20 keystream in a circuit.
+type ChaCha20 struct {
+	api  frontend.API
+	uapi *uints.BinaryField[uints.U32]
+}
+
+// New returns a new ChaCha20 instance.
+func New(api frontend.API) (*ChaCha20, error) {
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return nil, fmt.Errorf("new uints: %w", err)
+	}
+	return &ChaCha20{api: api, uapi: uapi}, nil
+}
+
+// Block returns the keystream block for the key, block counter and nonce, as
+// little-endian words.
+func (c *ChaCha20) Block(key [8]uints.U32, counter uints.U32, nonce [3]uints.U32) [16]uints.U32 {
+	var init [16]uints.U32
+	for i := range constants {
+		init[i] = uints.NewU32(constants[i])
+	}
+	copy(init[4:12], key[:])
+	init[12] = counter
+	copy(init[13:], nonce[:])
+
+	x := init
+	for i := 0; i < 10; i++ {
+		// column rounds
+		c.quarterRound(&x, 0, 4, 8, 12)
+		c.quarterRound(&x, 1, 5, 9, 13)
+		c.quarterRound(&x, 2, 6, 10, 14)
+		c.quarterRound(&x, 3, 7, 11, 15)
+		// diagonal rounds
+		c.quarterRound(&x, 0, 5, 10, 15)
+		c.quarterRound(&x, 1, 6, 11, 12)
+		c.quarterRound(&x, 2, 7, 8, 13)
+		c.quarterRound(&x, 3, 4, 9, 14)
+	}
+	for i := range x {
+		x[i] = c.uapi.Add(x[i], init[i])
+	}
+	return x
+}
+
+func (c *ChaCha20) quarterRound(x *[16]uints.U32, a, b, cc, d int) {
+	x[a] = c.uapi.Add(x[a], x[b])
+	x[d] = c.uapi.Lrot(c.uapi.Xor(x[d], x[a]), 16)
+	x[cc] = c.uapi.Add(x[cc], x[d])
+	x[b] = c.uapi.Lrot(c.uapi.Xor(x[b], x[cc]), 12)
+	x[a] = c.uapi.Add(x[a], x[b])
+	x[d] = c.uapi.Lrot(c.uapi.Xor(x[d], x[a]), 8)
+	x[cc] = c.uapi.Add(x[cc], x[d])
+	x[b] = c.uapi.Lrot(c.uapi.Xor(x[b], x[cc]), 7)
+}
+
+// XORKeyStream returns src XORed with the keystream for the key and nonce,
+// starting at the given block counter. As for any stream cipher, it both
+// encrypts and decrypts. The input bytes are range checked.
+func (c *ChaCha20) XORKeyStream(key [KeySize]uints.U8, nonce [NonceSize]uints.U8, counter uint32, src []uints.U8) ([]uints.U8, error) {
+	if uint64(counter)+uint64((len(src)+BlockSize-1)/BlockSize) > 1<<32 {
+		return nil, fmt.Errorf("block counter overflows for %d bytes", len(src))
+	}
+	var k [8]uints.U32
+	for i := range k {
+		k[i] = c.uapi.PackLSB(c.bytesOf(key[4*i : 4*i+4])...)
+	}
+	var n [3]uints.U32
+	for i := range n {
+		n[i] = c.uapi.PackLSB(c.bytesOf(nonce[4*i : 4*i+4])...)
+	}
+
+	dst := make([]uints.U8, 0, len(src))
+	for start := 0; start < len(src); start += BlockSize {
+		block := c.Block(k, uints.NewU32(counter), n)
+		counter++
+		end := start + BlockSize
+		if end > len(src) {
+			end = len(src)
+		}
+		for w := 0; start+4*w < end; w++ {
+			// pad the last word with zeros, the padding is discarded
+			word := [4]uints.U8{uints.NewU8(0), uints.NewU8(0), uints.NewU8(0), uints.NewU8(0)}
+			nb := copy(word[:], c.bytesOf(src[start+4*w:min(start+4*w+4, end)]))
+			res := c.uapi.UnpackLSB(c.uapi.Xor(c.uapi.PackLSB(word[:]...), block[w]))
+			dst = append(dst, res[:nb]...)
+		}
+	}
+	return dst, nil
+}
+
+// KeyCommitment returns the commitment to the key, H(key[0], …, key[31]).
+func KeyCommitment(h hash.FieldHasher, key [KeySize]uints.U8) frontend.Variable {
+	h.Reset()
+	for i := range key {
+		h.Write(key[i].Val)
+	}
+	res := h.Sum()
+	h.Reset()
+	return res
+}
+
+// DecryptCommitted asserts that keyCommitment is the commitment to the key
+// (see [KeyCommitment]) and returns the decryption of the ciphertext with the
+// key and nonce, starting at the given block counter.
+func (c *ChaCha20) DecryptCommitted(h hash.FieldHasher, keyCommitment frontend.Variable, key [KeySize]uints.U8, nonce [NonceSize]uints.U8, counter uint32, ciphertext []uints.U8) ([]uints.U8, error) {
+	c.api.AssertIsEqual(keyCommitment, KeyCommitment(h, key))
+	return c.XORKeyStream(key, nonce, counter, ciphertext)
+}
+
+// bytesOf returns the range checked bytes.
+func (c *ChaCha20) bytesOf(in []uints.U8) []uints.U8 {
+	res := make([]uints.U8, len(in))
+	for i := range in {
+		res[i] = c.uapi.ByteValueOf(in[i].Val)
+	}
+	return res
+}
@@ -0,0 +1,95 @@
+package chacha20
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	nativemimc "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/chacha20"
+)
+
+// the message spans two blocks and ends with a partial word
+const msgLen = 70
+
+type decryptCircuit struct {
+	Key           [KeySize]uints.U8
+	Nonce         [NonceSize]uints.U8
+	Ciphertext    [msgLen]uints.U8
+	KeyCommitment frontend.Variable `gnark:",public"`
+	Plaintext     [msgLen]uints.U8
+}
+
+func (c *decryptCircuit) Define(api frontend.API) error {
+	cipher, err := New(api)
+	if err != nil {
+		return err
+	}
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	plaintext, err := cipher.DecryptCommitted(&h, c.KeyCommitment, c.Key, c.Nonce, 1, c.Ciphertext[:])
+	if err != nil {
+		return err
+	}
+	for i := range plaintext {
+		api.AssertIsEqual(plaintext[i].Val, c.Plaintext[i].Val)
+	}
+	return nil
+}
+
+func TestDecryptCommitted(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var key [KeySize]byte
+	var nonce [NonceSize]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	nonce[7] = 0x4a
+	plaintext := []byte("Ladies and Gentlemen of the class of '99: If I could offer you only one")[:msgLen]
+	stream, err := chacha20.NewUnauthenticatedCipher(key[:], nonce[:])
+	assert.NoError(err)
+	stream.SetCounter(1)
+	ciphertext := make([]byte, msgLen)
+	stream.XORKeyStream(ciphertext, plaintext)
+
+	m := nativemimc.NewMiMC()
+	for i := range key {
+		var e fr.Element
+		e.SetUint64(uint64(key[i]))
+		b := e.Bytes()
+		m.Write(b[:])
+	}
+	commitment := new(big.Int).SetBytes(m.Sum(nil))
+
+	assignment := func(plaintext []byte) *decryptCircuit {
+		var a decryptCircuit
+		copy(a.Key[:], uints.NewU8Array(key[:]))
+		copy(a.Nonce[:], uints.NewU8Array(nonce[:]))
+		copy(a.Ciphertext[:], uints.NewU8Array(ciphertext))
+		copy(a.Plaintext[:], uints.NewU8Array(plaintext))
+		a.KeyCommitment = commitment
+		return &a
+	}
+
+	tampered := append([]byte{}, plaintext...)
+	tampered[msgLen-1] ^= 1
+	wrongKey := assignment(plaintext)
+	wrongKey.Key[0] = uints.NewU8(1)
+	wrongCommitment := assignment(plaintext)
+	wrongCommitment.KeyCommitment = 1
+
+	assert.CheckCircuit(&decryptCircuit{},
+		test.WithValidAssignment(assignment(plaintext)),
+		test.WithInvalidAssignment(assignment(tampered)),
+		test.WithInvalidAssignment(wrongKey),
+		test.WithInvalidAssignment(wrongCommitment),
+		test.WithCurves(ecc.BN254))
+}
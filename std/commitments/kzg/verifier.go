@@ -32,6 +32,7 @@ import (
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/algebra"
 	"github.com/consensys/gnark/std/algebra/algopts"
+	emsw_bls12377 "github.com/consensys/gnark/std/algebra/emulated/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bw6761"
@@ -107,6 +108,12 @@ func ValueOfCommitment[G1El algebra.G1ElementT](cmt any) (Commitment[G1El], erro
 			return ret, fmt.Errorf("mismatching types %T %T", ret, cmt)
 		}
 		s.G1El = sw_bls12377.NewG1Affine(tCmt)
+	case *Commitment[emsw_bls12377.G1Affine]:
+		tCmt, ok := cmt.(bls12377.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("mismatching types %T %T", ret, cmt)
+		}
+		s.G1El = emsw_bls12377.NewG1Affine(tCmt)
 	case *Commitment[sw_bls12381.G1Affine]:
 		tCmt, ok := cmt.(bls12381.G1Affine)
 		if !ok {
@@ -159,6 +166,13 @@ func ValueOfOpeningProof[FR emulated.FieldParams, G1El algebra.G1ElementT](proof
 		}
 		s.Quotient = sw_bls12377.NewG1Affine(tProof.H)
 		s.ClaimedValue = sw_bls12377.NewScalar(tProof.ClaimedValue)
+	case *OpeningProof[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine]:
+		tProof, ok := proof.(kzg_bls12377.OpeningProof)
+		if !ok {
+			return ret, fmt.Errorf("mismatching types %T %T", ret, proof)
+		}
+		s.Quotient = emsw_bls12377.NewG1Affine(tProof.H)
+		s.ClaimedValue = emsw_bls12377.NewScalar(tProof.ClaimedValue)
 	case *OpeningProof[sw_bls12381.ScalarField, sw_bls12381.G1Affine]:
 		tProof, ok := proof.(kzg_bls12381.OpeningProof)
 		if !ok {
@@ -214,6 +228,16 @@ func ValueOfBatchOpeningProof[FR emulated.FieldParams, G1El any](proof any) (Bat
 		for i := 0; i < len(s.ClaimedValues); i++ {
 			s.ClaimedValues[i] = sw_bls12377.NewScalar(tProof.ClaimedValues[i])
 		}
+	case *BatchOpeningProof[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine]:
+		tProof, ok := proof.(kzg_bls12377.BatchOpeningProof)
+		if !ok {
+			return ret, fmt.Errorf("mismatching types %T %T", ret, proof)
+		}
+		s.Quotient = emsw_bls12377.NewG1Affine(tProof.H)
+		s.ClaimedValues = make([]emulated.Element[emsw_bls12377.ScalarField], len(tProof.ClaimedValues))
+		for i := 0; i < len(s.ClaimedValues); i++ {
+			s.ClaimedValues[i] = emsw_bls12377.NewScalar(tProof.ClaimedValues[i])
+		}
 	case *BatchOpeningProof[sw_bls12381.ScalarField, sw_bls12381.G1Affine]:
 		tProof, ok := proof.(kzg_bls12381.BatchOpeningProof)
 		if !ok {
@@ -268,6 +292,9 @@ func PlaceholderVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT]()
 	case *VerifyingKey[sw_bls12377.G1Affine, sw_bls12377.G2Affine]:
 		s.G2[0] = sw_bls12377.NewG2AffineFixedPlaceholder()
 		s.G2[1] = sw_bls12377.NewG2AffineFixedPlaceholder()
+	case *VerifyingKey[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine]:
+		s.G2[0] = emsw_bls12377.NewG2AffineFixedPlaceholder()
+		s.G2[1] = emsw_bls12377.NewG2AffineFixedPlaceholder()
 	case *VerifyingKey[sw_bls12381.G1Affine, sw_bls12381.G2Affine]:
 		s.G2[0] = sw_bls12381.NewG2AffineFixedPlaceholder()
 		s.G2[1] = sw_bls12381.NewG2AffineFixedPlaceholder()
@@ -305,6 +332,14 @@ func ValueOfVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT](vk an
 		s.G1 = sw_bls12377.NewG1Affine(tVk.G1)
 		s.G2[0] = sw_bls12377.NewG2Affine(tVk.G2[0])
 		s.G2[1] = sw_bls12377.NewG2Affine(tVk.G2[1])
+	case *VerifyingKey[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine]:
+		tVk, ok := vk.(kzg_bls12377.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("mismatching types %T %T", ret, vk)
+		}
+		s.G1 = emsw_bls12377.NewG1Affine(tVk.G1)
+		s.G2[0] = emsw_bls12377.NewG2Affine(tVk.G2[0])
+		s.G2[1] = emsw_bls12377.NewG2Affine(tVk.G2[1])
 	case *VerifyingKey[sw_bls12381.G1Affine, sw_bls12381.G2Affine]:
 		tVk, ok := vk.(kzg_bls12381.VerifyingKey)
 		if !ok {
@@ -360,6 +395,14 @@ func ValueOfVerifyingKeyFixed[G1El algebra.G1ElementT, G2El algebra.G2ElementT](
 		s.G1 = sw_bls12377.NewG1Affine(tVk.G1)
 		s.G2[0] = sw_bls12377.NewG2AffineFixed(tVk.G2[0])
 		s.G2[1] = sw_bls12377.NewG2AffineFixed(tVk.G2[1])
+	case *VerifyingKey[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine]:
+		tVk, ok := vk.(kzg_bls12377.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("mismatching types %T %T", ret, vk)
+		}
+		s.G1 = emsw_bls12377.NewG1Affine(tVk.G1)
+		s.G2[0] = emsw_bls12377.NewG2AffineFixed(tVk.G2[0])
+		s.G2[1] = emsw_bls12377.NewG2AffineFixed(tVk.G2[1])
 	case *VerifyingKey[sw_bls12381.G1Affine, sw_bls12381.G2Affine]:
 		tVk, ok := vk.(kzg_bls12381.VerifyingKey)
 		if !ok {
@@ -14,6 +14,7 @@ import (
 	bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761"
 	ped_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/pedersen"
 	"github.com/consensys/gnark/std/algebra"
+	emsw_bls12377 "github.com/consensys/gnark/std/algebra/emulated/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bw6761"
@@ -35,6 +36,13 @@ func ValueOfVerifyingKey[G2El algebra.G2ElementT](vk any) (VerifyingKey[G2El], e
 		}
 		s.G = sw_bls12377.NewG2Affine(tVk.G)
 		s.GRootSigmaNeg = sw_bls12377.NewG2Affine(tVk.GRootSigmaNeg)
+	case *VerifyingKey[emsw_bls12377.G2Affine]:
+		tVk, ok := vk.(*ped_bls12377.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("expected *ped_bls12377.VerifyingKey, got %T", vk)
+		}
+		s.G = emsw_bls12377.NewG2Affine(tVk.G)
+		s.GRootSigmaNeg = emsw_bls12377.NewG2Affine(tVk.GRootSigmaNeg)
 	case *VerifyingKey[sw_bls12381.G2Affine]:
 		tVk, ok := vk.(*ped_bls12381.VerifyingKey)
 		if !ok {
@@ -83,6 +91,13 @@ func ValueOfVerifyingKeyFixed[G2El algebra.G2ElementT](vk any) (VerifyingKey[G2E
 		}
 		s.G = sw_bls12377.NewG2AffineFixed(tVk.G)
 		s.GRootSigmaNeg = sw_bls12377.NewG2AffineFixed(tVk.GRootSigmaNeg)
+	case *VerifyingKey[emsw_bls12377.G2Affine]:
+		tVk, ok := vk.(*ped_bls12377.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("expected *ped_bls12377.VerifyingKey, got %T", vk)
+		}
+		s.G = emsw_bls12377.NewG2AffineFixed(tVk.G)
+		s.GRootSigmaNeg = emsw_bls12377.NewG2AffineFixed(tVk.GRootSigmaNeg)
 	case *VerifyingKey[sw_bls12381.G2Affine]:
 		tVk, ok := vk.(*ped_bls12381.VerifyingKey)
 		if !ok {
@@ -151,6 +166,12 @@ func valueOfG1El[G1El algebra.G1ElementT](el any) (G1El, error) {
 			return ret, fmt.Errorf("expected bls12377.G1Affine, got %T", el)
 		}
 		*s = sw_bls12377.NewG1Affine(tEl)
+	case *emsw_bls12377.G1Affine:
+		tEl, ok := el.(bls12377.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.G1Affine, got %T", el)
+		}
+		*s = emsw_bls12377.NewG1Affine(tEl)
 	case *sw_bls12381.G1Affine:
 		tEl, ok := el.(bls12381.G1Affine)
 		if !ok {
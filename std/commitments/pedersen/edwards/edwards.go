@@ -0,0 +1,114 @@
+// Package edwards implements Pedersen commitments to field elements over the
+// native twisted Edwards curves, and their homomorphic operations.
+//
+// A value v is committed with the randomness r as
+//
+//	C = [v]G + [r]H
+//
+// where G and H are two generators of the prime order subgroup whose relative
+// discrete logarithm is unknown. The commitments are additively homomorphic, so
+// that balance statements over committed amounts can be expressed directly:
+// ∑Cᵢₙ - ∑Cₒᵤₜ commits to zero iff the amounts are balanced (given that they are
+// range checked so that the sums don't wrap around the subgroup order), and
+// the prover then knows the randomness ∑rᵢₙ - ∑rₒᵤₜ such that it equals [r]H.
+package edwards
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+)
+
+// Commitment is a Pedersen commitment to a value.
+type Commitment struct {
+	P twistededwards.Point
+}
+
+// Committer computes and operates on Pedersen commitments in a circuit.
+type Committer struct {
+	curve twistededwards.Curve
+	g, h  twistededwards.Point
+}
+
+// NewCommitter returns a Committer over the given curve, with the generators g
+// and h. They must be points of the prime order subgroup whose relative
+// discrete logarithm is unknown, for example obtained by hashing to the curve;
+// otherwise the commitments are not binding.
+func NewCommitter(curve twistededwards.Curve, g, h twistededwards.Point) *Committer {
+	return &Committer{curve: curve, g: g, h: h}
+}
+
+// Commit returns the commitment [value]G + [randomness]H.
+func (c *Committer) Commit(value, randomness frontend.Variable) Commitment {
+	return Commitment{P: c.curve.DoubleBaseScalarMul(c.g, c.h, value, randomness)}
+}
+
+// AssertOpening asserts that cm is the commitment to value with randomness.
+func (c *Committer) AssertOpening(cm Commitment, value, randomness frontend.Variable) {
+	c.assertIsEqual(cm, c.Commit(value, randomness))
+}
+
+// AssertCommitsToZero asserts that cm is the commitment to zero with
+// randomness, i.e. cm = [randomness]H.
+func (c *Committer) AssertCommitsToZero(cm Commitment, randomness frontend.Variable) {
+	c.assertIsEqual(cm, Commitment{P: c.curve.ScalarMul(c.h, randomness)})
+}
+
+// AssertIsOnCurve asserts that the commitment is a point of the curve. It must
+// be called on commitments which are not computed in the circuit.
+func (c *Committer) AssertIsOnCurve(cm Commitment) {
+	c.curve.AssertIsOnCurve(cm.P)
+}
+
+// Add returns the commitment to the sum of the values committed in a and b,
+// with the sum of their randomnesses.
+func (c *Committer) Add(a, b Commitment) Commitment {
+	return Commitment{P: c.curve.Add(a.P, b.P)}
+}
+
+// Sub returns the commitment to the difference of the values committed in a
+// and b, with the difference of their randomnesses.
+func (c *Committer) Sub(a, b Commitment) Commitment {
+	return Commitment{P: c.curve.Add(a.P, c.curve.Neg(b.P))}
+}
+
+// Sum returns the commitment to the sum of the values committed in cms. It
+// returns the commitment to zero with zero randomness if cms is empty.
+func (c *Committer) Sum(cms ...Commitment) Commitment {
+	if len(cms) == 0 {
+		return Commitment{P: twistededwards.Point{X: 0, Y: 1}}
+	}
+	res := cms[0]
+	for i := 1; i < len(cms); i++ {
+		res = c.Add(res, cms[i])
+	}
+	return res
+}
+
+// ScalarMul returns the commitment to the value committed in a multiplied by
+// k, with its randomness multiplied by k.
+func (c *Committer) ScalarMul(a Commitment, k frontend.Variable) Commitment {
+	return Commitment{P: c.curve.ScalarMul(a.P, k)}
+}
+
+// Rerandomize returns a commitment to the same value as a, with randomness
+// increased by randomness. The result is unlinkable to a for whoever doesn't
+// know randomness.
+func (c *Committer) Rerandomize(a Commitment, randomness frontend.Variable) Commitment {
+	return Commitment{P: c.curve.Add(a.P, c.curve.ScalarMul(c.h, randomness))}
+}
+
+// AssertBalance asserts that the sum of the values committed in inputs equals
+// the sum of the values committed in outputs, where excess is the difference
+// between the sums of the input and output randomnesses.
+//
+// The committed values must be range checked by the caller so that the sums
+// can't overflow the order of the subgroup.
+func (c *Committer) AssertBalance(inputs, outputs []Commitment, excess frontend.Variable) {
+	c.AssertCommitsToZero(c.Sub(c.Sum(inputs...), c.Sum(outputs...)), excess)
+}
+
+func (c *Committer) assertIsEqual(a, b Commitment) {
+	api := c.curve.API()
+	api.AssertIsEqual(a.P.X, b.P.X)
+	api.AssertIsEqual(a.P.Y, b.P.Y)
+}
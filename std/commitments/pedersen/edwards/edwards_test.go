@@ -0,0 +1,109 @@
+package edwards
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	tbn254 "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/test"
+)
+
+// generators returns G and H for the tests. The discrete logarithm of H is
+// known, which is fine for testing but breaks the binding property.
+func generators() (g, h tbn254.PointAffine) {
+	params := tbn254.GetEdwardsCurve()
+	g = params.Base
+	h.ScalarMultiplication(&g, big.NewInt(123456789))
+	return
+}
+
+func nativeCommit(value, randomness *big.Int) Commitment {
+	g, h := generators()
+	var vg, rh tbn254.PointAffine
+	vg.ScalarMultiplication(&g, value)
+	rh.ScalarMultiplication(&h, randomness)
+	vg.Add(&vg, &rh)
+	return Commitment{P: twistededwards.Point{X: vg.X, Y: vg.Y}}
+}
+
+type balanceCircuit struct {
+	Inputs             [2]Commitment
+	InValues, InRandom [2]frontend.Variable
+	Output             Commitment
+	Excess             frontend.Variable
+	Rerandomized       Commitment
+	Randomness         frontend.Variable
+	Scaled             Commitment
+	Factor             frontend.Variable
+}
+
+func (c *balanceCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+	g, h := generators()
+	committer := NewCommitter(curve,
+		twistededwards.Point{X: g.X, Y: g.Y},
+		twistededwards.Point{X: h.X, Y: h.Y})
+
+	for i := range c.Inputs {
+		committer.AssertIsOnCurve(c.Inputs[i])
+		committer.AssertOpening(c.Inputs[i], c.InValues[i], c.InRandom[i])
+	}
+	committer.AssertIsOnCurve(c.Output)
+	committer.AssertBalance(c.Inputs[:], []Commitment{c.Output}, c.Excess)
+
+	rerandomized := committer.Rerandomize(c.Inputs[0], c.Randomness)
+	committer.AssertOpening(rerandomized, c.InValues[0], api.Add(c.InRandom[0], c.Randomness))
+	committer.assertIsEqual(rerandomized, c.Rerandomized)
+
+	scaled := committer.ScalarMul(c.Inputs[1], c.Factor)
+	committer.AssertOpening(scaled, api.Mul(c.InValues[1], c.Factor), api.Mul(c.InRandom[1], c.Factor))
+	committer.assertIsEqual(scaled, c.Scaled)
+	return nil
+}
+
+func TestBalance(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := tbn254.GetEdwardsCurve()
+	order := &params.Order
+	random := func() *big.Int {
+		r, _ := rand.Int(rand.Reader, order)
+		return r
+	}
+	mod := func(v *big.Int) *big.Int { return v.Mod(v, order) }
+
+	inValues := [2]*big.Int{big.NewInt(70), big.NewInt(30)}
+	inRandom := [2]*big.Int{random(), random()}
+	outRandom := random()
+	randomness := random()
+	factor := big.NewInt(5)
+
+	var witness balanceCircuit
+	for i := range witness.Inputs {
+		witness.Inputs[i] = nativeCommit(inValues[i], inRandom[i])
+		witness.InValues[i] = inValues[i]
+		witness.InRandom[i] = inRandom[i]
+	}
+	witness.Output = nativeCommit(big.NewInt(100), outRandom)
+	witness.Excess = mod(new(big.Int).Sub(new(big.Int).Add(inRandom[0], inRandom[1]), outRandom))
+	witness.Rerandomized = nativeCommit(inValues[0], mod(new(big.Int).Add(inRandom[0], randomness)))
+	witness.Randomness = randomness
+	witness.Scaled = nativeCommit(new(big.Int).Mul(inValues[1], factor), mod(new(big.Int).Mul(inRandom[1], factor)))
+	witness.Factor = factor
+
+	// the output amount is not balanced
+	invalid := witness
+	invalid.Output = nativeCommit(big.NewInt(101), outRandom)
+
+	assert.CheckCircuit(&balanceCircuit{},
+		test.WithValidAssignment(&witness),
+		test.WithInvalidAssignment(&invalid),
+		test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,101 @@
+// Package credentials implements selective disclosure of signed attributes.
+//
+// An issuer signs the commitment to a list of attributes (e.g. holder
+// identifier, birth date, nationality) with EdDSA. The holder then proves in
+// a circuit that it knows a credential signed by the issuer, revealing only
+// some attributes and proving predicates on the hidden ones, e.g. that the
+// birth date is before a cutoff date (age over 18).
+//
+// As with BBS+ signatures, presentations are unlinkable: the signature and
+// the commitment are private inputs, so that only the issuer public key, the
+// revealed attributes and the predicate bounds are public. Contrary to BBS+,
+// the signature is verified in the circuit, which is built on a
+// SNARK-friendly hash function and twisted Edwards curve instead of pairings.
+//
+// The attributes are committed as H(attributes...), with the hash function
+// given as a [hash.FieldHasher]. The issuer signs the commitment, encoded as a
+// big-endian field element, with the same hash function.
+package credentials
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/rangecheck"
+	"github.com/consensys/gnark/std/signature/eddsa"
+)
+
+// Credential is a list of attributes signed by an issuer.
+type Credential struct {
+	// Attributes are the signed attributes. The number of attributes is fixed
+	// by the circuit.
+	Attributes []frontend.Variable
+	// Signature is the signature of the issuer on the commitment to the
+	// attributes.
+	Signature eddsa.Signature
+}
+
+// Commitment returns the commitment to the attributes, H(attributes...).
+func Commitment(h hash.FieldHasher, attributes []frontend.Variable) frontend.Variable {
+	h.Reset()
+	h.Write(attributes...)
+	res := h.Sum()
+	h.Reset()
+	return res
+}
+
+// Verify asserts that the credential is signed by the issuer.
+func Verify(curve twistededwards.Curve, h hash.FieldHasher, issuer eddsa.PublicKey, credential Credential) error {
+	if len(credential.Attributes) == 0 {
+		return fmt.Errorf("credential has no attributes")
+	}
+	commitment := Commitment(h, credential.Attributes)
+	if err := eddsa.Verify(curve, credential.Signature, commitment, issuer, h); err != nil {
+		return fmt.Errorf("verify signature: %w", err)
+	}
+	h.Reset()
+	return nil
+}
+
+// AssertDisclosure asserts that disclosed[i] equals attributes[i] when
+// reveal[i] is 1, and is 0 when reveal[i] is 0. The flags must be boolean,
+// which is asserted. The flags and the disclosed attributes are usually public
+// inputs, so that the verifier chooses which attributes are revealed.
+func AssertDisclosure(api frontend.API, attributes, reveal, disclosed []frontend.Variable) {
+	if len(reveal) != len(attributes) || len(disclosed) != len(attributes) {
+		panic(fmt.Sprintf("got %d attributes, %d flags and %d disclosed attributes", len(attributes), len(reveal), len(disclosed)))
+	}
+	for i := range attributes {
+		api.AssertIsBoolean(reveal[i])
+		api.AssertIsEqual(disclosed[i], api.Mul(reveal[i], attributes[i]))
+	}
+}
+
+// AssertAtLeast asserts that value ≥ bound. The value and the bound must fit
+// in nbBits bits, the value is range checked accordingly.
+func AssertAtLeast(api frontend.API, value, bound frontend.Variable, nbBits int) {
+	assertOrdered(api, bound, value, nbBits)
+}
+
+// AssertAtMost asserts that value ≤ bound. The value and the bound must fit in
+// nbBits bits, the value is range checked accordingly. For example, that the
+// holder is over 18 is proven by asserting that the birth date is at most the
+// date 18 years ago.
+func AssertAtMost(api frontend.API, value, bound frontend.Variable, nbBits int) {
+	assertOrdered(api, value, bound, nbBits)
+}
+
+// assertOrdered asserts that a ≤ b, where a and b fit in nbBits bits.
+func assertOrdered(api frontend.API, a, b frontend.Variable, nbBits int) {
+	if nbBits >= api.Compiler().FieldBitLen()-1 {
+		panic(fmt.Sprintf("attributes of %d bits are too large for the field", nbBits))
+	}
+	// the difference wraps around the field if a > b, and then doesn't fit in
+	// nbBits bits.
+	rc := rangecheck.New(api)
+	rc.Check(a, nbBits)
+	rc.Check(b, nbBits)
+	rc.Check(api.Sub(b, a), nbBits)
+}
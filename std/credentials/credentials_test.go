@@ -0,0 +1,107 @@
+package credentials
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	nativemimc "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark-crypto/hash"
+	nativeeddsa "github.com/consensys/gnark-crypto/signature/eddsa"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/signature/eddsa"
+	"github.com/consensys/gnark/test"
+)
+
+const nbAttributes = 3 // holder, birth date, nationality
+
+type presentationCircuit struct {
+	Issuer     eddsa.PublicKey `gnark:",public"`
+	Credential Credential
+	Reveal     [nbAttributes]frontend.Variable `gnark:",public"`
+	Disclosed  [nbAttributes]frontend.Variable `gnark:",public"`
+	Cutoff     frontend.Variable               `gnark:",public"`
+}
+
+func (c *presentationCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	if err := Verify(curve, &h, c.Issuer, c.Credential); err != nil {
+		return err
+	}
+	AssertDisclosure(api, c.Credential.Attributes, c.Reveal[:], c.Disclosed[:])
+	AssertAtMost(api, c.Credential.Attributes[1], c.Cutoff, 32)
+	return nil
+}
+
+func TestPresentation(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	issuer, err := nativeeddsa.New(tedwards.BN254, rand.Reader)
+	assert.NoError(err)
+	attributes := []int64{123456789, 20000101, 250}
+
+	// the issuer signs the commitment to the attributes
+	m := nativemimc.NewMiMC()
+	for _, a := range attributes {
+		var e fr.Element
+		e.SetInt64(a)
+		b := e.Bytes()
+		m.Write(b[:])
+	}
+	commitment := m.Sum(nil)
+	signature, err := issuer.Sign(commitment, hash.MIMC_BN254.New())
+	assert.NoError(err)
+
+	assignment := func(cutoff int64, reveal [nbAttributes]int64) *presentationCircuit {
+		var a presentationCircuit
+		a.Issuer.Assign(tedwards.BN254, issuer.Public().Bytes())
+		a.Credential.Signature.Assign(tedwards.BN254, signature)
+		a.Credential.Attributes = make([]frontend.Variable, nbAttributes)
+		for i := range attributes {
+			a.Credential.Attributes[i] = attributes[i]
+			a.Reveal[i] = reveal[i]
+			a.Disclosed[i] = reveal[i] * attributes[i]
+		}
+		a.Cutoff = cutoff
+		return &a
+	}
+
+	// a disclosed attribute differs from the signed one
+	wrongDisclosure := assignment(20060101, [nbAttributes]int64{0, 0, 1})
+	wrongDisclosure.Disclosed[2] = 251
+	// a hidden attribute is disclosed
+	leaked := assignment(20060101, [nbAttributes]int64{0, 0, 1})
+	leaked.Disclosed[1] = attributes[1]
+	// the credential is signed by another issuer
+	otherIssuer, err := nativeeddsa.New(tedwards.BN254, rand.Reader)
+	assert.NoError(err)
+	forged := assignment(20060101, [nbAttributes]int64{0, 0, 1})
+	forged.Issuer.Assign(tedwards.BN254, otherIssuer.Public().Bytes())
+	// an attribute was modified
+	modified := assignment(20060101, [nbAttributes]int64{0, 0, 1})
+	modified.Credential.Attributes[1] = 19900101
+
+	var circuit presentationCircuit
+	circuit.Credential.Attributes = make([]frontend.Variable, nbAttributes)
+	assert.CheckCircuit(&circuit,
+		test.WithValidAssignment(assignment(20060101, [nbAttributes]int64{0, 0, 1})),
+		test.WithValidAssignment(assignment(20000101, [nbAttributes]int64{1, 1, 1})),
+		test.WithValidAssignment(assignment(20060101, [nbAttributes]int64{0, 0, 0})),
+		test.WithInvalidAssignment(assignment(19991231, [nbAttributes]int64{0, 0, 1})),
+		test.WithInvalidAssignment(wrongDisclosure),
+		test.WithInvalidAssignment(leaked),
+		test.WithInvalidAssignment(forged),
+		test.WithInvalidAssignment(modified),
+		test.WithCurves(ecc.BN254))
+}
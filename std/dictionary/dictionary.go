@@ -0,0 +1,105 @@
+package dictionary
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/accumulator/merkle"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hint functions used in this package. This method is
+// useful for registering all hints in the solver.
+func GetHints() []solver.Hint {
+	return []solver.Hint{keyIndexHint}
+}
+
+// Dictionary is a key-value dictionary backed by log-derivative lookup tables.
+// The entries may be constants or circuit variables, for example private
+// inputs whose commitment is checked elsewhere in the circuit.
+type Dictionary struct {
+	api    frontend.API
+	keys   []frontend.Variable
+	keyTbl *logderivlookup.Table
+	valTbl *logderivlookup.Table
+}
+
+// New returns a new empty dictionary.
+func New(api frontend.API) *Dictionary {
+	return &Dictionary{
+		api:    api,
+		keyTbl: logderivlookup.New(api),
+		valTbl: logderivlookup.New(api),
+	}
+}
+
+// Insert adds the entry (key, value) to the dictionary. The keys should be
+// distinct, otherwise a lookup may return the value of any of the entries with
+// the queried key.
+func (d *Dictionary) Insert(key, value frontend.Variable) {
+	d.keys = append(d.keys, key)
+	d.keyTbl.Insert(key)
+	d.valTbl.Insert(value)
+}
+
+// Lookup returns the value associated to key. If the key is not in the
+// dictionary, then no proof can be generated.
+func (d *Dictionary) Lookup(key frontend.Variable) frontend.Variable {
+	if len(d.keys) == 0 {
+		panic("lookup in empty dictionary")
+	}
+	idx, err := d.api.Compiler().NewHint(keyIndexHint, 1, append([]frontend.Variable{key}, d.keys...)...)
+	if err != nil {
+		panic(fmt.Sprintf("key index hint: %v", err))
+	}
+	// the index is out of range if the key is not found, making the lookup
+	// unsatisfiable.
+	d.api.AssertIsEqual(d.keyTbl.Lookup(idx[0])[0], key)
+	return d.valTbl.Lookup(idx[0])[0]
+}
+
+// keyIndexHint returns the index of inputs[0] in inputs[1:], or len(inputs)-1
+// if it is not found.
+func keyIndexHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) < 1 || len(outputs) != 1 {
+		return fmt.Errorf("expected at least 1 input and 1 output")
+	}
+	key := inputs[0]
+	outputs[0].SetInt64(int64(len(inputs) - 1))
+	for i, k := range inputs[1:] {
+		if k.Cmp(key) == 0 {
+			outputs[0].SetInt64(int64(i))
+			break
+		}
+	}
+	return nil
+}
+
+// EntryHash returns the hash of the entry (key, value) computed with the hasher
+// h, that is h(key, value). It is the leaf data of the Merkle tree of entries
+// expected by [AssertMerkleEntry].
+func EntryHash(h hash.FieldHasher, key, value frontend.Variable) frontend.Variable {
+	h.Reset()
+	h.Write(key, value)
+	return h.Sum()
+}
+
+// AssertMerkleEntry asserts that (key, value) is the entry at position
+// leafIndex in the Merkle tree of entries with root proof.RootHash. The first
+// element of proof.Path must be the leaf data [EntryHash](h, key, value), the
+// remaining ones being the Merkle path as expected by
+// [merkle.MerkleProof.VerifyProof].
+//
+// Only membership can be proven. Whether the keys of the tree are distinct is
+// up to the party committing to the dictionary.
+func AssertMerkleEntry(api frontend.API, h hash.FieldHasher, proof merkle.MerkleProof, leafIndex, key, value frontend.Variable) {
+	api.AssertIsEqual(proof.Path[0], EntryHash(h, key, value))
+	proof.VerifyProof(api, h, leafIndex)
+}
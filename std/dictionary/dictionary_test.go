@@ -0,0 +1,185 @@
+package dictionary_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/internal/tinyfield"
+	"github.com/consensys/gnark/std/accumulator/merkle"
+	"github.com/consensys/gnark/std/dictionary"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type lookupCircuit struct {
+	Keys, Values [4]frontend.Variable
+	Query        frontend.Variable
+	Expected     frontend.Variable `gnark:",public"`
+}
+
+func (c *lookupCircuit) Define(api frontend.API) error {
+	d := dictionary.New(api)
+	for i := range c.Keys {
+		d.Insert(c.Keys[i], c.Values[i])
+	}
+	api.AssertIsEqual(d.Lookup(c.Query), c.Expected)
+	return nil
+}
+
+func TestDictionary(t *testing.T) {
+	assert := test.NewAssert(t)
+	keys := [4]frontend.Variable{10, 20, 30, 40}
+	values := [4]frontend.Variable{1, 2, 3, 4}
+	assert.CheckCircuit(&lookupCircuit{},
+		test.WithValidAssignment(&lookupCircuit{Keys: keys, Values: values, Query: 30, Expected: 3}),
+		test.WithValidAssignment(&lookupCircuit{Keys: keys, Values: values, Query: 10, Expected: 1}),
+		test.WithInvalidAssignment(&lookupCircuit{Keys: keys, Values: values, Query: 30, Expected: 4}),
+		// missing key
+		test.WithInvalidAssignment(&lookupCircuit{Keys: keys, Values: values, Query: 50, Expected: 0}),
+	)
+}
+
+type bytesCircuit struct {
+	A, B     [40]uints.U8
+	Expected frontend.Variable
+}
+
+func (c *bytesCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(dictionary.BytesIsEqual(api, c.A[:], c.B[:]), c.Expected)
+	return nil
+}
+
+func TestBytesIsEqual(t *testing.T) {
+	assert := test.NewAssert(t)
+	var a, b [40]uint8
+	copy(a[:], "attribute: date of birth, 1970-01-01...")
+	b = a
+	bDiff := a
+	bDiff[39] ^= 1
+	toU8 := func(v [40]uint8) (res [40]uints.U8) {
+		copy(res[:], uints.NewU8Array(v[:]))
+		return
+	}
+	// (1, 0) and (0, 256) pack to the same value, only the range checks
+	// reject the out of range byte.
+	aCarry, bCarry := toU8(a), toU8(a)
+	aCarry[0], aCarry[1] = uints.NewU8(1), uints.NewU8(0)
+	bCarry[0], bCarry[1] = uints.U8{Val: 0}, uints.U8{Val: 256}
+	assert.CheckCircuit(&bytesCircuit{},
+		test.WithValidAssignment(&bytesCircuit{A: toU8(a), B: toU8(b), Expected: 1}),
+		test.WithValidAssignment(&bytesCircuit{A: toU8(a), B: toU8(bDiff), Expected: 0}),
+		test.WithInvalidAssignment(&bytesCircuit{A: toU8(a), B: toU8(bDiff), Expected: 1}),
+		test.WithInvalidAssignment(&bytesCircuit{A: aCarry, B: bCarry, Expected: 1}),
+	)
+}
+
+func TestBytesIsEqualSmallField(t *testing.T) {
+	// a byte does not fit in the tiny field, the comparison is refused
+	// instead of looping forever.
+	_, err := frontend.Compile(tinyfield.Modulus(), r1cs.NewBuilder, &bytesCircuit{})
+	if err == nil {
+		t.Fatal("expected an error for a field smaller than a byte")
+	}
+	if !strings.Contains(err.Error(), "field too small") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+type stringKeyCircuit struct {
+	A, B         [8]uints.U8
+	LenA, LenB   frontend.Variable
+	ExpectedSame frontend.Variable
+}
+
+func (c *stringKeyCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	ka := dictionary.StringKey(api, &h, c.A[:], c.LenA)
+	kb := dictionary.StringKey(api, &h, c.B[:], c.LenB)
+	api.AssertIsEqual(api.IsZero(api.Sub(ka, kb)), c.ExpectedSame)
+	return nil
+}
+
+func TestStringKey(t *testing.T) {
+	assert := test.NewAssert(t)
+	toU8 := func(s string) (res [8]uints.U8) {
+		var b [8]uint8
+		copy(b[:], s)
+		copy(res[:], uints.NewU8Array(b[:]))
+		return
+	}
+	assert.CheckCircuit(&stringKeyCircuit{},
+		// the padding is ignored
+		test.WithValidAssignment(&stringKeyCircuit{A: toU8("name"), B: toU8("name+pad"), LenA: 4, LenB: 4, ExpectedSame: 1}),
+		// trailing zeros are significant
+		test.WithValidAssignment(&stringKeyCircuit{A: toU8("name"), B: toU8("name"), LenA: 4, LenB: 5, ExpectedSame: 0}),
+		test.WithValidAssignment(&stringKeyCircuit{A: toU8("name"), B: toU8("nams"), LenA: 4, LenB: 4, ExpectedSame: 0}),
+	)
+}
+
+type merkleEntryCircuit struct {
+	Proof      merkle.MerkleProof
+	LeafIndex  frontend.Variable
+	Key, Value frontend.Variable
+}
+
+func (c *merkleEntryCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	dictionary.AssertMerkleEntry(api, &h, c.Proof, c.LeafIndex, c.Key, c.Value)
+	return nil
+}
+
+func TestMerkleEntry(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 2
+	keys := []uint64{10, 20, 30, 40}
+	values := []uint64{1, 2, 3, 4}
+
+	// the leaf data is the hash of the entry
+	var buf bytes.Buffer
+	for i := range keys {
+		var k, v fr.Element
+		k.SetUint64(keys[i])
+		v.SetUint64(values[i])
+		h := hash.MIMC_BN254.New()
+		kb, vb := k.Bytes(), v.Bytes()
+		h.Write(kb[:])
+		h.Write(vb[:])
+		buf.Write(h.Sum(nil))
+	}
+	const proofIndex = 2
+	root, proofPath, _, err := merkletree.BuildReaderProof(&buf, hash.MIMC_BN254.New(), fr.Bytes, proofIndex)
+	assert.NoError(err)
+
+	newAssignment := func(key, value uint64) *merkleEntryCircuit {
+		res := &merkleEntryCircuit{
+			Proof:     merkle.MerkleProof{RootHash: root, Path: make([]frontend.Variable, depth+1)},
+			LeafIndex: proofIndex,
+			Key:       key,
+			Value:     value,
+		}
+		for i := range res.Proof.Path {
+			res.Proof.Path[i] = proofPath[i]
+		}
+		return res
+	}
+	circuit := &merkleEntryCircuit{Proof: merkle.MerkleProof{Path: make([]frontend.Variable, depth+1)}}
+	assert.CheckCircuit(circuit,
+		test.WithValidAssignment(newAssignment(keys[proofIndex], values[proofIndex])),
+		test.WithInvalidAssignment(newAssignment(keys[proofIndex], values[proofIndex]+1)),
+		test.WithCurves(ecc.BN254),
+	)
+}
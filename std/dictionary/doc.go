@@ -0,0 +1,16 @@
+// Package dictionary provides gadgets for selective disclosure over committed
+// data: equality of private byte strings and lookups in key-value
+// dictionaries.
+//
+// Two dictionary representations are provided:
+//   - [Dictionary] stores all the entries in the circuit and is backed by a
+//     log-derivative lookup table. Every lookup costs a constant number of
+//     constraints, but the circuit size grows with the number of entries.
+//   - a Merkle tree of entries, where only the root is known to the circuit.
+//     [AssertMerkleEntry] checks that a key-value pair is a leaf of the tree,
+//     the cost being logarithmic in the number of entries.
+//
+// Keys and values are native field elements. Byte strings can be used as keys
+// through [StringKey], which hashes a string of variable length into a single
+// field element.
+package dictionary
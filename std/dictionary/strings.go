@@ -0,0 +1,79 @@
+package dictionary
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/rangecheck"
+	"github.com/consensys/gnark/std/varlen"
+)
+
+// BytesIsEqual returns 1 if the byte strings a and b are equal and 0
+// otherwise. The strings must have the same length. Every byte is range
+// checked, as the [uints.U8] allocated as circuit inputs are not constrained to
+// be bytes.
+func BytesIsEqual(api frontend.API, a, b []uints.U8) frontend.Variable {
+	diffs := packedDiff(api, a, b)
+	res := frontend.Variable(1)
+	for i := range diffs {
+		res = api.And(res, api.IsZero(diffs[i]))
+	}
+	return res
+}
+
+// AssertBytesEqual asserts that the byte strings a and b are equal. The strings
+// must have the same length. As in [BytesIsEqual], every byte is range
+// checked.
+func AssertBytesEqual(api frontend.API, a, b []uints.U8) {
+	diffs := packedDiff(api, a, b)
+	for i := range diffs {
+		api.AssertIsEqual(diffs[i], 0)
+	}
+}
+
+// packedDiff range checks the bytes, packs as many of them as fit in a field
+// element and returns the differences of the packed values. Without the range
+// checks the packing would not be injective, for example (1, 0) and (0, 256)
+// pack to the same value. With them, the strings are equal iff all the
+// differences are zero. It panics if the field cannot hold a byte.
+func packedDiff(api frontend.API, a, b []uints.U8) []frontend.Variable {
+	if len(a) != len(b) {
+		panic("byte strings of different lengths")
+	}
+	rchecker := rangecheck.New(api)
+	for i := range a {
+		rchecker.Check(a[i].Val, 8)
+		rchecker.Check(b[i].Val, 8)
+	}
+	chunkSize := (api.Compiler().FieldBitLen() - 1) / 8
+	if chunkSize < 1 {
+		// the difference of two bytes could wrap around the modulus
+		panic("field too small to pack bytes")
+	}
+	var res []frontend.Variable
+	for start := 0; start < len(a); start += chunkSize {
+		end := min(start+chunkSize, len(a))
+		pa, pb := frontend.Variable(0), frontend.Variable(0)
+		for i := start; i < end; i++ {
+			pa = api.Add(api.Mul(pa, 256), a[i].Val)
+			pb = api.Add(api.Mul(pb, 256), b[i].Val)
+		}
+		res = append(res, api.Sub(pa, pb))
+	}
+	return res
+}
+
+// StringKey returns the key of the byte string made of the first length bytes
+// of s, computed with the hasher h. The remaining bytes of s are ignored, so
+// the same string padded differently has the same key. The length must be at
+// most len(s), otherwise no proof can be generated.
+//
+// The key is the digest of the length followed by the bytes, see
+// [varlen.Array.Hash].
+func StringKey(api frontend.API, h hash.FieldHasher, s []uints.U8, length frontend.Variable) frontend.Variable {
+	arr := varlen.Array{Values: make([]frontend.Variable, len(s)), Length: length}
+	for i := range s {
+		arr.Values[i] = s[i].Val
+	}
+	return arr.Hash(api, h)
+}
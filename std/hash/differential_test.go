@@ -0,0 +1,99 @@
+package hash_test
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	gohash "hash"
+	"math/big"
+	mrand "math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/differential"
+	"github.com/consensys/gnark/std/hash"
+	zkmimc "github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/hash/sha3"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	gosha3 "golang.org/x/crypto/sha3"
+)
+
+// binaryCase checks the binary hasher gadget against native on a message of
+// random length, spanning several blocks.
+func binaryCase(gadget func(frontend.API) (hash.BinaryHasher, error), native func() gohash.Hash) differential.Case[[]uints.U8, []uints.U8] {
+	return differential.Case[[]uints.U8, []uints.U8]{
+		Sample: func() ([]uints.U8, []uints.U8) {
+			msg := make([]byte, mrand.Intn(300)) //#nosec G404 -- test input only
+			if _, err := rand.Read(msg); err != nil {
+				panic(err)
+			}
+			h := native()
+			h.Write(msg)
+			return uints.NewU8Array(msg), uints.NewU8Array(h.Sum(nil))
+		},
+		Assert: func(api frontend.API, in, out *[]uints.U8) error {
+			h, err := gadget(api)
+			if err != nil {
+				return err
+			}
+			uapi, err := uints.New[uints.U32](api)
+			if err != nil {
+				return err
+			}
+			h.Write(*in)
+			res := h.Sum()
+			if len(res) != len(*out) {
+				panic("digest of unexpected length")
+			}
+			for i := range res {
+				uapi.ByteAssertEq(res[i], (*out)[i])
+			}
+			return nil
+		},
+	}
+}
+
+func TestBinaryHashDifferential(t *testing.T) {
+	assert := test.NewAssert(t)
+	differential.Run(assert, ecc.BN254.ScalarField(), 3, map[string]differential.Case[[]uints.U8, []uints.U8]{
+		"SHA2-256":   binaryCase(sha2.New, sha256.New),
+		"SHA3-256":   binaryCase(sha3.New256, gosha3.New256),
+		"SHA3-384":   binaryCase(sha3.New384, gosha3.New384),
+		"SHA3-512":   binaryCase(sha3.New512, gosha3.New512),
+		"Keccak-256": binaryCase(sha3.NewLegacyKeccak256, gosha3.NewLegacyKeccak256),
+		"Keccak-512": binaryCase(sha3.NewLegacyKeccak512, gosha3.NewLegacyKeccak512),
+	})
+}
+
+func TestFieldHashDifferential(t *testing.T) {
+	assert := test.NewAssert(t)
+	differential.Run(assert, ecc.BN254.ScalarField(), 5, map[string]differential.Case[[]frontend.Variable, frontend.Variable]{
+		"MiMC": {
+			Sample: func() ([]frontend.Variable, frontend.Variable) {
+				in := make([]frontend.Variable, 1+mrand.Intn(10)) //#nosec G404 -- test input only
+				h := mimc.NewMiMC()
+				for i := range in {
+					var x fr.Element
+					x.SetRandom()
+					b := x.Bytes()
+					h.Write(b[:])
+					in[i] = x.String()
+				}
+				return in, new(big.Int).SetBytes(h.Sum(nil))
+			},
+			Assert: func(api frontend.API, in *[]frontend.Variable, out *frontend.Variable) error {
+				h, err := zkmimc.NewMiMC(api)
+				if err != nil {
+					return err
+				}
+				h.Write(*in...)
+				api.AssertIsEqual(h.Sum(), *out)
+				return nil
+			},
+		},
+	})
+}
@@ -19,9 +19,11 @@ package mimc
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/hash"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/internal/utils"
 )
@@ -72,3 +74,51 @@ func (h *MiMC) Sum() frontend.Variable {
 	return h.h
 
 }
+
+// PublicInputsHasher hashes the public inputs of a circuit using MiMC. It
+// implements [frontend.PublicInputsHasher] and is to be used with the
+// [frontend.WithPublicInputsHash] compile option and the
+// [frontend.PublicInputsHashed] witness option.
+type PublicInputsHasher struct{}
+
+// HashVariables computes the MiMC digest of the inputs in-circuit.
+func (PublicInputsHasher) HashVariables(api frontend.API, inputs []frontend.Variable) (frontend.Variable, error) {
+	h, err := NewMiMC(api)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(inputs...)
+	return h.Sum(), nil
+}
+
+// HashValues computes the MiMC digest of the inputs natively using the MiMC
+// implementation in gnark-crypto corresponding to the field.
+func (PublicInputsHasher) HashValues(field *big.Int, inputs []*big.Int) (*big.Int, error) {
+	var hf hash.Hash
+	switch utils.FieldToCurve(field) {
+	case ecc.BN254:
+		hf = hash.MIMC_BN254
+	case ecc.BLS12_381:
+		hf = hash.MIMC_BLS12_381
+	case ecc.BLS12_377:
+		hf = hash.MIMC_BLS12_377
+	case ecc.BW6_761:
+		hf = hash.MIMC_BW6_761
+	case ecc.BW6_633:
+		hf = hash.MIMC_BW6_633
+	case ecc.BLS24_315:
+		hf = hash.MIMC_BLS24_315
+	case ecc.BLS24_317:
+		hf = hash.MIMC_BLS24_317
+	default:
+		return nil, fmt.Errorf("no MiMC for scalar field %s", field.String())
+	}
+	h := hf.New()
+	buf := make([]byte, h.BlockSize())
+	for i := range inputs {
+		if _, err := h.Write(inputs[i].FillBytes(buf)); err != nil {
+			return nil, err
+		}
+	}
+	return new(big.Int).SetBytes(h.Sum(nil)), nil
+}
@@ -22,7 +22,9 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/backend/groth16"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/consensys/gnark/test"
 )
 
@@ -93,3 +95,52 @@ func TestMimcAll(t *testing.T) {
 	}
 
 }
+
+type publicInputsCircuit struct {
+	A, B [3]frontend.Variable `gnark:",public"`
+	C    frontend.Variable
+}
+
+func (circuit *publicInputsCircuit) Define(api frontend.API) error {
+	for i := range circuit.A {
+		api.AssertIsEqual(api.Mul(circuit.A[i], circuit.C), circuit.B[i])
+	}
+	return nil
+}
+
+func TestPublicInputsHash(t *testing.T) {
+	assert := test.NewAssert(t)
+	field := ecc.BN254.ScalarField()
+
+	ccs, err := frontend.Compile(field, r1cs.NewBuilder, &publicInputsCircuit{}, frontend.WithPublicInputsHash(PublicInputsHasher{}))
+	assert.NoError(err)
+	// digest and the constant wire
+	assert.Equal(2, ccs.GetNbPublicVariables())
+
+	assignment := publicInputsCircuit{
+		A: [3]frontend.Variable{1, 2, 3},
+		B: [3]frontend.Variable{5, 10, 15},
+		C: 5,
+	}
+	witness, err := frontend.NewWitness(&assignment, field, frontend.PublicInputsHashed(PublicInputsHasher{}))
+	assert.NoError(err)
+	publicWitness, err := witness.Public()
+	assert.NoError(err)
+
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, witness)
+	assert.NoError(err)
+	assert.NoError(groth16.Verify(proof, vk, publicWitness))
+
+	// public witness with different public inputs doesn't verify
+	assignment.B[0] = 6
+	wrongWitness, err := frontend.NewWitness(&assignment, field, frontend.PublicInputsHashed(PublicInputsHasher{}), frontend.PublicOnly())
+	assert.NoError(err)
+	assert.Error(groth16.Verify(proof, vk, wrongWitness))
+
+	// and the prover can't prove for them
+	wrongWitness, err = frontend.NewWitness(&assignment, field, frontend.PublicInputsHashed(PublicInputsHasher{}))
+	assert.NoError(err)
+	assert.Error(ccs.IsSolved(wrongWitness))
+}
@@ -4,14 +4,18 @@ import (
 	"sync"
 
 	"github.com/consensys/gnark/constraint/solver"
+	emfields_bls12377 "github.com/consensys/gnark/std/algebra/emulated/fields_bls12377"
 	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/fields_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/fields_bw6761"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
 	"github.com/consensys/gnark/std/algebra/native/fields_bls12377"
 	"github.com/consensys/gnark/std/algebra/native/fields_bls24315"
 	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/native/sw_bls24315"
+	"github.com/consensys/gnark/std/dictionary"
 	"github.com/consensys/gnark/std/evmprecompiles"
 	"github.com/consensys/gnark/std/internal/logderivarg"
 	"github.com/consensys/gnark/std/math/bits"
@@ -42,7 +46,9 @@ func registerHints() {
 	solver.RegisterHint(evmprecompiles.GetHints()...)
 	solver.RegisterHint(logderivarg.GetHints()...)
 	solver.RegisterHint(bitslice.GetHints()...)
+	solver.RegisterHint(dictionary.GetHints()...)
 	// emulated fields
+	solver.RegisterHint(emfields_bls12377.GetHints()...)
 	solver.RegisterHint(fields_bls12381.GetHints()...)
 	solver.RegisterHint(fields_bn254.GetHints()...)
 	solver.RegisterHint(fields_bw6761.GetHints()...)
@@ -51,6 +57,8 @@ func registerHints() {
 	solver.RegisterHint(fields_bls24315.GetHints()...)
 	// emulated curves
 	solver.RegisterHint(sw_emulated.GetHints()...)
+	solver.RegisterHint(sw_bn254.GetHints()...)
+	solver.RegisterHint(sw_bls12381.GetHints()...)
 	// native curves
 	solver.RegisterHint(sw_bls12377.GetHints()...)
 	solver.RegisterHint(sw_bls24315.GetHints()...)
@@ -121,31 +121,66 @@ func Build(api frontend.API, table Table, queries Table) error {
 			tmp := api.DivUnchecked(exps[i], api.Sub(challenge, randLinearCombination(api, rowCoeffs, table[i])))
 			lp = api.Add(lp, tmp)
 		}
-		var rp frontend.Variable = 0
-
-		toInvert := make([]frontend.Variable, len(queries))
-		for i := range queries {
-			toInvert[i] = api.Sub(challenge, randLinearCombination(api, rowCoeffs, queries[i]))
-		}
+		rp := sumInverses(api, challenge, rowCoeffs, queries)
+		api.AssertIsEqual(lp, rp)
+		return nil
+	}, toCommit...)
+	return nil
+}
 
-		if bapi, ok := api.(frontend.BatchInverter); ok {
-			toInvert = bapi.BatchInvert(toInvert)
-		} else {
-			for i := range toInvert {
-				toInvert[i] = api.Inverse(toInvert[i])
-			}
+// BuildMultisetEquality asserts that the rows of b are a permutation of the
+// rows of a, by checking
+//
+//	∑_{a∈A} 1/(x-∑_{i∈[n]}r_i*a_i) == ∑_{b∈B} 1/(x-∑_{i∈[n]}r_i*b_i).
+//
+// Unlike in [Build], the rows of a do not have to be unique.
+func BuildMultisetEquality(api frontend.API, a, b Table) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("got %d and %d rows", len(a), len(b))
+	}
+	if len(a) == 0 {
+		return nil
+	}
+	nbRow := len(a[0])
+	var toCommit []frontend.Variable
+	for i := range a {
+		if len(a[i]) != nbRow || len(b[i]) != nbRow {
+			return fmt.Errorf("row length mismatch")
 		}
+		toCommit = append(toCommit, a[i]...)
+		toCommit = append(toCommit, b[i]...)
+	}
 
-		for i := range queries {
-			// tmp := api.Inverse(api.Sub(challenge, randLinearCombination(api, rowCoeffs, queries[i])))
-			rp = api.Add(rp, toInvert[i])
-		}
-		api.AssertIsEqual(lp, rp)
+	multicommit.WithCommitment(api, func(api frontend.API, commitment frontend.Variable) error {
+		rowCoeffs, challenge := randLinearCoefficients(api, nbRow, commitment)
+		api.AssertIsEqual(sumInverses(api, challenge, rowCoeffs, a), sumInverses(api, challenge, rowCoeffs, b))
 		return nil
 	}, toCommit...)
 	return nil
 }
 
+// sumInverses returns ∑_{r∈rows} 1/(challenge-∑_{i∈[n]}rowCoeffs_i*r_i).
+func sumInverses(api frontend.API, challenge frontend.Variable, rowCoeffs []frontend.Variable, rows Table) frontend.Variable {
+	toInvert := make([]frontend.Variable, len(rows))
+	for i := range rows {
+		toInvert[i] = api.Sub(challenge, randLinearCombination(api, rowCoeffs, rows[i]))
+	}
+
+	if bapi, ok := api.(frontend.BatchInverter); ok {
+		toInvert = bapi.BatchInvert(toInvert)
+	} else {
+		for i := range toInvert {
+			toInvert[i] = api.Inverse(toInvert[i])
+		}
+	}
+
+	var res frontend.Variable = 0
+	for i := range toInvert {
+		res = api.Add(res, toInvert[i])
+	}
+	return res
+}
+
 func randLinearCoefficients(api frontend.API, nbRow int, commitment frontend.Variable) (rowCoeffs []frontend.Variable, challenge frontend.Variable) {
 	if nbRow == 1 {
 		return []frontend.Variable{1}, commitment
@@ -0,0 +1,74 @@
+// Package table implements lookups in constant tables, such as S-boxes,
+// character mappings or alphabets.
+//
+// The table is defined once and then queried many times. The package chooses
+// the lookup argument depending on the frontend capabilities:
+//   - if the builder implements [frontend.Committer], the queries are checked
+//     with the log-derivative argument of [logderivlookup], at a cost linear in
+//     the size of the table plus the number of queries;
+//   - otherwise every query is a multiplexer over the entries, using
+//     [selector.Mux], at a cost linear in the size of the table per query.
+package table
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/selector"
+)
+
+// Table is a constant lookup table.
+type Table struct {
+	api     frontend.API
+	entries []frontend.Variable
+
+	// lookup is nil when the lookups are multiplexers.
+	lookup *logderivlookup.Table
+}
+
+// New returns a table of the entries, indexed from zero.
+func New(api frontend.API, entries ...frontend.Variable) *Table {
+	_, useLookup := api.(frontend.Committer)
+	return newTable(api, entries, useLookup)
+}
+
+// NewFromBytes returns a table of the bytes, indexed from zero. For instance,
+// the AES S-box is NewFromBytes(api, sbox[:]).
+func NewFromBytes(api frontend.API, entries []byte) *Table {
+	vals := make([]frontend.Variable, len(entries))
+	for i := range entries {
+		vals[i] = entries[i]
+	}
+	return New(api, vals...)
+}
+
+func newTable(api frontend.API, entries []frontend.Variable, useLookup bool) *Table {
+	if len(entries) == 0 {
+		panic("empty table")
+	}
+	t := &Table{api: api, entries: entries}
+	if useLookup {
+		t.lookup = logderivlookup.New(api)
+		for i := range entries {
+			t.lookup.Insert(entries[i])
+		}
+	}
+	return t
+}
+
+// Len returns the number of entries of the table.
+func (t *Table) Len() int {
+	return len(t.entries)
+}
+
+// Lookup returns the entries at the indices. The indices must be smaller than
+// the length of the table, otherwise no proof can be generated.
+func (t *Table) Lookup(inds ...frontend.Variable) []frontend.Variable {
+	if t.lookup != nil {
+		return t.lookup.Lookup(inds...)
+	}
+	vals := make([]frontend.Variable, len(inds))
+	for i := range inds {
+		vals[i] = selector.Mux(t.api, inds[i], t.entries...)
+	}
+	return vals
+}
@@ -0,0 +1,59 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+const base64Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+type base64Circuit struct {
+	Indices [4]frontend.Variable
+	Chars   [4]frontend.Variable
+
+	useMux bool
+}
+
+func (c *base64Circuit) Define(api frontend.API) error {
+	var t *Table
+	if c.useMux {
+		entries := make([]frontend.Variable, len(base64Alphabet))
+		for i := range entries {
+			entries[i] = base64Alphabet[i]
+		}
+		t = newTable(api, entries, false)
+	} else {
+		t = NewFromBytes(api, []byte(base64Alphabet))
+	}
+	chars := t.Lookup(c.Indices[:]...)
+	for i := range chars {
+		api.AssertIsEqual(chars[i], c.Chars[i])
+	}
+	return nil
+}
+
+func TestTable(t *testing.T) {
+	assert := test.NewAssert(t)
+	valid := &base64Circuit{
+		Indices: [4]frontend.Variable{0, 26, 62, 63},
+		Chars:   [4]frontend.Variable{'A', 'a', '+', '/'},
+	}
+	wrong := &base64Circuit{
+		Indices: [4]frontend.Variable{0, 26, 62, 63},
+		Chars:   [4]frontend.Variable{'A', 'b', '+', '/'},
+	}
+	outOfRange := &base64Circuit{
+		Indices: [4]frontend.Variable{0, 26, 62, 64},
+		Chars:   [4]frontend.Variable{'A', 'a', '+', 0},
+	}
+	for _, useMux := range []bool{false, true} {
+		assert.CheckCircuit(&base64Circuit{useMux: useMux},
+			test.WithValidAssignment(valid),
+			test.WithInvalidAssignment(wrong),
+			test.WithInvalidAssignment(outOfRange),
+			test.WithCurves(ecc.BN254))
+	}
+}
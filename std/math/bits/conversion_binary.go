@@ -16,6 +16,31 @@ func FromBinary(api frontend.API, digits []frontend.Variable, opts ...BaseConver
 	return FromBase(api, Binary, digits, opts...)
 }
 
+// ToBinaryLE decomposes v into bits in little-endian order: the first returned
+// bit is the least significant one. It is equivalent to [ToBinary].
+func ToBinaryLE(api frontend.API, v frontend.Variable, opts ...BaseConversionOption) []frontend.Variable {
+	return ToBinary(api, v, opts...)
+}
+
+// ToBinaryBE decomposes v into bits in big-endian order: the first returned bit
+// is the most significant one. The number of bits is set with [WithNbDigits],
+// and defaults to the bit-length of the native field.
+func ToBinaryBE(api frontend.API, v frontend.Variable, opts ...BaseConversionOption) []frontend.Variable {
+	return reverse(ToBinary(api, v, opts...))
+}
+
+// FromBinaryLE recomposes a value from its bits given in little-endian order.
+// It is equivalent to [FromBinary].
+func FromBinaryLE(api frontend.API, digits []frontend.Variable, opts ...BaseConversionOption) frontend.Variable {
+	return FromBinary(api, digits, opts...)
+}
+
+// FromBinaryBE recomposes a value from its bits given in big-endian order: the
+// first bit is the most significant one.
+func FromBinaryBE(api frontend.API, digits []frontend.Variable, opts ...BaseConversionOption) frontend.Variable {
+	return FromBinary(api, reverse(digits), opts...)
+}
+
 func fromBinary(api frontend.API, digits []frontend.Variable, opts ...BaseConversionOption) frontend.Variable {
 
 	cfg := baseConversionConfig{}
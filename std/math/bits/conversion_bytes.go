@@ -0,0 +1,89 @@
+package bits
+
+import (
+	"github.com/consensys/gnark/frontend"
+)
+
+// ToBytesLE decomposes v into bytes in little-endian order: the first returned
+// byte is the least significant one. Every byte is returned as a variable in
+// the range [0, 255].
+//
+// The number of bytes is set with [WithNbDigits] (counting bytes, not bits),
+// and defaults to the smallest number of bytes holding a native field element.
+// As for [ToBinary], the conversion is unsatisfiable if v does not fit in the
+// requested number of bytes.
+func ToBytesLE(api frontend.API, v frontend.Variable, opts ...BaseConversionOption) []frontend.Variable {
+	cfg := baseConversionConfig{
+		NbDigits: (api.Compiler().FieldBitLen() + 7) / 8,
+	}
+	for _, o := range opts {
+		if err := o(&cfg); err != nil {
+			panic(err)
+		}
+	}
+	bitOpts := []BaseConversionOption{WithNbDigits(8 * cfg.NbDigits)}
+	if cfg.UnconstrainedOutputs {
+		bitOpts = append(bitOpts, WithUnconstrainedOutputs())
+	}
+	if cfg.omitModulusCheck {
+		bitOpts = append(bitOpts, OmitModulusCheck())
+	}
+	bits := ToBinary(api, v, bitOpts...)
+	res := make([]frontend.Variable, cfg.NbDigits)
+	for i := range res {
+		// the bits are already constrained, recomposing is only a linear
+		// combination.
+		res[i] = FromBinary(api, bits[8*i:8*i+8], WithUnconstrainedInputs())
+	}
+	return res
+}
+
+// ToBytesBE decomposes v into bytes in big-endian order: the first returned
+// byte is the most significant one. This is the byte order expected by the
+// hash gadgets of the standard library (for example SHA2 input and output). See
+// [ToBytesLE] for the options.
+func ToBytesBE(api frontend.API, v frontend.Variable, opts ...BaseConversionOption) []frontend.Variable {
+	return reverse(ToBytesLE(api, v, opts...))
+}
+
+// FromBytesLE recomposes a value from its bytes given in little-endian order,
+// returning Σ 256**i * bytes[i]. Unless [WithUnconstrainedInputs] is set, every
+// byte is constrained to be in the range [0, 255].
+func FromBytesLE(api frontend.API, bytes []frontend.Variable, opts ...BaseConversionOption) frontend.Variable {
+	if len(bytes) == 0 {
+		panic("FromBytes needs at least 1 byte")
+	}
+	cfg := baseConversionConfig{}
+	for _, o := range opts {
+		if err := o(&cfg); err != nil {
+			panic(err)
+		}
+	}
+	if cfg.UnconstrainedInputs {
+		res := frontend.Variable(0)
+		for i := len(bytes) - 1; i >= 0; i-- {
+			res = api.Add(api.Mul(res, 256), bytes[i])
+		}
+		return res
+	}
+	bits := make([]frontend.Variable, 0, 8*len(bytes))
+	for i := range bytes {
+		bits = append(bits, ToBinary(api, bytes[i], WithNbDigits(8))...)
+	}
+	return FromBinary(api, bits, WithUnconstrainedInputs())
+}
+
+// FromBytesBE recomposes a value from its bytes given in big-endian order: the
+// first byte is the most significant one. See [FromBytesLE] for the options.
+func FromBytesBE(api frontend.API, bytes []frontend.Variable, opts ...BaseConversionOption) frontend.Variable {
+	return FromBytesLE(api, reverse(bytes), opts...)
+}
+
+// reverse returns a copy of s in reverse order.
+func reverse(s []frontend.Variable) []frontend.Variable {
+	res := make([]frontend.Variable, len(s))
+	for i := range s {
+		res[len(s)-1-i] = s[i]
+	}
+	return res
+}
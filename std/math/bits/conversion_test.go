@@ -40,6 +40,80 @@ func TestToBinary(t *testing.T) {
 
 }
 
+type endiannessCircuit struct {
+	A       frontend.Variable
+	BitsLE  [3]frontend.Variable
+	BitsBE  [3]frontend.Variable
+	BytesLE [2]frontend.Variable
+	BytesBE [2]frontend.Variable
+}
+
+func (c *endiannessCircuit) Define(api frontend.API) error {
+	bitsLE := bits.ToBinaryLE(api, c.A, bits.WithNbDigits(3))
+	bitsBE := bits.ToBinaryBE(api, c.A, bits.WithNbDigits(3))
+	bytesLE := bits.ToBytesLE(api, c.A, bits.WithNbDigits(2))
+	bytesBE := bits.ToBytesBE(api, c.A, bits.WithNbDigits(2))
+	for i := range c.BitsLE {
+		api.AssertIsEqual(bitsLE[i], c.BitsLE[i])
+		api.AssertIsEqual(bitsBE[i], c.BitsBE[i])
+	}
+	for i := range c.BytesLE {
+		api.AssertIsEqual(bytesLE[i], c.BytesLE[i])
+		api.AssertIsEqual(bytesBE[i], c.BytesBE[i])
+	}
+	api.AssertIsEqual(bits.FromBinaryLE(api, c.BitsLE[:]), c.A)
+	api.AssertIsEqual(bits.FromBinaryBE(api, c.BitsBE[:]), c.A)
+	api.AssertIsEqual(bits.FromBytesLE(api, c.BytesLE[:]), c.A)
+	api.AssertIsEqual(bits.FromBytesBE(api, c.BytesBE[:]), c.A)
+	return nil
+}
+
+func TestEndianness(t *testing.T) {
+	assert := test.NewAssert(t)
+	// 6 = 0b110 = 0x0006
+	assert.CheckCircuit(&endiannessCircuit{},
+		test.WithValidAssignment(&endiannessCircuit{
+			A:       6,
+			BitsLE:  [3]frontend.Variable{0, 1, 1},
+			BitsBE:  [3]frontend.Variable{1, 1, 0},
+			BytesLE: [2]frontend.Variable{6, 0},
+			BytesBE: [2]frontend.Variable{0, 6},
+		}),
+		test.WithInvalidAssignment(&endiannessCircuit{
+			A:       6,
+			BitsLE:  [3]frontend.Variable{1, 1, 0},
+			BitsBE:  [3]frontend.Variable{0, 1, 1},
+			BytesLE: [2]frontend.Variable{0, 6},
+			BytesBE: [2]frontend.Variable{6, 0},
+		}),
+	)
+}
+
+type bytesCircuit struct {
+	A       frontend.Variable
+	BytesBE [2]frontend.Variable
+}
+
+func (c *bytesCircuit) Define(api frontend.API) error {
+	bytesBE := bits.ToBytesBE(api, c.A, bits.WithNbDigits(2))
+	for i := range c.BytesBE {
+		api.AssertIsEqual(bytesBE[i], c.BytesBE[i])
+	}
+	api.AssertIsEqual(bits.FromBytesBE(api, c.BytesBE[:]), c.A)
+	return nil
+}
+
+func TestToBytes(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.CheckCircuit(&bytesCircuit{},
+		test.WithValidAssignment(&bytesCircuit{A: 0x0102, BytesBE: [2]frontend.Variable{0x01, 0x02}}),
+		test.WithValidAssignment(&bytesCircuit{A: 0xffff, BytesBE: [2]frontend.Variable{0xff, 0xff}}),
+		test.WithInvalidAssignment(&bytesCircuit{A: 0x10000, BytesBE: [2]frontend.Variable{0x00, 0x00}}),
+		// bytes out of range, even if the recomposed value matches
+		test.WithInvalidAssignment(&bytesCircuit{A: 0x0102, BytesBE: [2]frontend.Variable{0x00, 0x0102}}),
+	)
+}
+
 type toTernaryCircuit struct {
 	A          frontend.Variable
 	T0, T1, T2 frontend.Variable
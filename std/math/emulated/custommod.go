@@ -32,6 +32,7 @@ func (f *Field[T]) ModAdd(a, b *Element[T], modulus *Element[T]) *Element[T] {
 	var target overflowError
 	for nextOverflow, err = f.addPreCond(a, b); errors.As(err, &target); nextOverflow, err = f.addPreCond(a, b) {
 		if errors.As(err, &target) {
+			f.checkStrictOverflow(err)
 			if !target.reduceRight {
 				a = f.mulMod(a, f.shortOne(), 0, modulus)
 			} else {
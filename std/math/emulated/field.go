@@ -29,6 +29,10 @@ type Field[T FieldParams] struct {
 	maxOf     uint
 	maxOfOnce sync.Once
 
+	// strictOverflow indicates that the operations should fail instead of
+	// automatically reducing the inputs when the result would overflow.
+	strictOverflow bool
+
 	// constants for often used elements n, 0 and 1. Allocated only once
 	nConstOnce        sync.Once
 	nConst            *Element[T]
@@ -268,6 +272,41 @@ func (f *Field[T]) maxOverflow() uint {
 	return f.maxOf
 }
 
+// Overflow returns the overflow of the element a, i.e. the number of bits the
+// limbs of a may exceed the limb width [FieldParams.BitsPerLimb]. The overflow
+// is zero for elements in normal form and increases with every addition,
+// subtraction or multiplication without reduction.
+func (f *Field[T]) Overflow(a *Element[T]) uint {
+	return a.overflow
+}
+
+// MaxOverflow returns the maximal overflow an element may have. If the overflow
+// of a result of an operation would exceed this value, then the inputs are
+// reduced first (or the operation fails in strict mode, see
+// [Field.SetStrictOverflow]).
+func (f *Field[T]) MaxOverflow() uint {
+	return f.maxOverflow()
+}
+
+// SetStrictOverflow sets the strict overflow mode and returns the previous
+// mode. In strict mode the operations do not automatically reduce the inputs
+// when the result would overflow the native field, but instead fail the
+// circuit compilation with an error indicating the operation and the overflow.
+// This allows to audit the reduction points of gadgets composing multiple
+// operations. Explicit reductions with [Field.Reduce] are still allowed.
+//
+// As the [Field] instance is shared between all gadgets using the same
+// emulated field in the circuit, then the mode should be restored after the
+// audited section:
+//
+//	prev := f.SetStrictOverflow(true)
+//	defer f.SetStrictOverflow(prev)
+func (f *Field[T]) SetStrictOverflow(strict bool) (prev bool) {
+	prev = f.strictOverflow
+	f.strictOverflow = strict
+	return prev
+}
+
 func max[T constraints.Ordered](a ...T) T {
 	if len(a) == 0 {
 		var f T
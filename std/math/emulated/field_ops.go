@@ -152,6 +152,9 @@ func (f *Field[T]) Sum(inputs ...*Element[T]) *Element[T] {
 		}
 	}
 	addOverflow := bits.Len(uint(len(inputs)))
+	if nextOverflow := overflow + uint(addOverflow); nextOverflow > f.maxOverflow() {
+		f.checkStrictOverflow(overflowError{op: "sum", nextOverflow: nextOverflow, maxOverflow: f.maxOverflow()})
+	}
 	limbs := make([]frontend.Variable, nbLimbs)
 	for i := range limbs {
 		limbs[i] = 0
@@ -349,6 +352,7 @@ func (f *Field[T]) reduceAndOp(op func(*Element[T], *Element[T], uint) *Element[
 	var target overflowError
 
 	for nextOverflow, err = preCond(a, b); errors.As(err, &target); nextOverflow, err = preCond(a, b) {
+		f.checkStrictOverflow(err)
 		if !target.reduceRight {
 			a = f.Reduce(a)
 		} else {
@@ -358,6 +362,14 @@ func (f *Field[T]) reduceAndOp(op func(*Element[T], *Element[T], uint) *Element[
 	return op(a, b, nextOverflow)
 }
 
+// checkStrictOverflow panics with the overflow error err if strict overflow
+// mode is enabled. See [Field.SetStrictOverflow].
+func (f *Field[T]) checkStrictOverflow(err error) {
+	if f.strictOverflow {
+		panic(fmt.Errorf("strict overflow mode: %w", err))
+	}
+}
+
 type overflowError struct {
 	op           string
 	nextOverflow uint
@@ -309,3 +309,38 @@ func TestHintNativeOutput(t *testing.T) {
 	testHintNativeOutput[Secp256k1Fp](t)
 	testHintNativeOutput[BN254Fp](t)
 }
+
+type StrictOverflowCircuit[T FieldParams] struct {
+	A, B   Element[T]
+	Strict bool `gnark:"-"`
+}
+
+func (c *StrictOverflowCircuit[T]) Define(api frontend.API) error {
+	f, err := NewField[T](api)
+	if err != nil {
+		return err
+	}
+	prev := f.SetStrictOverflow(c.Strict)
+	defer f.SetStrictOverflow(prev)
+	// the overflow of the product of non-reduced elements exceeds the maximum
+	// and requires the reduction of the inputs
+	a := f.Add(&c.A, &c.A)
+	b := f.Add(&c.B, &c.B)
+	if f.Overflow(a) != 1 || f.Overflow(b) != 1 {
+		return fmt.Errorf("unexpected overflow %d %d", f.Overflow(a), f.Overflow(b))
+	}
+	for f.Overflow(a) < f.MaxOverflow()-f.Overflow(b) {
+		a = f.Add(a, a)
+	}
+	f.Mul(a, b)
+	return nil
+}
+
+func TestStrictOverflow(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, err := frontend.Compile(testCurve.ScalarField(), r1cs.NewBuilder, &StrictOverflowCircuit[Secp256k1Fp]{})
+	assert.NoError(err)
+	_, err = frontend.Compile(testCurve.ScalarField(), r1cs.NewBuilder, &StrictOverflowCircuit[Secp256k1Fp]{Strict: true})
+	assert.Error(err)
+	assert.ErrorContains(err, "strict overflow mode: op mul")
+}
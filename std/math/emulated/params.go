@@ -13,7 +13,7 @@ import (
 //   - [Goldilocks]
 //   - [Secp256k1Fp] and [Secp256k1Fr]
 //   - [BN254Fp] and [BN254Fr]
-//   - [BLS12377Fp]
+//   - [BLS12377Fp] and [BLS12377Fr]
 //   - [BLS12381Fp] and [BLS12381Fr]
 //   - [P256Fp] and [P256Fr]
 //   - [P384Fp] and [P384Fr]
@@ -31,6 +31,7 @@ type (
 	BN254Fp     = emparams.BN254Fp
 	BN254Fr     = emparams.BN254Fr
 	BLS12377Fp  = emparams.BLS12377Fp
+	BLS12377Fr  = emparams.BLS12377Fr
 	BLS12381Fp  = emparams.BLS12381Fp
 	BLS12381Fr  = emparams.BLS12381Fr
 	P256Fp      = emparams.P256Fp
@@ -101,6 +101,62 @@ func (p *Polynomial[FR]) EvalUnivariate(P Univariate[FR], at *emulated.Element[F
 	return res
 }
 
+// EvalUnivariateMany evaluates univariate polynomials P at a point at. It
+// returns the evaluations in the same order as the inputs. The method does not
+// mutate the inputs.
+//
+// The method allows to share the computation of the powers of at between the
+// polynomials and reduces each evaluation only once.
+func (p *Polynomial[FR]) EvalUnivariateMany(at *emulated.Element[FR], P ...Univariate[FR]) []*emulated.Element[FR] {
+	var maxLen int
+	for i := range P {
+		if len(P[i]) > maxLen {
+			maxLen = len(P[i])
+		}
+	}
+	powers := make([]*emulated.Element[FR], maxLen)
+	if maxLen > 0 {
+		powers[0] = p.f.One()
+	}
+	for i := 1; i < maxLen; i++ {
+		powers[i] = p.f.Mul(powers[i-1], at)
+	}
+	res := make([]*emulated.Element[FR], len(P))
+	for i := range P {
+		if len(P[i]) == 0 {
+			res[i] = p.f.Zero()
+			continue
+		}
+		res[i] = p.innerProduct(FromSlice(P[i]), powers[:len(P[i])])
+	}
+	return res
+}
+
+// EvalUnivariateConstant evaluates univariate polynomial with constant
+// coefficients at a point at using Horner's rule. It returns the evaluation.
+// The coefficients are given in increasing degree order and are reduced modulo
+// the emulated field modulus.
+//
+// The method is useful when the polynomial is known at circuit compilation time
+// (for example when it is fixed by a verification key), as adding constant
+// coefficients does not create additional multiplication checks.
+func (p *Polynomial[FR]) EvalUnivariateConstant(P []*big.Int, at *emulated.Element[FR]) *emulated.Element[FR] {
+	if len(P) == 0 {
+		return p.f.Zero()
+	}
+	var fr FR
+	coeffs := make([]*emulated.Element[FR], len(P))
+	for i := range P {
+		coeffs[i] = p.f.NewElement(new(big.Int).Mod(P[i], fr.Modulus()))
+	}
+	res := coeffs[len(coeffs)-1]
+	for i := len(coeffs) - 2; i >= 0; i-- {
+		res = p.f.Mul(res, at)
+		res = p.f.Add(res, coeffs[i])
+	}
+	return res
+}
+
 // EvalMultilinear evaluates multilinear polynomial at variable values at. It
 // returns the evaluation. The method does not mutate the inputs.
 func (p *Polynomial[FR]) EvalMultilinear(at []*emulated.Element[FR], M Multilinear[FR]) (*emulated.Element[FR], error) {
@@ -1,6 +1,7 @@
 package polynomial
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark/frontend"
@@ -49,6 +50,67 @@ func TestEvalPoly(t *testing.T) {
 	testEvalPoly[emparams.BN254Fr](t, []int64{1, 2, 3, 4}, 5, 586)
 }
 
+type evalPolyManyCircuit[FR emulated.FieldParams] struct {
+	P           [][]emulated.Element[FR] `gnark:",public"`
+	At          emulated.Element[FR]     `gnark:",secret"`
+	Evaluations []emulated.Element[FR]   `gnark:",secret"`
+	Constant    []*big.Int               `gnark:"-"`
+}
+
+func (c *evalPolyManyCircuit[FR]) Define(api frontend.API) error {
+	p, err := New[FR](api)
+	if err != nil {
+		return err
+	}
+	f, err := emulated.NewField[FR](api)
+	if err != nil {
+		return err
+	}
+	Ps := make([]Univariate[FR], len(c.P))
+	for i := range c.P {
+		Ps[i] = c.P[i]
+	}
+	res := p.EvalUnivariateMany(&c.At, Ps...)
+	for i := range res {
+		f.AssertIsEqual(res[i], &c.Evaluations[i])
+		// Horner evaluation must agree with the shared-powers evaluation
+		f.AssertIsEqual(p.EvalUnivariate(Ps[i], &c.At), res[i])
+	}
+	resConst := p.EvalUnivariateConstant(c.Constant, &c.At)
+	f.AssertIsEqual(resConst, &c.Evaluations[0])
+	return nil
+}
+
+func TestEvalPolyMany(t *testing.T) {
+	assert := test.NewAssert(t)
+	ps := [][]int64{{1, 2, 3, 4}, {5, 6}, {7}}
+	at := int64(5)
+	evals := []int64{586, 35, 7}
+	P := make([][]emulated.Element[emparams.BN254Fr], len(ps))
+	placeholder := make([][]emulated.Element[emparams.BN254Fr], len(ps))
+	E := make([]emulated.Element[emparams.BN254Fr], len(ps))
+	for i := range ps {
+		P[i] = make([]emulated.Element[emparams.BN254Fr], len(ps[i]))
+		placeholder[i] = make([]emulated.Element[emparams.BN254Fr], len(ps[i]))
+		for j := range ps[i] {
+			P[i][j] = emulated.ValueOf[emparams.BN254Fr](ps[i][j])
+		}
+		E[i] = emulated.ValueOf[emparams.BN254Fr](evals[i])
+	}
+	constant := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)}
+	witness := evalPolyManyCircuit[emparams.BN254Fr]{
+		P:           P,
+		At:          emulated.ValueOf[emparams.BN254Fr](at),
+		Evaluations: E,
+	}
+	circuit := evalPolyManyCircuit[emparams.BN254Fr]{
+		P:           placeholder,
+		Evaluations: make([]emulated.Element[emparams.BN254Fr], len(ps)),
+		Constant:    constant,
+	}
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness))
+}
+
 type evalMultiLinCircuit[FR emulated.FieldParams] struct {
 	M          []emulated.Element[FR] `gnark:",public"`
 	At         []emulated.Element[FR] `gnark:",secret"`
@@ -0,0 +1,58 @@
+package privacy
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash"
+)
+
+// ExtendedKey is a secret key with the chain code from which its children are
+// derived.
+type ExtendedKey struct {
+	Key       frontend.Variable
+	ChainCode frontend.Variable
+}
+
+// MasterKeyCommitment returns the commitment to the master key,
+// H(DomainMasterKey, key, chain code).
+func MasterKeyCommitment(h hash.FieldHasher, master ExtendedKey) frontend.Variable {
+	return sum(h, DomainMasterKey, master.Key, master.ChainCode)
+}
+
+// DeriveHardened returns the child of the parent key at the given index. The
+// child key and chain code are H(DomainChildKey, key, chain code, index) and
+// H(DomainChildChainCode, key, chain code, index). As the derivation depends
+// on the parent secret key, a child key doesn't leak information on its
+// parent or siblings.
+func DeriveHardened(h hash.FieldHasher, parent ExtendedKey, index frontend.Variable) ExtendedKey {
+	return ExtendedKey{
+		Key:       sum(h, DomainChildKey, parent.Key, parent.ChainCode, index),
+		ChainCode: sum(h, DomainChildChainCode, parent.Key, parent.ChainCode, index),
+	}
+}
+
+// DerivePath returns the key derived from master along the path of indices.
+func DerivePath(h hash.FieldHasher, master ExtendedKey, path ...frontend.Variable) ExtendedKey {
+	key := master
+	for i := range path {
+		key = DeriveHardened(h, key, path[i])
+	}
+	return key
+}
+
+// AssertDerivedPublicKey asserts that publicKey is [sk]G, where G is the base
+// point of the curve and sk the secret key derived from the master key along
+// the path, and that masterCommitment is the commitment to the master key.
+func AssertDerivedPublicKey(curve twistededwards.Curve, h hash.FieldHasher, master ExtendedKey, masterCommitment frontend.Variable, path []frontend.Variable, publicKey twistededwards.Point) {
+	api := curve.API()
+	api.AssertIsEqual(masterCommitment, MasterKeyCommitment(h, master))
+
+	child := DerivePath(h, master, path...)
+	base := twistededwards.Point{
+		X: curve.Params().Base[0],
+		Y: curve.Params().Base[1],
+	}
+	res := curve.ScalarMul(base, child.Key)
+	api.AssertIsEqual(res.X, publicKey.X)
+	api.AssertIsEqual(res.Y, publicKey.Y)
+}
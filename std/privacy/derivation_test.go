@@ -0,0 +1,62 @@
+package privacy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	tbn254 "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type derivationCircuit struct {
+	Master           ExtendedKey
+	MasterCommitment frontend.Variable `gnark:",public"`
+	Path             [3]frontend.Variable
+	PublicKey        twistededwards.Point `gnark:",public"`
+}
+
+func (c *derivationCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	AssertDerivedPublicKey(curve, &h, c.Master, c.MasterCommitment, c.Path[:], c.PublicKey)
+	return nil
+}
+
+func TestDerivedPublicKey(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	key, chainCode := big.NewInt(1234), big.NewInt(5678)
+	path := []*big.Int{big.NewInt(44), big.NewInt(60), big.NewInt(0)}
+
+	var witness derivationCircuit
+	witness.Master = ExtendedKey{Key: key, ChainCode: chainCode}
+	witness.MasterCommitment = nativeSum(DomainMasterKey, key, chainCode)
+	for i := range path {
+		witness.Path[i] = path[i]
+		key, chainCode = nativeSum(DomainChildKey, key, chainCode, path[i]), nativeSum(DomainChildChainCode, key, chainCode, path[i])
+	}
+	var pk tbn254.PointAffine
+	base := tbn254.GetEdwardsCurve().Base
+	pk.ScalarMultiplication(&base, key)
+	witness.PublicKey = twistededwards.Point{X: pk.X, Y: pk.Y}
+
+	// the public key is derived along another path
+	wrongPath := witness
+	wrongPath.Path[2] = 1
+
+	assert.CheckCircuit(&derivationCircuit{},
+		test.WithValidAssignment(&witness),
+		test.WithInvalidAssignment(&wrongPath),
+		test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,51 @@
+package privacy
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// TimestampCommitment returns the commitment to the timestamp,
+// H(DomainTimestamp, timestamp, blinding).
+func TimestampCommitment(h hash.FieldHasher, timestamp, blinding frontend.Variable) frontend.Variable {
+	return sum(h, DomainTimestamp, timestamp, blinding)
+}
+
+// FreshnessTag returns H(DomainFreshness, commitment, blinding, nonce). When
+// the nonce is chosen by the verifier and the tag is a public input, the proof
+// can't be replayed for another nonce, and only the holder of the opening of
+// the commitment can compute the tag.
+func FreshnessTag(h hash.FieldHasher, commitment, blinding, nonce frontend.Variable) frontend.Variable {
+	return sum(h, DomainFreshness, commitment, blinding, nonce)
+}
+
+// AssertTimestampInInterval asserts that commitment is the commitment to a
+// timestamp with blinding, and that lower ≤ timestamp ≤ upper. The timestamp
+// and the bounds must fit in nbBits bits, they are range checked accordingly.
+func AssertTimestampInInterval(api frontend.API, h hash.FieldHasher, commitment, timestamp, blinding, lower, upper frontend.Variable, nbBits int) {
+	if nbBits >= api.Compiler().FieldBitLen()-1 {
+		panic(fmt.Sprintf("timestamps of %d bits are too large for the field", nbBits))
+	}
+	api.AssertIsEqual(commitment, TimestampCommitment(h, timestamp, blinding))
+
+	// the differences wrap around the field if the timestamp is out of the
+	// interval, and then don't fit in nbBits bits. The bounds are checked too,
+	// as a bound wrapping around the field would make the difference fit.
+	rc := rangecheck.New(api)
+	rc.Check(timestamp, nbBits)
+	rc.Check(lower, nbBits)
+	rc.Check(upper, nbBits)
+	rc.Check(api.Sub(timestamp, lower), nbBits)
+	rc.Check(api.Sub(upper, timestamp), nbBits)
+}
+
+// AssertFreshTimestampInInterval asserts that the committed timestamp is in
+// the interval as [AssertTimestampInInterval], and that tag is its freshness
+// tag for the nonce.
+func AssertFreshTimestampInInterval(api frontend.API, h hash.FieldHasher, commitment, timestamp, blinding, lower, upper, nonce, tag frontend.Variable, nbBits int) {
+	AssertTimestampInInterval(api, h, commitment, timestamp, blinding, lower, upper, nbBits)
+	api.AssertIsEqual(tag, FreshnessTag(h, commitment, blinding, nonce))
+}
@@ -0,0 +1,63 @@
+package privacy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type intervalCircuit struct {
+	Timestamp, Blinding frontend.Variable
+	Commitment          frontend.Variable `gnark:",public"`
+	Lower, Upper        frontend.Variable `gnark:",public"`
+	Nonce, Tag          frontend.Variable `gnark:",public"`
+}
+
+func (c *intervalCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	AssertFreshTimestampInInterval(api, &h, c.Commitment, c.Timestamp, c.Blinding, c.Lower, c.Upper, c.Nonce, c.Tag, 40)
+	return nil
+}
+
+func TestTimestampInInterval(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	assignment := func(timestamp, nonce int64) *intervalCircuit {
+		blinding := big.NewInt(987654321)
+		commitment := nativeSum(DomainTimestamp, big.NewInt(timestamp), blinding)
+		return &intervalCircuit{
+			Timestamp:  timestamp,
+			Blinding:   blinding,
+			Commitment: commitment,
+			Lower:      1700000000,
+			Upper:      1800000000,
+			Nonce:      nonce,
+			Tag:        nativeSum(DomainFreshness, commitment, blinding, big.NewInt(nonce)),
+		}
+	}
+
+	// replayed for another nonce
+	replayed := assignment(1750000000, 1)
+	replayed.Nonce = 2
+
+	// lower bound of -1, wrapping around the field
+	wrapped := assignment(1750000000, 1)
+	wrapped.Lower = new(big.Int).Sub(ecc.BN254.ScalarField(), big.NewInt(1))
+
+	assert.CheckCircuit(&intervalCircuit{},
+		test.WithValidAssignment(assignment(1750000000, 1)),
+		test.WithValidAssignment(assignment(1700000000, 1)),
+		test.WithValidAssignment(assignment(1800000000, 1)),
+		test.WithInvalidAssignment(assignment(1699999999, 1)),
+		test.WithInvalidAssignment(assignment(1800000001, 1)),
+		test.WithInvalidAssignment(replayed),
+		test.WithInvalidAssignment(wrapped),
+		test.WithCurves(ecc.BN254))
+}
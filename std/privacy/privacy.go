@@ -0,0 +1,93 @@
+// Package privacy implements the primitives shared by private payment
+// protocols: addresses derived from a secret key, note commitments,
+// nullifiers, hierarchical key derivation and interval proofs over committed
+// timestamps.
+//
+// A note is an amount owned by an address. It is published as its commitment,
+// which hides its content. To spend it, its owner proves the knowledge of the
+// secret key of the address and publishes its nullifier, which is unlinkable to
+// the commitment but is unique for the note, preventing double spending.
+//
+// Credentials and attestations commit to their timestamp, which can be proven
+// to lie in a public interval, e.g. before an expiry date, without revealing
+// it (see [AssertTimestampInInterval]).
+//
+// Keys can also be derived from a master key along a path, as in BIP32
+// hardened derivation, so that ownership of a derived public key is proven
+// without revealing the master key (see [AssertDerivedPublicKey]).
+//
+// All the primitives are built on a SNARK-friendly hash function, given as a
+// [hash.FieldHasher], which is used as a PRF keyed by the secret key for the
+// address, the nullifier, the key derivation and the freshness tag. The inputs
+// are domain separated, so that the same hash function can be used for all
+// primitives. The hasher is reset before and after use.
+package privacy
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+)
+
+// Domain separation tags, written first to the hash function.
+const (
+	DomainAddress = iota + 1
+	DomainNoteCommitment
+	DomainNullifier
+	DomainMasterKey
+	DomainChildKey
+	DomainChildChainCode
+	DomainTimestamp
+	DomainFreshness
+)
+
+// Note is a private note.
+type Note struct {
+	// Owner is the address of the owner of the note.
+	Owner frontend.Variable
+	// Value is the amount of the note. It must be range checked by the caller.
+	Value frontend.Variable
+	// Rho is a nonce unique to the note, from which its nullifier is derived.
+	Rho frontend.Variable
+	// Blinding is the randomness of the commitment to the note.
+	Blinding frontend.Variable
+}
+
+// Address returns the address derived from the secret key, H(DomainAddress, sk).
+func Address(h hash.FieldHasher, secretKey frontend.Variable) frontend.Variable {
+	return sum(h, DomainAddress, secretKey)
+}
+
+// NoteCommitment returns the commitment to the note,
+// H(DomainNoteCommitment, owner, value, rho, blinding).
+func NoteCommitment(h hash.FieldHasher, note Note) frontend.Variable {
+	return sum(h, DomainNoteCommitment, note.Owner, note.Value, note.Rho, note.Blinding)
+}
+
+// Nullifier returns the nullifier of the note of nonce rho owned by the secret
+// key, H(DomainNullifier, sk, rho). It can only be computed by the owner of the
+// note.
+func Nullifier(h hash.FieldHasher, secretKey, rho frontend.Variable) frontend.Variable {
+	return sum(h, DomainNullifier, secretKey, rho)
+}
+
+// AssertOwnership asserts that the note is owned by the secret key.
+func AssertOwnership(api frontend.API, h hash.FieldHasher, note Note, secretKey frontend.Variable) {
+	api.AssertIsEqual(note.Owner, Address(h, secretKey))
+}
+
+// AssertSpend asserts that the note of the given commitment is owned by the
+// secret key and that nullifier is its nullifier.
+func AssertSpend(api frontend.API, h hash.FieldHasher, note Note, commitment, nullifier, secretKey frontend.Variable) {
+	AssertOwnership(api, h, note, secretKey)
+	api.AssertIsEqual(commitment, NoteCommitment(h, note))
+	api.AssertIsEqual(nullifier, Nullifier(h, secretKey, note.Rho))
+}
+
+func sum(h hash.FieldHasher, domain int, data ...frontend.Variable) frontend.Variable {
+	h.Reset()
+	h.Write(domain)
+	h.Write(data...)
+	res := h.Sum()
+	h.Reset()
+	return res
+}
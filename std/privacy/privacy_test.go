@@ -0,0 +1,67 @@
+package privacy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	nativemimc "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+func nativeSum(domain int64, data ...*big.Int) *big.Int {
+	h := nativemimc.NewMiMC()
+	for _, d := range append([]*big.Int{big.NewInt(domain)}, data...) {
+		var e fr.Element
+		e.SetBigInt(d)
+		b := e.Bytes()
+		h.Write(b[:])
+	}
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
+
+type spendCircuit struct {
+	Note       Note
+	SecretKey  frontend.Variable
+	Commitment frontend.Variable `gnark:",public"`
+	Nullifier  frontend.Variable `gnark:",public"`
+}
+
+func (c *spendCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	AssertSpend(api, &h, c.Note, c.Commitment, c.Nullifier, c.SecretKey)
+	return nil
+}
+
+func TestSpend(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	sk, rho, blinding, value := big.NewInt(42), big.NewInt(7), big.NewInt(1234), big.NewInt(100)
+	owner := nativeSum(DomainAddress, sk)
+
+	var witness spendCircuit
+	witness.Note = Note{Owner: owner, Value: value, Rho: rho, Blinding: blinding}
+	witness.SecretKey = sk
+	witness.Commitment = nativeSum(DomainNoteCommitment, owner, value, rho, blinding)
+	witness.Nullifier = nativeSum(DomainNullifier, sk, rho)
+
+	// spending with another key
+	wrongKey := witness
+	wrongKey.SecretKey = 43
+
+	// double spending with another nullifier
+	wrongNullifier := witness
+	wrongNullifier.Nullifier = nativeSum(DomainNullifier, sk, big.NewInt(8))
+
+	assert.CheckCircuit(&spendCircuit{},
+		test.WithValidAssignment(&witness),
+		test.WithInvalidAssignment(&wrongKey),
+		test.WithInvalidAssignment(&wrongNullifier),
+		test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,137 @@
+// Package publicinput allows to commit to a large number of public inputs with
+// a single one.
+//
+// The cost of the verification of a proof grows linearly with the number of
+// public inputs. When there are thousands of them (rollup state, batch data),
+// they can instead be given as secret inputs to the circuit, which asserts
+// that their hash is the only public input. The verifier then computes the
+// digest natively from the data it is given, and verifies the proof against
+// it.
+//
+// Compiling the circuit with [frontend.WithPublicInputsHash] does this for all
+// the declared public inputs. [AssertDigest] and [Digest] do it for a chosen
+// subset of the inputs, with the same [frontend.PublicInputsHasher], so that
+// both ways give the same digest. [Polynomial] and [Evaluate] build the PLONK
+// public-input polynomial committing to the inputs, for verifiers implemented
+// outside of gnark.
+package publicinput
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// AssertDigest asserts that digest is the hash of inputs with h.
+func AssertDigest(api frontend.API, h frontend.PublicInputsHasher, digest frontend.Variable, inputs ...frontend.Variable) error {
+	res, err := h.HashVariables(api, inputs)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(digest, res)
+	return nil
+}
+
+// Digest returns the hash of inputs with h in the field, to be used as the
+// public input of a circuit calling [AssertDigest].
+func Digest(h frontend.PublicInputsHasher, field *big.Int, inputs ...*big.Int) (*big.Int, error) {
+	return h.HashValues(field, inputs)
+}
+
+// Polynomial returns the PLONK public-input polynomial, in Lagrange form on a
+// domain of size n, of a circuit whose public inputs are the values
+// publicInputs hashed with h, the digest being its only public input as with
+// [frontend.WithPublicInputsHash]. The digest is the value at ω⁰ and the value
+// of each commitment term is added at ω^Index, the values at the other points
+// of the domain being zero:
+//
+//	PI = digest⋅L₀ + ∑ cⱼ⋅L_kⱼ
+//
+// The commitment terms depend on the proof, see [CommitmentTerm]. The result can
+// be evaluated with [Evaluate].
+func Polynomial(h frontend.PublicInputsHasher, field *big.Int, publicInputs []*big.Int, n uint64, commitments ...CommitmentTerm) ([]*big.Int, error) {
+	if n == 0 {
+		return nil, errors.New("empty domain")
+	}
+	digest, err := Digest(h, field, publicInputs...)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*big.Int, n)
+	res[0] = digest
+	for i := 1; i < len(res); i++ {
+		res[i] = new(big.Int)
+	}
+	for _, c := range commitments {
+		if c.Index >= n {
+			return nil, errors.New("commitment index out of the domain")
+		}
+		res[c.Index] = new(big.Int).Add(res[c.Index], c.Value)
+		res[c.Index].Mod(res[c.Index], field)
+	}
+	return res, nil
+}
+
+// CommitmentTerm is the term of a BSB22 commitment (api.Commit) added by the
+// PLONK verifier to the public-input polynomial: the value Value at the point
+// ω^Index of the domain. For the i-th commitment of the proof, Index is
+// vk.NbPublicVariables+vk.CommitmentConstraintIndexes[i] and Value the hash to
+// field of proof.Bsb22Commitments[i], as computed by the verifier.
+type CommitmentTerm struct {
+	Index uint64
+	Value *big.Int
+}
+
+// Evaluate returns the evaluation at ζ of the polynomial given by its values
+// on the first len(values) points of the domain of size n generated by ω, and
+// by the terms of the commitments:
+//
+//	PI(ζ) = ∑ vᵢ⋅Lᵢ(ζ) + ∑ cⱼ⋅L_kⱼ(ζ), Lᵢ(ζ) = ωⁱ/n⋅(ζⁿ-1)/(ζ-ωⁱ)
+//
+// as computed by the PLONK verifier. ζ must not be in the domain.
+func Evaluate(field *big.Int, omega *big.Int, n uint64, values []*big.Int, zeta *big.Int, commitments ...CommitmentTerm) (*big.Int, error) {
+	if uint64(len(values)) > n {
+		return nil, errors.New("more values than the size of the domain")
+	}
+	nInv := new(big.Int).SetUint64(n)
+	if nInv.ModInverse(nInv, field) == nil {
+		return nil, errors.New("domain size not invertible in the field")
+	}
+	// (ζⁿ-1)/n
+	zh := new(big.Int).Exp(zeta, new(big.Int).SetUint64(n), field)
+	zh.Sub(zh, big.NewInt(1))
+	zh.Mul(zh, nInv).Mod(zh, field)
+
+	// res += v⋅ωⁱ/n⋅(ζⁿ-1)/(ζ-ωⁱ)
+	res := new(big.Int)
+	var li, den big.Int
+	addTerm := func(wi, v *big.Int) error {
+		den.Sub(zeta, wi).Mod(&den, field)
+		if den.ModInverse(&den, field) == nil {
+			return errors.New("evaluation point in the domain")
+		}
+		li.Mul(wi, zh).Mul(&li, &den)
+		li.Mul(&li, v)
+		res.Add(res, &li).Mod(res, field)
+		return nil
+	}
+
+	wi := big.NewInt(1)
+	for i := range values {
+		if err := addTerm(wi, values[i]); err != nil {
+			return nil, err
+		}
+		wi = new(big.Int).Mul(wi, omega)
+		wi.Mod(wi, field)
+	}
+	for _, c := range commitments {
+		if c.Index >= n {
+			return nil, errors.New("commitment index out of the domain")
+		}
+		if err := addTerm(new(big.Int).Exp(omega, new(big.Int).SetUint64(c.Index), field), c.Value); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
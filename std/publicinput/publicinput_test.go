@@ -0,0 +1,134 @@
+package publicinput
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+const nbInputs = 100
+
+type batchCircuit struct {
+	Data   [nbInputs]frontend.Variable
+	Digest frontend.Variable `gnark:",public"`
+}
+
+func (c *batchCircuit) Define(api frontend.API) error {
+	return AssertDigest(api, mimc.PublicInputsHasher{}, c.Digest, c.Data[:]...)
+}
+
+type publicBatchCircuit struct {
+	Data [nbInputs]frontend.Variable `gnark:",public"`
+}
+
+func (c *publicBatchCircuit) Define(api frontend.API) error {
+	return nil
+}
+
+func TestDigest(t *testing.T) {
+	assert := test.NewAssert(t)
+	field := ecc.BN254.ScalarField()
+
+	var witness batchCircuit
+	var public publicBatchCircuit
+	data := make([]*big.Int, nbInputs)
+	for i := range data {
+		data[i] = big.NewInt(int64(i * i))
+		witness.Data[i] = data[i]
+		public.Data[i] = data[i]
+	}
+	digest, err := Digest(mimc.PublicInputsHasher{}, field, data...)
+	assert.NoError(err)
+	witness.Digest = digest
+
+	invalid := witness
+	invalid.Data[3] = 10
+
+	assert.CheckCircuit(&batchCircuit{},
+		test.WithValidAssignment(&witness),
+		test.WithInvalidAssignment(&invalid),
+		test.WithCurves(ecc.BN254))
+
+	_, err = Digest(mimc.PublicInputsHasher{}, field, field)
+	assert.Error(err)
+
+	// the polynomial starts with the public witness of the circuit compiled
+	// with hashed public inputs, and holds the commitment terms at their index
+	const n = 8
+	commitment := CommitmentTerm{Index: 5, Value: big.NewInt(42)}
+	pi, err := Polynomial(mimc.PublicInputsHasher{}, field, data, n, commitment)
+	assert.NoError(err)
+	assert.Equal(n, len(pi))
+	w, err := frontend.NewWitness(&public, field, frontend.PublicInputsHashed(mimc.PublicInputsHasher{}), frontend.PublicOnly())
+	assert.NoError(err)
+	values := w.Vector().(fr.Vector)
+	for i := range pi {
+		var v fr.Element
+		switch {
+		case i < len(values):
+			v = values[i]
+		case uint64(i) == commitment.Index:
+			v.SetBigInt(commitment.Value)
+		}
+		var p fr.Element
+		p.SetBigInt(pi[i])
+		assert.True(v.Equal(&p), "value %d", i)
+	}
+
+	// both forms evaluate to the same value
+	domain := fft.NewDomain(n)
+	omega := domain.Generator.BigInt(new(big.Int))
+	var zeta fr.Element
+	zeta.SetRandom()
+	fromPolynomial, err := Evaluate(field, omega, n, pi, zeta.BigInt(new(big.Int)))
+	assert.NoError(err)
+	fromTerms, err := Evaluate(field, omega, n, []*big.Int{digest}, zeta.BigInt(new(big.Int)), commitment)
+	assert.NoError(err)
+	assert.Equal(fromTerms, fromPolynomial)
+
+	_, err = Polynomial(mimc.PublicInputsHasher{}, field, data, n, CommitmentTerm{Index: n, Value: big.NewInt(1)})
+	assert.Error(err)
+}
+
+func TestEvaluate(t *testing.T) {
+	assert := test.NewAssert(t)
+	field := ecc.BN254.ScalarField()
+	const n = 16
+
+	values := make([]*big.Int, 5)
+	lagrange := make([]fr.Element, n)
+	for i := range values {
+		lagrange[i].SetRandom()
+		values[i] = lagrange[i].BigInt(new(big.Int))
+	}
+	// the term of a commitment after the public inputs
+	lagrange[9].SetRandom()
+	commitment := CommitmentTerm{Index: 9, Value: lagrange[9].BigInt(new(big.Int))}
+	var zeta fr.Element
+	zeta.SetRandom()
+
+	// evaluate the interpolated polynomial in canonical form
+	domain := fft.NewDomain(n)
+	domain.FFTInverse(lagrange, fft.DIF)
+	fft.BitReverse(lagrange)
+	var expected fr.Element
+	for i := n - 1; i >= 0; i-- {
+		expected.Mul(&expected, &zeta).Add(&expected, &lagrange[i])
+	}
+
+	res, err := Evaluate(field, domain.Generator.BigInt(new(big.Int)), n, values, zeta.BigInt(new(big.Int)), commitment)
+	assert.NoError(err)
+	assert.Equal(expected.BigInt(new(big.Int)), res)
+
+	_, err = Evaluate(field, domain.Generator.BigInt(new(big.Int)), n, values, zeta.BigInt(new(big.Int)), CommitmentTerm{Index: n, Value: big.NewInt(1)})
+	assert.Error(err)
+
+	_, err = Evaluate(field, domain.Generator.BigInt(new(big.Int)), n, values, big.NewInt(1))
+	assert.Error(err)
+}
@@ -0,0 +1,75 @@
+// Package shuffle implements verifiable shuffles, as used in mixnets and
+// voting schemes.
+//
+// A shuffle of a list of ciphertexts is a permutation of the list, where every
+// ciphertext is re-randomized, so that the outputs can't be linked to the
+// inputs. The prover knows the permutation and the randomness, and proves that
+// the outputs are a shuffle of the inputs without revealing them.
+//
+// The permutation is proven with a log-derivative argument. For rows a and b,
+// the multisets {a_i} and {b_i} are equal if and only if
+//
+//	∑ 1/(x - ∑_k r_k*a_{i,k}) == ∑ 1/(x - ∑_k r_k*b_{i,k}),
+//
+// which is checked for random x and r_k derived from a commitment to the rows
+// (see [github.com/consensys/gnark/std/multicommit.WithCommitment]). This
+// requires the builder to implement [frontend.Committer].
+package shuffle
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/internal/logderivarg"
+)
+
+// Ciphertext is an ElGamal ciphertext (C1, C2) = ([k]G, M + [k]PK) over a
+// twisted Edwards curve, where G is the base point, PK the public key and M
+// the encoded message.
+type Ciphertext struct {
+	C1, C2 twistededwards.Point
+}
+
+// Rerandomize returns the ciphertext re-randomized with r for the public key,
+// (C1 + [r]G, C2 + [r]PK). It encrypts the same message.
+func Rerandomize(curve twistededwards.Curve, publicKey twistededwards.Point, c Ciphertext, r frontend.Variable) Ciphertext {
+	base := twistededwards.Point{
+		X: curve.Params().Base[0],
+		Y: curve.Params().Base[1],
+	}
+	return Ciphertext{
+		C1: curve.Add(c.C1, curve.ScalarMul(base, r)),
+		C2: curve.Add(c.C2, curve.ScalarMul(publicKey, r)),
+	}
+}
+
+// AssertShuffle asserts that outputs is a shuffle of inputs for the public
+// key. The witness of the shuffle is given as the inputs in the order of the
+// outputs, permuted[i] = inputs[π(i)], and the randomness such that
+// outputs[i] = Rerandomize(permuted[i], randomness[i]).
+func AssertShuffle(api frontend.API, curve twistededwards.Curve, publicKey twistededwards.Point, inputs, outputs, permuted []Ciphertext, randomness []frontend.Variable) error {
+	if len(outputs) != len(inputs) || len(permuted) != len(inputs) || len(randomness) != len(inputs) {
+		return fmt.Errorf("got %d inputs, %d outputs, %d permuted inputs and %d random scalars", len(inputs), len(outputs), len(permuted), len(randomness))
+	}
+	for i := range outputs {
+		r := Rerandomize(curve, publicKey, permuted[i], randomness[i])
+		api.AssertIsEqual(outputs[i].C1.X, r.C1.X)
+		api.AssertIsEqual(outputs[i].C1.Y, r.C1.Y)
+		api.AssertIsEqual(outputs[i].C2.X, r.C2.X)
+		api.AssertIsEqual(outputs[i].C2.Y, r.C2.Y)
+	}
+	a := make([][]frontend.Variable, len(inputs))
+	b := make([][]frontend.Variable, len(permuted))
+	for i := range inputs {
+		a[i] = []frontend.Variable{inputs[i].C1.X, inputs[i].C1.Y, inputs[i].C2.X, inputs[i].C2.Y}
+		b[i] = []frontend.Variable{permuted[i].C1.X, permuted[i].C1.Y, permuted[i].C2.X, permuted[i].C2.Y}
+	}
+	return AssertIsPermutation(api, a, b)
+}
+
+// AssertIsPermutation asserts that the rows of b are a permutation of the rows
+// of a. All the rows must have the same length.
+func AssertIsPermutation(api frontend.API, a, b [][]frontend.Variable) error {
+	return logderivarg.BuildMultisetEquality(api, a, b)
+}
@@ -0,0 +1,127 @@
+package shuffle
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	edbn254 "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/test"
+)
+
+const nbCiphertexts = 3
+
+type shuffleCircuit struct {
+	PublicKey  twistededwards.Point      `gnark:",public"`
+	Inputs     [nbCiphertexts]Ciphertext `gnark:",public"`
+	Outputs    [nbCiphertexts]Ciphertext `gnark:",public"`
+	Permuted   [nbCiphertexts]Ciphertext
+	Randomness [nbCiphertexts]frontend.Variable
+}
+
+func (c *shuffleCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+	return AssertShuffle(api, curve, c.PublicKey, c.Inputs[:], c.Outputs[:], c.Permuted[:], c.Randomness[:])
+}
+
+type nativeCiphertext struct {
+	c1, c2 edbn254.PointAffine
+}
+
+func (c nativeCiphertext) assign() Ciphertext {
+	return Ciphertext{
+		C1: twistededwards.Point{X: c.c1.X, Y: c.c1.Y},
+		C2: twistededwards.Point{X: c.c2.X, Y: c.c2.Y},
+	}
+}
+
+func encrypt(pk edbn254.PointAffine, m, k int64) nativeCiphertext {
+	base := edbn254.GetEdwardsCurve().Base
+	var c nativeCiphertext
+	var msg, kpk edbn254.PointAffine
+	msg.ScalarMultiplication(&base, big.NewInt(m))
+	kpk.ScalarMultiplication(&pk, big.NewInt(k))
+	c.c1.ScalarMultiplication(&base, big.NewInt(k))
+	c.c2.Add(&msg, &kpk)
+	return c
+}
+
+func rerandomize(pk edbn254.PointAffine, c nativeCiphertext, r int64) nativeCiphertext {
+	zero := encrypt(pk, 0, r)
+	c.c1.Add(&c.c1, &zero.c1)
+	c.c2.Add(&c.c2, &zero.c2)
+	return c
+}
+
+func TestShuffle(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	base := edbn254.GetEdwardsCurve().Base
+	var pk edbn254.PointAffine
+	pk.ScalarMultiplication(&base, big.NewInt(123456789))
+
+	var inputs [nbCiphertexts]nativeCiphertext
+	for i := range inputs {
+		inputs[i] = encrypt(pk, int64(i+1), int64(1000+i))
+	}
+
+	assignment := func(perm [nbCiphertexts]int, randomness [nbCiphertexts]int64) *shuffleCircuit {
+		var a shuffleCircuit
+		a.PublicKey = twistededwards.Point{X: pk.X, Y: pk.Y}
+		for i := range inputs {
+			a.Inputs[i] = inputs[i].assign()
+			a.Permuted[i] = inputs[perm[i]].assign()
+			a.Outputs[i] = rerandomize(pk, inputs[perm[i]], randomness[i]).assign()
+			a.Randomness[i] = randomness[i]
+		}
+		return &a
+	}
+	randomness := [nbCiphertexts]int64{11, 22, 33}
+
+	// the randomness doesn't match the outputs
+	wrongRandomness := assignment([nbCiphertexts]int{2, 0, 1}, randomness)
+	wrongRandomness.Randomness[1] = 23
+	// the outputs are not consistent with the permuted inputs
+	inconsistent := assignment([nbCiphertexts]int{2, 0, 1}, randomness)
+	inconsistent.Permuted[0] = inputs[1].assign()
+
+	assert.CheckCircuit(&shuffleCircuit{},
+		test.WithValidAssignment(assignment([nbCiphertexts]int{2, 0, 1}, randomness)),
+		test.WithValidAssignment(assignment([nbCiphertexts]int{0, 1, 2}, randomness)),
+		// the first input is duplicated and the second one dropped
+		test.WithInvalidAssignment(assignment([nbCiphertexts]int{0, 0, 2}, randomness)),
+		test.WithInvalidAssignment(wrongRandomness),
+		test.WithInvalidAssignment(inconsistent),
+		test.WithCurves(ecc.BN254))
+}
+
+type permutationCircuit struct {
+	A, B [4][2]frontend.Variable
+}
+
+func (c *permutationCircuit) Define(api frontend.API) error {
+	a := make([][]frontend.Variable, len(c.A))
+	b := make([][]frontend.Variable, len(c.B))
+	for i := range c.A {
+		a[i] = c.A[i][:]
+		b[i] = c.B[i][:]
+	}
+	return AssertIsPermutation(api, a, b)
+}
+
+func TestAssertIsPermutation(t *testing.T) {
+	assert := test.NewAssert(t)
+	rows := [4][2]frontend.Variable{{1, 2}, {3, 4}, {1, 2}, {5, 6}}
+	assert.CheckCircuit(&permutationCircuit{},
+		test.WithValidAssignment(&permutationCircuit{A: rows, B: [4][2]frontend.Variable{{5, 6}, {1, 2}, {3, 4}, {1, 2}}}),
+		test.WithInvalidAssignment(&permutationCircuit{A: rows, B: [4][2]frontend.Variable{{5, 6}, {1, 2}, {3, 4}, {3, 4}}}),
+		// the columns are swapped
+		test.WithInvalidAssignment(&permutationCircuit{A: rows, B: [4][2]frontend.Variable{{6, 5}, {1, 2}, {3, 4}, {1, 2}}}),
+		test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,162 @@
+// Package ssz implements the SSZ hash tree root of the Ethereum consensus
+// specification in circuit, and the verification of Merkle branches for
+// generalized indices.
+//
+// Chunks are 32 bytes long. Basic values are packed into chunks with [Pack],
+// the chunks are merkleized with [Merkleize] and the length of lists is mixed
+// in with [MixInLength]. [VerifyBranch] checks a proof of a node of a tree
+// against its root, as is_valid_merkle_branch of the specification.
+package ssz
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// ChunkSize is the size in bytes of the chunks and of the roots.
+const ChunkSize = 32
+
+// maxDepth is the depth of the deepest tree supported.
+const maxDepth = 64
+
+// zeroHashes[i] is the root of the tree of depth i with zero chunks.
+var zeroHashes [maxDepth + 1][ChunkSize]byte
+
+func init() {
+	for i := 1; i < len(zeroHashes); i++ {
+		zeroHashes[i] = sha256.Sum256(append(zeroHashes[i-1][:], zeroHashes[i-1][:]...))
+	}
+}
+
+// Pack splits the serialized basic values into chunks, and right-pads the last
+// chunk with zero bytes.
+func Pack(data []uints.U8) [][]uints.U8 {
+	chunks := make([][]uints.U8, (len(data)+ChunkSize-1)/ChunkSize)
+	for i := range chunks {
+		chunks[i] = make([]uints.U8, ChunkSize)
+		n := copy(chunks[i], data[i*ChunkSize:])
+		for j := n; j < ChunkSize; j++ {
+			chunks[i][j] = uints.NewU8(0)
+		}
+	}
+	return chunks
+}
+
+// Merkleize returns the root of the binary Merkle tree of the chunks, padded
+// with zero chunks up to limit chunks rounded to the next power of two. If
+// limit is zero, the chunks are padded to the next power of two.
+func Merkleize(api frontend.API, chunks [][]uints.U8, limit int) ([]uints.U8, error) {
+	if limit == 0 {
+		limit = len(chunks)
+	}
+	if len(chunks) > limit {
+		return nil, fmt.Errorf("%d chunks exceed the limit %d", len(chunks), limit)
+	}
+	depth := 0
+	if limit > 1 {
+		depth = bits.Len(uint(limit - 1))
+	}
+	if depth > maxDepth {
+		return nil, fmt.Errorf("tree of depth %d is too deep", depth)
+	}
+	for i := range chunks {
+		if len(chunks[i]) != ChunkSize {
+			return nil, fmt.Errorf("chunk %d is %d bytes long", i, len(chunks[i]))
+		}
+	}
+	if len(chunks) == 0 {
+		return uints.NewU8Array(zeroHashes[depth][:]), nil
+	}
+
+	// hash the layers, completing the odd nodes with the zero hash of the
+	// layer.
+	layer := chunks
+	for d := 0; d < depth; d++ {
+		next := make([][]uints.U8, (len(layer)+1)/2)
+		for i := range next {
+			right := uints.NewU8Array(zeroHashes[d][:])
+			if 2*i+1 < len(layer) {
+				right = layer[2*i+1]
+			}
+			node, err := hashPair(api, layer[2*i], right)
+			if err != nil {
+				return nil, err
+			}
+			next[i] = node
+		}
+		layer = next
+	}
+	return layer[0], nil
+}
+
+// MixInLength returns SHA-256(root || length), with the length encoded as a
+// little-endian 256-bit integer. The length is range checked to 64 bits.
+func MixInLength(api frontend.API, root []uints.U8, length frontend.Variable) ([]uints.U8, error) {
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return nil, err
+	}
+	chunk := make([]uints.U8, 0, ChunkSize)
+	chunk = append(chunk, uapi.UnpackLSB(uapi.ValueOf(length))...)
+	for len(chunk) < ChunkSize {
+		chunk = append(chunk, uints.NewU8(0))
+	}
+	return hashPair(api, root, chunk)
+}
+
+// VerifyBranch asserts that leaf is the node at the generalized index gindex
+// of the tree of the given root. The branch holds the siblings of the path,
+// from the leaf up to the root, and its length is the depth of gindex.
+func VerifyBranch(api frontend.API, leaf []uints.U8, branch [][]uints.U8, gindex uint64, root []uints.U8) error {
+	if gindex == 0 {
+		return fmt.Errorf("invalid generalized index 0")
+	}
+	depth := bits.Len64(gindex) - 1
+	if len(branch) != depth {
+		return fmt.Errorf("branch of length %d for a generalized index of depth %d", len(branch), depth)
+	}
+	node := leaf
+	var err error
+	for i := 0; i < depth; i++ {
+		if gindex>>i&1 == 1 {
+			node, err = hashPair(api, branch[i], node)
+		} else {
+			node, err = hashPair(api, node, branch[i])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return AssertRootsEqual(api, node, root)
+}
+
+// AssertRootsEqual asserts that the roots a and b are equal.
+func AssertRootsEqual(api frontend.API, a, b []uints.U8) error {
+	if len(a) != ChunkSize || len(b) != ChunkSize {
+		return fmt.Errorf("roots must be %d bytes long", ChunkSize)
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	for i := range a {
+		uapi.ByteAssertEq(a[i], b[i])
+	}
+	return nil
+}
+
+// hashPair returns SHA-256(left || right).
+func hashPair(api frontend.API, left, right []uints.U8) ([]uints.U8, error) {
+	h, err := sha2.New(api)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(), nil
+}
@@ -0,0 +1,86 @@
+package ssz
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+func nativeHash(left, right []byte) []byte {
+	h := sha256.Sum256(append(append([]byte{}, left...), right...))
+	return h[:]
+}
+
+// listCircuit checks the hash tree root of a list of uint64 values of limit
+// 16 (4 chunks), and the branch of its second chunk.
+type listCircuit struct {
+	Data   [5 * 8]uints.U8
+	Length frontend.Variable
+	Branch [3][ChunkSize]uints.U8
+	Root   [ChunkSize]uints.U8
+}
+
+func (c *listCircuit) Define(api frontend.API) error {
+	chunks := Pack(c.Data[:])
+	dataRoot, err := Merkleize(api, chunks, 4)
+	if err != nil {
+		return err
+	}
+	root, err := MixInLength(api, dataRoot, c.Length)
+	if err != nil {
+		return err
+	}
+	if err = AssertRootsEqual(api, root, c.Root[:]); err != nil {
+		return err
+	}
+
+	// the chunks of the list are at the generalized indices 8 + i.
+	branch := make([][]uints.U8, len(c.Branch))
+	for i := range c.Branch {
+		branch[i] = c.Branch[i][:]
+	}
+	return VerifyBranch(api, chunks[1], branch, 9, c.Root[:])
+}
+
+func TestList(t *testing.T) {
+	data := make([]byte, 5*8)
+	for i := 0; i < 5; i++ {
+		binary.LittleEndian.PutUint64(data[8*i:], uint64(1000+i))
+	}
+	var chunks [2][ChunkSize]byte
+	copy(chunks[0][:], data)
+	copy(chunks[1][:], data[ChunkSize:])
+	var length [ChunkSize]byte
+	binary.LittleEndian.PutUint64(length[:], 5)
+	dataRoot := nativeHash(nativeHash(chunks[0][:], chunks[1][:]), zeroHashes[1][:])
+	root := nativeHash(dataRoot, length[:])
+
+	var witness listCircuit
+	copy(witness.Data[:], uints.NewU8Array(data))
+	witness.Length = 5
+	copy(witness.Branch[0][:], uints.NewU8Array(chunks[0][:]))
+	copy(witness.Branch[1][:], uints.NewU8Array(zeroHashes[1][:]))
+	copy(witness.Branch[2][:], uints.NewU8Array(length[:]))
+	copy(witness.Root[:], uints.NewU8Array(root))
+	if err := test.IsSolved(&listCircuit{}, &witness, ecc.BN254.ScalarField()); err != nil {
+		t.Fatal(err)
+	}
+
+	// wrong length
+	witness.Length = 4
+	if err := test.IsSolved(&listCircuit{}, &witness, ecc.BN254.ScalarField()); err == nil {
+		t.Fatal("expected error for the wrong length")
+	}
+}
+
+func TestZeroHashes(t *testing.T) {
+	var zero [2 * ChunkSize]byte
+	if h := sha256.Sum256(zero[:]); h != zeroHashes[1] {
+		t.Fatal("unexpected zero hash")
+	}
+}
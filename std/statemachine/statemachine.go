@@ -0,0 +1,145 @@
+// Package statemachine builds circuits proving sequences of state
+// transitions.
+//
+// The user supplies the gadget of a single step as a [Transition], and
+// [NewCircuit] returns a circuit proving a fixed number of steps from the state
+// committed to by the public InitialCommitment to the state committed to by the
+// public FinalCommitment. The commitment to a state is the hash of a random
+// blinding element followed by the state, so that the commitments don't reveal
+// low-entropy states, which could otherwise be found by hashing candidates. The
+// states and their blinding elements stay private.
+//
+// Long runs are split into segments proven separately: the proofs of
+// consecutive segments chain when the final commitment of one is the initial
+// commitment of the next, that is when the final state and blinding element of
+// one are the initial ones of the next. The proofs can be checked one by one,
+// or folded into a single proof by an outer circuit verifying them with
+// std/recursion and asserting that their commitments chain.
+package statemachine
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// Transition is the gadget of a single step of the state machine.
+type Transition interface {
+	// Step returns the state following state when applying input. The
+	// returned state has the same length as state.
+	Step(api frontend.API, state, input []frontend.Variable) ([]frontend.Variable, error)
+}
+
+// Circuit proves len(Inputs) transitions from the state InitialState, which
+// commits to InitialCommitment with InitialBlinding, to a state which commits
+// to FinalCommitment with FinalBlinding.
+//
+// It must be created with [NewCircuit] for compilation, and with
+// [NewAssignment] for the witness.
+type Circuit struct {
+	InitialCommitment frontend.Variable `gnark:",public"`
+	FinalCommitment   frontend.Variable `gnark:",public"`
+
+	InitialBlinding frontend.Variable
+	FinalBlinding   frontend.Variable
+	InitialState    []frontend.Variable
+	Inputs          [][]frontend.Variable
+
+	transition Transition
+	hasher     frontend.PublicInputsHasher
+}
+
+// NewCircuit returns the circuit proving nbSteps transitions of t, on states of
+// stateSize variables and inputs of inputSize variables. The states are
+// committed to with hasher, for instance mimc.PublicInputsHasher.
+func NewCircuit(t Transition, hasher frontend.PublicInputsHasher, nbSteps, stateSize, inputSize int) *Circuit {
+	c := &Circuit{
+		InitialState: make([]frontend.Variable, stateSize),
+		Inputs:       make([][]frontend.Variable, nbSteps),
+		transition:   t,
+		hasher:       hasher,
+	}
+	for i := range c.Inputs {
+		c.Inputs[i] = make([]frontend.Variable, inputSize)
+	}
+	return c
+}
+
+// Define declares the constraints of the transitions and of the commitments.
+func (c *Circuit) Define(api frontend.API) error {
+	if c.transition == nil || c.hasher == nil {
+		return errors.New("the circuit must be created with NewCircuit")
+	}
+	initial, err := c.hasher.HashVariables(api, append([]frontend.Variable{c.InitialBlinding}, c.InitialState...))
+	if err != nil {
+		return fmt.Errorf("initial commitment: %w", err)
+	}
+	api.AssertIsEqual(c.InitialCommitment, initial)
+
+	state := c.InitialState
+	for i := range c.Inputs {
+		next, err := c.transition.Step(api, state, c.Inputs[i])
+		if err != nil {
+			return fmt.Errorf("step %d: %w", i, err)
+		}
+		if len(next) != len(state) {
+			return fmt.Errorf("step %d: state of size %d, expected %d", i, len(next), len(state))
+		}
+		state = next
+	}
+
+	final, err := c.hasher.HashVariables(api, append([]frontend.Variable{c.FinalBlinding}, state...))
+	if err != nil {
+		return fmt.Errorf("final commitment: %w", err)
+	}
+	api.AssertIsEqual(c.FinalCommitment, final)
+	return nil
+}
+
+// NewBlinding returns a random blinding element of the field, for the
+// commitment to a state.
+func NewBlinding(field *big.Int) (*big.Int, error) {
+	return rand.Int(rand.Reader, field)
+}
+
+// Commitment returns the commitment to the state with the blinding element, in
+// the field, as computed in the circuit.
+func Commitment(hasher frontend.PublicInputsHasher, field *big.Int, blinding *big.Int, state []*big.Int) (*big.Int, error) {
+	return hasher.HashValues(field, append([]*big.Int{blinding}, state...))
+}
+
+// NewAssignment returns the assignment of the circuit for the run from
+// initialState to finalState with the inputs, the states being committed to
+// with the blinding elements. The final state must be the one computed by the
+// transitions, natively.
+func NewAssignment(hasher frontend.PublicInputsHasher, field *big.Int, initialBlinding, finalBlinding *big.Int, initialState, finalState []*big.Int, inputs [][]*big.Int) (*Circuit, error) {
+	initial, err := Commitment(hasher, field, initialBlinding, initialState)
+	if err != nil {
+		return nil, fmt.Errorf("initial commitment: %w", err)
+	}
+	final, err := Commitment(hasher, field, finalBlinding, finalState)
+	if err != nil {
+		return nil, fmt.Errorf("final commitment: %w", err)
+	}
+	c := &Circuit{
+		InitialCommitment: initial,
+		FinalCommitment:   final,
+		InitialBlinding:   initialBlinding,
+		FinalBlinding:     finalBlinding,
+		InitialState:      make([]frontend.Variable, len(initialState)),
+		Inputs:            make([][]frontend.Variable, len(inputs)),
+	}
+	for i := range initialState {
+		c.InitialState[i] = initialState[i]
+	}
+	for i := range inputs {
+		c.Inputs[i] = make([]frontend.Variable, len(inputs[i]))
+		for j := range inputs[i] {
+			c.Inputs[i][j] = inputs[i][j]
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,70 @@
+package statemachine
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+// fibonacci maps the state (a, b) to (b, a+b+x) for the input x.
+type fibonacci struct{}
+
+func (fibonacci) Step(api frontend.API, state, input []frontend.Variable) ([]frontend.Variable, error) {
+	return []frontend.Variable{state[1], api.Add(state[0], state[1], input[0])}, nil
+}
+
+func nativeFibonacci(state []*big.Int, inputs [][]*big.Int) []*big.Int {
+	a, b := new(big.Int).Set(state[0]), new(big.Int).Set(state[1])
+	for i := range inputs {
+		a, b = b, new(big.Int).Add(a, b)
+		b.Add(b, inputs[i][0])
+	}
+	return []*big.Int{a, b}
+}
+
+func TestStateMachine(t *testing.T) {
+	const nbSteps = 4
+	assert := test.NewAssert(t)
+	field := ecc.BN254.ScalarField()
+	hasher := mimc.PublicInputsHasher{}
+
+	initial := []*big.Int{big.NewInt(0), big.NewInt(1)}
+	inputs := make([][]*big.Int, nbSteps)
+	for i := range inputs {
+		inputs[i] = []*big.Int{big.NewInt(int64(i))}
+	}
+	final := nativeFibonacci(initial, inputs)
+
+	initialBlinding, err := NewBlinding(field)
+	assert.NoError(err)
+	finalBlinding, err := NewBlinding(field)
+	assert.NoError(err)
+
+	valid, err := NewAssignment(hasher, field, initialBlinding, finalBlinding, initial, final, inputs)
+	assert.NoError(err)
+	wrongFinal, err := NewAssignment(hasher, field, initialBlinding, finalBlinding, initial, []*big.Int{final[0], big.NewInt(0)}, inputs)
+	assert.NoError(err)
+	wrongInitial, err := NewAssignment(hasher, field, initialBlinding, finalBlinding, []*big.Int{big.NewInt(1), big.NewInt(1)}, final, inputs)
+	assert.NoError(err)
+	wrongInitial.InitialState = valid.InitialState
+	wrongBlinding := *valid
+	wrongBlinding.FinalBlinding = initialBlinding
+
+	// the commitments to the same state differ
+	c1, err := Commitment(hasher, field, initialBlinding, final)
+	assert.NoError(err)
+	c2, err := Commitment(hasher, field, finalBlinding, final)
+	assert.NoError(err)
+	assert.NotEqual(c1, c2)
+
+	assert.CheckCircuit(NewCircuit(fibonacci{}, hasher, nbSteps, 2, 1),
+		test.WithValidAssignment(valid),
+		test.WithInvalidAssignment(wrongFinal),
+		test.WithInvalidAssignment(wrongInitial),
+		test.WithInvalidAssignment(&wrongBlinding),
+		test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,82 @@
+// Package tendermint provides components to verify Tendermint (CometBFT)
+// light client updates in circuit, for instance in zk-IBC bridges.
+//
+// The headers and the validator sets of Tendermint are committed to with the
+// RFC 6962 SHA-256 Merkle tree implemented by [MerkleRoot]. The leaves are the
+// protobuf encodings of the header fields or of the validators, which are
+// given to the circuit as bytes. [ValidatorPower] decodes the voting powers
+// from the validator leaves, and [AssertVotingPowerThreshold] checks that the
+// validators who signed a commit hold enough of the voting power.
+//
+// The commit signatures are Ed25519 signatures over the canonical vote
+// encoding. Their verification needs the emulated Edwards25519 curve and
+// SHA-512, which are not in std yet.
+package tendermint
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// prefixes of the leaf and inner node hashes, for domain separation.
+const (
+	leafPrefix  = 0
+	innerPrefix = 1
+)
+
+// LeafHash returns SHA-256(0x00 || leaf).
+func LeafHash(api frontend.API, leaf []uints.U8) ([]uints.U8, error) {
+	return sum(api, leafPrefix, leaf)
+}
+
+// InnerHash returns SHA-256(0x01 || left || right).
+func InnerHash(api frontend.API, left, right []uints.U8) ([]uints.U8, error) {
+	return sum(api, innerPrefix, left, right)
+}
+
+// MerkleRoot returns the root of the RFC 6962 Merkle tree of the leaves, as
+// computed by Tendermint for the header and validator set hashes. The tree of
+// n > 1 leaves splits them at the largest power of two smaller than n. The root
+// of no leaves is SHA-256 of the empty string.
+func MerkleRoot(api frontend.API, leaves [][]uints.U8) ([]uints.U8, error) {
+	switch len(leaves) {
+	case 0:
+		h, err := sha2.New(api)
+		if err != nil {
+			return nil, err
+		}
+		return h.Sum(), nil
+	case 1:
+		return LeafHash(api, leaves[0])
+	}
+	k := splitPoint(len(leaves))
+	left, err := MerkleRoot(api, leaves[:k])
+	if err != nil {
+		return nil, err
+	}
+	right, err := MerkleRoot(api, leaves[k:])
+	if err != nil {
+		return nil, err
+	}
+	return InnerHash(api, left, right)
+}
+
+// splitPoint returns the largest power of two smaller than n > 1.
+func splitPoint(n int) int {
+	return 1 << (bits.Len(uint(n-1)) - 1)
+}
+
+func sum(api frontend.API, prefix uint8, data ...[]uints.U8) ([]uints.U8, error) {
+	h, err := sha2.New(api)
+	if err != nil {
+		return nil, err
+	}
+	h.Write([]uints.U8{uints.NewU8(prefix)})
+	for i := range data {
+		h.Write(data[i])
+	}
+	return h.Sum(), nil
+}
@@ -0,0 +1,194 @@
+package tendermint
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+// nativeRoot computes the RFC 6962 Merkle root of the leaves.
+func nativeRoot(leaves [][]byte) []byte {
+	switch len(leaves) {
+	case 0:
+		h := sha256.Sum256(nil)
+		return h[:]
+	case 1:
+		h := sha256.Sum256(append([]byte{leafPrefix}, leaves[0]...))
+		return h[:]
+	}
+	k := splitPoint(len(leaves))
+	data := append([]byte{innerPrefix}, nativeRoot(leaves[:k])...)
+	h := sha256.Sum256(append(data, nativeRoot(leaves[k:])...))
+	return h[:]
+}
+
+type merkleCircuit struct {
+	Leaves [][]uints.U8
+	Root   [32]uints.U8
+}
+
+func (c *merkleCircuit) Define(api frontend.API) error {
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	root, err := MerkleRoot(api, c.Leaves)
+	if err != nil {
+		return err
+	}
+	for i := range c.Root {
+		uapi.ByteAssertEq(c.Root[i], root[i])
+	}
+	return nil
+}
+
+func TestMerkleRoot(t *testing.T) {
+	leaves := [][]byte{[]byte("validator 0"), []byte("validator 1"), []byte("validator 2")}
+	circuit := merkleCircuit{Leaves: make([][]uints.U8, len(leaves))}
+	witness := merkleCircuit{Leaves: make([][]uints.U8, len(leaves))}
+	for i := range leaves {
+		circuit.Leaves[i] = make([]uints.U8, len(leaves[i]))
+		witness.Leaves[i] = uints.NewU8Array(leaves[i])
+	}
+	copy(witness.Root[:], uints.NewU8Array(nativeRoot(leaves)))
+	if err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSplitPoint(t *testing.T) {
+	for n, k := range map[int]int{2: 1, 3: 2, 4: 2, 5: 4, 8: 4, 9: 8} {
+		if got := splitPoint(n); got != k {
+			t.Fatalf("split point of %d: expected %d, got %d", n, k, got)
+		}
+	}
+}
+
+type votingCircuit struct {
+	Powers [4]frontend.Variable
+	Signed [4]frontend.Variable
+}
+
+func (c *votingCircuit) Define(api frontend.API) error {
+	AssertVotingPowerThreshold(api, c.Powers[:], c.Signed[:], 2, 3, 60)
+	return nil
+}
+
+func TestVotingPowerThreshold(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := func(signed ...frontend.Variable) *votingCircuit {
+		var w votingCircuit
+		w.Powers = [4]frontend.Variable{10, 20, 30, 40}
+		copy(w.Signed[:], signed)
+		return &w
+	}
+	assert.CheckCircuit(&votingCircuit{},
+		test.WithValidAssignment(assignment(1, 0, 1, 1)),
+		test.WithValidAssignment(assignment(0, 1, 1, 1)),
+		test.WithValidAssignment(assignment(0, 0, 1, 1)),
+		test.WithInvalidAssignment(assignment(1, 1, 1, 0)),
+		test.WithInvalidAssignment(assignment(1, 0, 0, 1)),
+		test.WithInvalidAssignment(assignment(0, 0, 0, 2)),
+		test.WithCurves(ecc.BN254))
+}
+
+// validatorLeaf returns the SimpleValidator protobuf encoding of a validator
+// with an Ed25519 key.
+func validatorLeaf(key [32]byte, power uint64) []byte {
+	leaf := append([]byte{0x0a, 0x22, 0x0a, 0x20}, key[:]...)
+	leaf = append(leaf, 0x10)
+	return binary.AppendUvarint(leaf, power)
+}
+
+type validatorSetCircuit struct {
+	Leaves [][]uints.U8
+	Signed []frontend.Variable
+	Root   [32]uints.U8
+}
+
+func (c *validatorSetCircuit) Define(api frontend.API) error {
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	root, err := MerkleRoot(api, c.Leaves)
+	if err != nil {
+		return err
+	}
+	for i := range c.Root {
+		uapi.ByteAssertEq(c.Root[i], root[i])
+	}
+	powers := make([]frontend.Variable, len(c.Leaves))
+	for i := range c.Leaves {
+		if powers[i], err = ValidatorPower(api, c.Leaves[i]); err != nil {
+			return err
+		}
+	}
+	AssertVotingPowerThreshold(api, powers, c.Signed, 2, 3, 60)
+	return nil
+}
+
+func TestValidatorSetThreshold(t *testing.T) {
+	assert := test.NewAssert(t)
+	powers := []uint64{10, 300, 1 << 40}
+	leaves := make([][]byte, len(powers))
+	for i := range powers {
+		leaves[i] = validatorLeaf([32]byte{byte(i)}, powers[i])
+	}
+	root := nativeRoot(leaves)
+
+	circuit := validatorSetCircuit{Leaves: make([][]uints.U8, len(leaves)), Signed: make([]frontend.Variable, len(leaves))}
+	assignment := func(leaves [][]byte, signed ...frontend.Variable) *validatorSetCircuit {
+		w := validatorSetCircuit{Leaves: make([][]uints.U8, len(leaves)), Signed: signed}
+		for i := range leaves {
+			w.Leaves[i] = uints.NewU8Array(leaves[i])
+		}
+		copy(w.Root[:], uints.NewU8Array(root))
+		return &w
+	}
+	for i := range leaves {
+		circuit.Leaves[i] = make([]uints.U8, len(leaves[i]))
+	}
+
+	assert.NoError(test.IsSolved(&circuit, assignment(leaves, 0, 0, 1), ecc.BN254.ScalarField()))
+	assert.Error(test.IsSolved(&circuit, assignment(leaves, 1, 1, 0), ecc.BN254.ScalarField()))
+
+	// a power different from the validator set doesn't match the root
+	tampered := append([][]byte{}, leaves...)
+	tampered[1] = validatorLeaf([32]byte{1}, 1<<7+127)
+	assert.Error(test.IsSolved(&circuit, assignment(tampered, 0, 1, 0), ecc.BN254.ScalarField()))
+}
+
+func TestValidatorPower(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, power := range []uint64{1, 127, 128, 300, 1<<60 - 1} {
+		leaf := validatorLeaf([32]byte{1, 2, 3}, power)
+		circuit := validatorPowerCircuit{Leaf: make([]uints.U8, len(leaf))}
+		assert.NoError(test.IsSolved(&circuit, &validatorPowerCircuit{Leaf: uints.NewU8Array(leaf), Power: power}, ecc.BN254.ScalarField()))
+		assert.Error(test.IsSolved(&circuit, &validatorPowerCircuit{Leaf: uints.NewU8Array(leaf), Power: power + 1}, ecc.BN254.ScalarField()))
+	}
+	// the last byte of the varint has its continuation bit set
+	leaf := validatorLeaf([32]byte{}, 300)
+	leaf[len(leaf)-1] |= 0x80
+	circuit := validatorPowerCircuit{Leaf: make([]uints.U8, len(leaf))}
+	assert.Error(test.IsSolved(&circuit, &validatorPowerCircuit{Leaf: uints.NewU8Array(leaf), Power: 300}, ecc.BN254.ScalarField()))
+}
+
+type validatorPowerCircuit struct {
+	Leaf  []uints.U8
+	Power frontend.Variable
+}
+
+func (c *validatorPowerCircuit) Define(api frontend.API) error {
+	power, err := ValidatorPower(api, c.Leaf)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(power, c.Power)
+	return nil
+}
@@ -0,0 +1,98 @@
+package tendermint
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// ed25519ValidatorPrefix is the start of the protobuf encoding of a
+// SimpleValidator: the field pub_key (1) of length 34, holding the field
+// ed25519 (1) of length 32. It is followed by the 32 bytes of the key, the tag
+// of the field voting_power (2) and the varint of the power.
+var ed25519ValidatorPrefix = [...]uint8{0x0a, 0x22, 0x0a, 0x20}
+
+const (
+	ed25519KeySize   = 32
+	votingPowerTag   = 0x10
+	votingPowerStart = len(ed25519ValidatorPrefix) + ed25519KeySize + 1
+)
+
+// ValidatorPower returns the voting power of the validator encoded in leaf, a
+// leaf of the validator set tree given to [MerkleRoot]. The leaf must be the
+// SimpleValidator protobuf encoding of a validator with an Ed25519 key, whose
+// voting power is a varint filling the rest of the leaf. As the length of the
+// leaf is fixed by the circuit, so is the number of bytes of the varint: a
+// validator set must be given in a circuit compiled for the lengths of its
+// leaves.
+//
+// The powers passed to [AssertVotingPowerThreshold] must be bound to the
+// validator set hash this way, otherwise the prover chooses them freely.
+func ValidatorPower(api frontend.API, leaf []uints.U8) (frontend.Variable, error) {
+	nbPowerBytes := len(leaf) - votingPowerStart
+	if nbPowerBytes < 1 || 7*(nbPowerBytes-1) >= 63 {
+		return nil, fmt.Errorf("leaf of %d bytes is not an Ed25519 validator", len(leaf))
+	}
+	for i := range ed25519ValidatorPrefix {
+		api.AssertIsEqual(leaf[i].Val, ed25519ValidatorPrefix[i])
+	}
+	api.AssertIsEqual(leaf[votingPowerStart-1].Val, votingPowerTag)
+
+	// every byte of the varint but the last has its most significant bit set.
+	power := frontend.Variable(0)
+	for i := len(leaf) - 1; i >= votingPowerStart; i-- {
+		b := api.ToBinary(leaf[i].Val, 8)
+		if i == len(leaf)-1 {
+			api.AssertIsEqual(b[7], 0)
+		} else {
+			api.AssertIsEqual(b[7], 1)
+		}
+		power = api.Add(api.Mul(power, 1<<7), api.FromBinary(b[:7]...))
+	}
+	return power, nil
+}
+
+// AssertVotingPowerThreshold asserts that the validators who signed hold
+// strictly more than numerator/denominator of the total voting power:
+//
+//	denominator·∑ signed[i]·powers[i] > numerator·∑ powers[i]
+//
+// A commit is valid for Tendermint with the threshold 2/3, and a light client
+// skipping blocks trusts a validator set overlapping by 1/3.
+//
+// The signed flags are constrained to be booleans, and the voting powers are
+// range checked to nbBits bits (Tendermint bounds the total voting power to
+// 60 bits). The powers are not bound to the validator set: the caller must
+// decode them from the leaves of the validator set tree with [ValidatorPower].
+// Neither are the signed flags bound to the commit signatures, which can't be
+// verified in circuit yet (see the package documentation): the caller must
+// check the signatures of the flagged validators outside of the circuit.
+func AssertVotingPowerThreshold(api frontend.API, powers, signed []frontend.Variable, numerator, denominator uint64, nbBits int) {
+	if len(powers) != len(signed) {
+		panic("number of voting powers and of signed flags differ")
+	}
+	if denominator == 0 || numerator >= denominator {
+		panic("threshold must be in [0, 1)")
+	}
+	// bound of denominator·signed and numerator·total
+	nbBound := nbBits + bits.Len(uint(len(powers))) + bits.Len64(denominator)
+	if nbBound >= api.Compiler().FieldBitLen()-1 {
+		panic(fmt.Sprintf("voting powers of %d bits are too large for the field", nbBits))
+	}
+
+	rc := rangecheck.New(api)
+	total, signedPower := frontend.Variable(0), frontend.Variable(0)
+	for i := range powers {
+		api.AssertIsBoolean(signed[i])
+		rc.Check(powers[i], nbBits)
+		total = api.Add(total, powers[i])
+		signedPower = api.Add(signedPower, api.Mul(signed[i], powers[i]))
+	}
+
+	// the difference wraps around the field if the threshold isn't reached.
+	diff := api.Sub(api.Mul(signedPower, denominator), api.Mul(total, numerator), 1)
+	rc.Check(diff, nbBound)
+}
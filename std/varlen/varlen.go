@@ -0,0 +1,117 @@
+// Package varlen provides helpers for circuits handling arrays of variable
+// length.
+//
+// The size of the circuit is fixed at compile time, so a variable length array
+// is represented by a slice of fixed maximal length and a variable actual
+// length. The elements past the actual length (the padding) are arbitrary
+// unless [Array.AssertIsPadded] is called, and all the methods of [Array] only
+// depend on the first Length elements. This avoids hand-rolling the masking
+// logic, which is error-prone: forgetting to mask the padding or to bind the
+// length allows a malicious prover to choose the padding freely.
+//
+// All methods constrain the length to be in the range [0, len(Values)], no
+// proof can be generated otherwise.
+package varlen
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/selector"
+)
+
+// Array is an array of variable length. The maximal length of the array is
+// len(Values) and is fixed at compile time, the actual length is Length.
+//
+// When used as a circuit input, Values must be allocated with the maximal
+// length when defining the circuit.
+type Array struct {
+	Values []frontend.Variable
+	Length frontend.Variable
+}
+
+// Mask returns a slice of len(a.Values) bits, where the first a.Length bits are
+// 1 and the remaining ones are 0.
+func (a Array) Mask(api frontend.API) []frontend.Variable {
+	switch len(a.Values) {
+	case 0:
+		api.AssertIsEqual(a.Length, 0)
+		return nil
+	case 1:
+		api.AssertIsBoolean(a.Length)
+		return []frontend.Variable{a.Length}
+	}
+	ones := make([]frontend.Variable, len(a.Values))
+	for i := range ones {
+		ones[i] = 1
+	}
+	return selector.Partition(api, a.Length, false, ones)
+}
+
+// Masked returns the values of the array where the padding is replaced by
+// zeros.
+func (a Array) Masked(api frontend.API) []frontend.Variable {
+	mask := a.Mask(api)
+	res := make([]frontend.Variable, len(a.Values))
+	for i := range res {
+		res[i] = api.Mul(mask[i], a.Values[i])
+	}
+	return res
+}
+
+// AssertIsPadded asserts that the elements of the array past its length are
+// zero.
+func (a Array) AssertIsPadded(api frontend.API) {
+	mask := a.Mask(api)
+	for i := range a.Values {
+		// (1 - mask[i]) * values[i] == 0
+		api.AssertIsEqual(api.Mul(api.Sub(1, mask[i]), a.Values[i]), 0)
+	}
+}
+
+// Hash writes the length of the array followed by its masked values to the
+// hasher h and returns the digest. Two arrays with the same maximal length have
+// the same digest if and only if they have the same length and the same first
+// Length elements, whatever their padding. The length prefix ensures that
+// arrays differing only by trailing zeros have different digests.
+//
+// The hasher is reset before use.
+func (a Array) Hash(api frontend.API, h hash.FieldHasher) frontend.Variable {
+	h.Reset()
+	h.Write(a.Length)
+	h.Write(a.Masked(api)...)
+	return h.Sum()
+}
+
+// IsEqual returns 1 if a and b have the same length and the same first Length
+// elements, and 0 otherwise. The arrays may have different maximal lengths.
+func (a Array) IsEqual(api frontend.API, b Array) frontend.Variable {
+	diffs := a.maskedDiff(api, b)
+	res := api.IsZero(api.Sub(a.Length, b.Length))
+	for i := range diffs {
+		res = api.And(res, api.IsZero(diffs[i]))
+	}
+	return res
+}
+
+// AssertIsEqual asserts that a and b have the same length and the same first
+// Length elements. The arrays may have different maximal lengths.
+func (a Array) AssertIsEqual(api frontend.API, b Array) {
+	api.AssertIsEqual(a.Length, b.Length)
+	diffs := a.maskedDiff(api, b)
+	for i := range diffs {
+		api.AssertIsEqual(diffs[i], 0)
+	}
+}
+
+// maskedDiff returns the differences of the values of a and b, masked with the
+// mask of a, up to the smallest maximal length. Past it the values only matter
+// if the lengths differ. The mask of b is computed to constrain its length.
+func (a Array) maskedDiff(api frontend.API, b Array) []frontend.Variable {
+	maskA := a.Mask(api)
+	b.Mask(api)
+	res := make([]frontend.Variable, min(len(a.Values), len(b.Values)))
+	for i := range res {
+		res[i] = api.Mul(maskA[i], api.Sub(a.Values[i], b.Values[i]))
+	}
+	return res
+}
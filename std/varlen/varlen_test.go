@@ -0,0 +1,93 @@
+package varlen_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/varlen"
+	"github.com/consensys/gnark/test"
+)
+
+const maxLen = 5
+
+func newArray(length int, values ...frontend.Variable) varlen.Array {
+	a := varlen.Array{Values: make([]frontend.Variable, maxLen), Length: length}
+	for i := range a.Values {
+		a.Values[i] = 0
+	}
+	copy(a.Values, values)
+	return a
+}
+
+type hashCircuit struct {
+	A        varlen.Array
+	Expected [maxLen]frontend.Variable
+}
+
+func (c *hashCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	res := c.A.Hash(api, &h)
+	// hash of the length prefixed array, padded with zeros.
+	h.Reset()
+	h.Write(c.A.Length)
+	h.Write(c.Expected[:]...)
+	api.AssertIsEqual(res, h.Sum())
+	return nil
+}
+
+func TestHash(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.CheckCircuit(&hashCircuit{A: newArray(0)},
+		test.WithValidAssignment(&hashCircuit{A: newArray(3, 1, 2, 3, 4, 5), Expected: [maxLen]frontend.Variable{1, 2, 3, 0, 0}}),
+		test.WithValidAssignment(&hashCircuit{A: newArray(0, 1, 2, 3, 4, 5), Expected: [maxLen]frontend.Variable{0, 0, 0, 0, 0}}),
+		test.WithValidAssignment(&hashCircuit{A: newArray(5, 1, 2, 3, 4, 5), Expected: [maxLen]frontend.Variable{1, 2, 3, 4, 5}}),
+		// length out of range
+		test.WithInvalidAssignment(&hashCircuit{A: newArray(6, 1, 2, 3, 4, 5), Expected: [maxLen]frontend.Variable{1, 2, 3, 4, 5}}),
+	)
+}
+
+type equalCircuit struct {
+	A, B     varlen.Array
+	Expected frontend.Variable
+}
+
+func (c *equalCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.A.IsEqual(api, c.B), c.Expected)
+	return nil
+}
+
+func TestIsEqual(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.CheckCircuit(&equalCircuit{A: newArray(0), B: newArray(0)},
+		// padding is ignored
+		test.WithValidAssignment(&equalCircuit{A: newArray(2, 1, 2, 3), B: newArray(2, 1, 2, 4), Expected: 1}),
+		test.WithValidAssignment(&equalCircuit{A: newArray(0, 1), B: newArray(0), Expected: 1}),
+		test.WithValidAssignment(&equalCircuit{A: newArray(3, 1, 2, 3), B: newArray(3, 1, 2, 4), Expected: 0}),
+		// trailing zeros are significant
+		test.WithValidAssignment(&equalCircuit{A: newArray(2, 1, 2), B: newArray(3, 1, 2), Expected: 0}),
+		test.WithInvalidAssignment(&equalCircuit{A: newArray(2, 1, 2, 3), B: newArray(2, 1, 2, 4), Expected: 0}),
+	)
+}
+
+type paddedCircuit struct {
+	A varlen.Array
+}
+
+func (c *paddedCircuit) Define(api frontend.API) error {
+	c.A.AssertIsPadded(api)
+	return nil
+}
+
+func TestAssertIsPadded(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.CheckCircuit(&paddedCircuit{A: newArray(0)},
+		test.WithValidAssignment(&paddedCircuit{A: newArray(2, 1, 2)}),
+		test.WithValidAssignment(&paddedCircuit{A: newArray(5, 1, 2, 3, 4, 5)}),
+		test.WithInvalidAssignment(&paddedCircuit{A: newArray(2, 1, 2, 3)}),
+		test.WithInvalidAssignment(&paddedCircuit{A: newArray(6, 1, 2, 3, 4, 5)}),
+	)
+}
@@ -0,0 +1,57 @@
+//go:build !verifier_only
+
+package test
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/test/unsafekzg"
+)
+
+var (
+	_groth16 = tBackend{
+		setup: func(ccs constraint.ConstraintSystem, curve ecc.ID) (
+			pk, vk any,
+			pkBuilder, vkBuilder, proofBuilder func() any,
+			err error) {
+			pk, vk, err = groth16.Setup(ccs)
+			return pk, vk, func() any { return groth16.NewProvingKey(curve) }, func() any { return groth16.NewVerifyingKey(curve) }, func() any { return groth16.NewProof(curve) }, err
+		},
+		prove: func(ccs constraint.ConstraintSystem, pk any, fullWitness witness.Witness, opts ...backend.ProverOption) (proof any, err error) {
+			return groth16.Prove(ccs, pk.(groth16.ProvingKey), fullWitness, opts...)
+		},
+		verify: func(proof, vk any, publicWitness witness.Witness, opts ...backend.VerifierOption) error {
+			return groth16.Verify(proof.(groth16.Proof), vk.(groth16.VerifyingKey), publicWitness, opts...)
+		},
+	}
+
+	_plonk = tBackend{
+		setup: func(ccs constraint.ConstraintSystem, curve ecc.ID) (
+			pk, vk any,
+			pkBuilder, vkBuilder, proofBuilder func() any,
+			err error) {
+			srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+			if err != nil {
+				return nil, nil, nil, nil, nil, err
+			}
+			pk, vk, err = plonk.Setup(ccs, srs, srsLagrange)
+			return pk, vk, func() any { return plonk.NewProvingKey(curve) }, func() any { return plonk.NewVerifyingKey(curve) }, func() any { return plonk.NewProof(curve) }, err
+		},
+		prove: func(ccs constraint.ConstraintSystem, pk any, fullWitness witness.Witness, opts ...backend.ProverOption) (proof any, err error) {
+			return plonk.Prove(ccs, pk.(plonk.ProvingKey), fullWitness, opts...)
+		},
+		verify: func(proof, vk any, publicWitness witness.Witness, opts ...backend.VerifierOption) error {
+			return plonk.Verify(proof.(plonk.Proof), vk.(plonk.VerifyingKey), publicWitness, opts...)
+		},
+	}
+)
+
+// withSolverOptions returns the prover option setting the solver options.
+func withSolverOptions(solverOpts ...solver.Option) backend.ProverOption {
+	return backend.WithSolverOptions(solverOpts...)
+}
@@ -0,0 +1,51 @@
+//go:build verifier_only
+
+package test
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/constraint/solver"
+)
+
+// errVerifierOnly is returned by the backends of the test engine when built
+// with the verifier_only tag, which excludes Setup and Prove.
+var errVerifierOnly = errors.New("setup and prove are not available in verifier_only builds")
+
+func setupVerifierOnly(constraint.ConstraintSystem, ecc.ID) (pk, vk any, pkBuilder, vkBuilder, proofBuilder func() any, err error) {
+	return nil, nil, nil, nil, nil, errVerifierOnly
+}
+
+func proveVerifierOnly(constraint.ConstraintSystem, any, witness.Witness, ...backend.ProverOption) (any, error) {
+	return nil, errVerifierOnly
+}
+
+var (
+	_groth16 = tBackend{
+		setup: setupVerifierOnly,
+		prove: proveVerifierOnly,
+		verify: func(proof, vk any, publicWitness witness.Witness, opts ...backend.VerifierOption) error {
+			return groth16.Verify(proof.(groth16.Proof), vk.(groth16.VerifyingKey), publicWitness, opts...)
+		},
+	}
+
+	_plonk = tBackend{
+		setup: setupVerifierOnly,
+		prove: proveVerifierOnly,
+		verify: func(proof, vk any, publicWitness witness.Witness, opts ...backend.VerifierOption) error {
+			return plonk.Verify(proof.(plonk.Proof), vk.(plonk.VerifyingKey), publicWitness, opts...)
+		},
+	}
+)
+
+// withSolverOptions returns a prover option doing nothing, as the prover
+// configuration has no solver options in verifier_only builds.
+func withSolverOptions(...solver.Option) backend.ProverOption {
+	return func(*backend.ProverConfig) error { return nil }
+}
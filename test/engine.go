@@ -116,8 +116,11 @@ func IsSolved(circuit, witness frontend.Circuit, field *big.Int, opts ...TestEng
 	// clone the circuit
 	c := shallowClone(circuit)
 
-	// set the witness values
-	copyWitness(c, witness)
+	// set the witness values; configuration fields (sizes, flags, constants)
+	// are not part of the witness and keep the values of the circuit
+	if err := copyWitness(c, witness); err != nil {
+		return err
+	}
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -638,7 +641,7 @@ func shallowClone(circuit frontend.Circuit) frontend.Circuit {
 	return circuitCopy
 }
 
-func copyWitness(to, from frontend.Circuit) {
+func copyWitness(to, from frontend.Circuit) error {
 	var wValues []reflect.Value
 
 	collectHandler := func(f schema.LeafInfo, tInput reflect.Value) error {
@@ -650,18 +653,22 @@ func copyWitness(to, from frontend.Circuit) {
 		return nil
 	}
 	if _, err := schema.Walk(from, tVariable, collectHandler); err != nil {
-		panic(err)
+		return err
 	}
 
 	i := 0
 	setHandler := func(f schema.LeafInfo, tInput reflect.Value) error {
+		if i >= len(wValues) {
+			return fmt.Errorf("when parsing variable %s: the witness has only %d variables", f.FullName(), len(wValues))
+		}
 		tInput.Set(wValues[i])
 		i++
 		return nil
 	}
-	// this can't error.
-	_, _ = schema.Walk(to, tVariable, setHandler)
-
+	if _, err := schema.Walk(to, tVariable, setHandler); err != nil {
+		return err
+	}
+	return nil
 }
 
 func (e *engine) Field() *big.Int {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/consensys/gnark/std/math/bits"
 )
 
@@ -101,3 +102,51 @@ func TestPreCompileHook(t *testing.T) {
 		t.Error("callback not called")
 	}
 }
+
+type configuredCircuit struct {
+	nbRounds int
+	Square   bool
+	X        frontend.Variable
+	Y        frontend.Variable `gnark:",public"`
+}
+
+func (c *configuredCircuit) Define(api frontend.API) error {
+	res := c.X
+	for i := 0; i < c.nbRounds; i++ {
+		if c.Square {
+			res = api.Mul(res, res)
+		} else {
+			res = api.Add(res, res)
+		}
+	}
+	api.AssertIsEqual(res, c.Y)
+	return nil
+}
+
+type taggedConfigurationCircuit struct {
+	NbRounds int `gnark:",secret"`
+	X        frontend.Variable
+}
+
+func (c *taggedConfigurationCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.X, c.NbRounds)
+	return nil
+}
+
+func TestConfigurationFields(t *testing.T) {
+	// the configuration is taken from the circuit, not from the assignment
+	circuit := &configuredCircuit{nbRounds: 2, Square: true}
+	if err := IsSolved(circuit, &configuredCircuit{X: 3, Y: 81}, ecc.BN254.ScalarField()); err != nil {
+		t.Fatal(err)
+	}
+	if err := IsSolved(circuit, &configuredCircuit{nbRounds: 1, X: 3, Y: 9}, ecc.BN254.ScalarField()); err == nil {
+		t.Fatal("witness shouldn't solve circuit")
+	}
+
+	if err := IsSolved(&taggedConfigurationCircuit{}, &taggedConfigurationCircuit{X: 0}, ecc.BN254.ScalarField()); err == nil {
+		t.Fatal("expected an error for the tagged configuration field")
+	}
+	if _, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &taggedConfigurationCircuit{}); err == nil {
+		t.Fatal("expected an error for the tagged configuration field")
+	}
+}
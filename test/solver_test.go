@@ -81,6 +81,10 @@ func (pw *permutterWitness) Public() (witness.Witness, error) {
 	return pw, nil
 }
 
+func (pw *permutterWitness) Secret() (witness.Witness, error) {
+	return pw, nil
+}
+
 func (pw *permutterWitness) Vector() any {
 	return pw.vector
 }
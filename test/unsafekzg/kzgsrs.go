@@ -195,7 +195,9 @@ func toLagrange(canonicalSRS kzg.SRS, tau *big.Int) kzg.SRS {
 		// since we know the randomness in test.
 		pAlpha := make([]fr_bn254.Element, size)
 		pAlpha[0].SetUint64(1)
-		pAlpha[1].SetBigInt(tau)
+		if size > 1 {
+			pAlpha[1].SetBigInt(tau)
+		}
 		for i := 2; i < len(pAlpha); i++ {
 			pAlpha[i].Mul(&pAlpha[i-1], &pAlpha[1])
 		}
@@ -217,7 +219,9 @@ func toLagrange(canonicalSRS kzg.SRS, tau *big.Int) kzg.SRS {
 		// since we know the randomness in test.
 		pAlpha := make([]fr_bls12381.Element, size)
 		pAlpha[0].SetUint64(1)
-		pAlpha[1].SetBigInt(tau)
+		if size > 1 {
+			pAlpha[1].SetBigInt(tau)
+		}
 		for i := 2; i < len(pAlpha); i++ {
 			pAlpha[i].Mul(&pAlpha[i-1], &pAlpha[1])
 		}
@@ -239,7 +243,9 @@ func toLagrange(canonicalSRS kzg.SRS, tau *big.Int) kzg.SRS {
 		// since we know the randomness in test.
 		pAlpha := make([]fr_bls12377.Element, size)
 		pAlpha[0].SetUint64(1)
-		pAlpha[1].SetBigInt(tau)
+		if size > 1 {
+			pAlpha[1].SetBigInt(tau)
+		}
 		for i := 2; i < len(pAlpha); i++ {
 			pAlpha[i].Mul(&pAlpha[i-1], &pAlpha[1])
 		}
@@ -261,7 +267,9 @@ func toLagrange(canonicalSRS kzg.SRS, tau *big.Int) kzg.SRS {
 		// since we know the randomness in test.
 		pAlpha := make([]fr_bw6761.Element, size)
 		pAlpha[0].SetUint64(1)
-		pAlpha[1].SetBigInt(tau)
+		if size > 1 {
+			pAlpha[1].SetBigInt(tau)
+		}
 		for i := 2; i < len(pAlpha); i++ {
 			pAlpha[i].Mul(&pAlpha[i-1], &pAlpha[1])
 		}
@@ -284,7 +292,9 @@ func toLagrange(canonicalSRS kzg.SRS, tau *big.Int) kzg.SRS {
 		// since we know the randomness in test.
 		pAlpha := make([]fr_bls24317.Element, size)
 		pAlpha[0].SetUint64(1)
-		pAlpha[1].SetBigInt(tau)
+		if size > 1 {
+			pAlpha[1].SetBigInt(tau)
+		}
 		for i := 2; i < len(pAlpha); i++ {
 			pAlpha[i].Mul(&pAlpha[i-1], &pAlpha[1])
 		}
@@ -307,7 +317,9 @@ func toLagrange(canonicalSRS kzg.SRS, tau *big.Int) kzg.SRS {
 		// since we know the randomness in test.
 		pAlpha := make([]fr_bls24315.Element, size)
 		pAlpha[0].SetUint64(1)
-		pAlpha[1].SetBigInt(tau)
+		if size > 1 {
+			pAlpha[1].SetBigInt(tau)
+		}
 		for i := 2; i < len(pAlpha); i++ {
 			pAlpha[i].Mul(&pAlpha[i-1], &pAlpha[1])
 		}
@@ -330,7 +342,9 @@ func toLagrange(canonicalSRS kzg.SRS, tau *big.Int) kzg.SRS {
 		// since we know the randomness in test.
 		pAlpha := make([]fr_bw6633.Element, size)
 		pAlpha[0].SetUint64(1)
-		pAlpha[1].SetBigInt(tau)
+		if size > 1 {
+			pAlpha[1].SetBigInt(tau)
+		}
 		for i := 2; i < len(pAlpha); i++ {
 			pAlpha[i].Mul(&pAlpha[i-1], &pAlpha[1])
 		}